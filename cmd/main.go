@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"time"
 
 	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/middleware/auth"
 	"github.com/Risuii/movie/src/middleware/request"
 	"github.com/go-chi/chi/v5"
 
@@ -27,18 +27,37 @@ func main() {
 func startService(ctx context.Context) {
 	address := fmt.Sprintf(":%d", app.Config().BindAddress)
 
+	jwtCfg := app.Config().JWT
+	verifier, err := auth.InitVerifier(auth.Options{
+		Issuer:           jwtCfg.Issuer,
+		Audience:         jwtCfg.Audience,
+		HMACSecret:       jwtCfg.HMACSecret,
+		RSAPublicKeyFile: jwtCfg.RSAPublicKeyFile,
+		JWKSFile:         jwtCfg.JWKSFile,
+		Leeway:           jwtCfg.Leeway,
+	})
+	if err != nil {
+		log.Fatal("init jwt verifier err: ", err)
+	}
+
+	trustedProxies, err := request.ParseTrustedProxies(app.Config().TrustedProxies)
+	if err != nil {
+		log.Fatal("parse trusted proxies err: ", err)
+	}
+
 	r := chi.NewRouter()
 	r.Use(chimiddleware.Recoverer)
 	r.Use(request.RequestIDContext(request.DefaultGenerator))
 	r.Use(request.RequestAttributesContext)
+	r.Use(auth.JWTContext(verifier))
 	r.Use(chimiddleware.Logger)
-	r.Use(chimiddleware.RealIP)
-	r.Use(chimiddleware.Timeout(60 * time.Second))
+	r.Use(request.RealIP(trustedProxies))
 
 	deps := v1.Dependencies(ctx)
 	v1.Router(r, deps)
+	v1.StartJobs(ctx, deps)
 
-	err := http.ListenAndServe(address, r)
+	err = http.ListenAndServe(address, r)
 	if err != nil {
 		log.Println(err)
 	}
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE public.movie_genres;
+DROP TABLE public.genres;
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+CREATE TABLE public.genres (
+    id bigserial PRIMARY KEY,
+    name character varying(100) NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at timestamp with time zone
+);
+
+CREATE UNIQUE INDEX genres_name_key ON public.genres (lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE public.movie_genres (
+    movie_id bigint NOT NULL REFERENCES public.movies (id) ON DELETE CASCADE,
+    genre_id bigint NOT NULL REFERENCES public.genres (id) ON DELETE CASCADE,
+    PRIMARY KEY (movie_id, genre_id)
+);
+
+CREATE INDEX movie_genres_genre_id_idx ON public.movie_genres (genre_id);
+
+-- Seed genres that used to live in movie description
+INSERT INTO public.genres (name) VALUES ('horror'), ('superhero'), ('drama'), ('musical');
+
+INSERT INTO public.movie_genres (movie_id, genre_id)
+SELECT m.id, g.id FROM public.movies m JOIN public.genres g ON g.name = 'horror'
+WHERE m.description ILIKE '%horor%';
+
+INSERT INTO public.movie_genres (movie_id, genre_id)
+SELECT m.id, g.id FROM public.movies m JOIN public.genres g ON g.name = 'superhero'
+WHERE m.description ILIKE '%super hero%';
+
+INSERT INTO public.movie_genres (movie_id, genre_id)
+SELECT m.id, g.id FROM public.movies m JOIN public.genres g ON g.name IN ('drama', 'musical')
+WHERE m.description ILIKE '%drama musical%';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE public.movie_credits;
+DROP TABLE public.people;
+
+COMMIT;
@@ -0,0 +1,38 @@
+BEGIN;
+
+CREATE TABLE public.people (
+    id bigserial PRIMARY KEY,
+    name character varying(255) NOT NULL,
+    biography text NOT NULL DEFAULT '',
+    image character varying(255) NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at timestamp with time zone
+);
+
+CREATE INDEX people_name_idx ON public.people (lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE public.movie_credits (
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL REFERENCES public.movies (id) ON DELETE CASCADE,
+    person_id bigint NOT NULL REFERENCES public.people (id) ON DELETE CASCADE,
+    role character varying(20) NOT NULL CHECK (role IN ('director', 'writer', 'actor')),
+    character_name character varying(255) NOT NULL DEFAULT '',
+    billing_order integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX movie_credits_movie_id_idx ON public.movie_credits (movie_id, role, billing_order);
+CREATE INDEX movie_credits_person_id_idx ON public.movie_credits (person_id);
+
+-- Seed director and writer that used to live in movie description
+INSERT INTO public.people (name) VALUES ('Joko Anwar');
+
+INSERT INTO public.movie_credits (movie_id, person_id, role, billing_order)
+SELECT m.id, p.id, r.role, 0
+FROM public.movies m
+JOIN public.people p ON p.name = 'Joko Anwar'
+CROSS JOIN (VALUES ('director'), ('writer')) AS r (role)
+WHERE m.description ILIKE '%Joko Anwar%';
+
+COMMIT;
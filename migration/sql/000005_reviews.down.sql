@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE public.movies
+    DROP COLUMN average_rating,
+    DROP COLUMN rating_count;
+
+DROP TABLE public.reviews;
+
+COMMIT;
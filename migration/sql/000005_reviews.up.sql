@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE public.reviews (
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL REFERENCES public.movies (id) ON DELETE CASCADE,
+    user_id character varying(255) NOT NULL,
+    score smallint NOT NULL CHECK (score BETWEEN 1 AND 10),
+    content text NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at timestamp with time zone
+);
+
+-- One live review per user on each movie
+CREATE UNIQUE INDEX reviews_movie_id_user_id_key ON public.reviews (movie_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX reviews_movie_id_created_at_idx ON public.reviews (movie_id, created_at DESC) WHERE deleted_at IS NULL;
+
+-- Audience rating aggregated from reviews, rating column stay as editorial rating
+ALTER TABLE public.movies
+    ADD COLUMN average_rating numeric(4, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN rating_count integer NOT NULL DEFAULT 0;
+
+COMMIT;
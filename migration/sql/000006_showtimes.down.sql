@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE public.showtimes;
+DROP TABLE public.screens;
+DROP TABLE public.cinemas;
+
+ALTER TABLE public.movies DROP COLUMN runtime;
+
+COMMIT;
@@ -0,0 +1,52 @@
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Runtime in minutes, showtime end is computed from it
+ALTER TABLE public.movies ADD COLUMN runtime integer NOT NULL DEFAULT 0 CHECK (runtime >= 0);
+
+CREATE TABLE public.cinemas (
+    id bigserial PRIMARY KEY,
+    name character varying(255) NOT NULL,
+    city character varying(100) NOT NULL,
+    address text NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at timestamp with time zone
+);
+
+CREATE INDEX cinemas_city_idx ON public.cinemas (lower(city)) WHERE deleted_at IS NULL;
+
+CREATE TABLE public.screens (
+    id bigserial PRIMARY KEY,
+    cinema_id bigint NOT NULL REFERENCES public.cinemas (id) ON DELETE CASCADE,
+    name character varying(100) NOT NULL,
+    seat_map jsonb NOT NULL DEFAULT '{"rows": []}',
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at timestamp with time zone
+);
+
+CREATE UNIQUE INDEX screens_cinema_id_name_key ON public.screens (cinema_id, lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE public.showtimes (
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL REFERENCES public.movies (id) ON DELETE CASCADE,
+    screen_id bigint NOT NULL REFERENCES public.screens (id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    price bigint NOT NULL CHECK (price >= 0),
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at timestamp with time zone,
+    CHECK (end_time > start_time),
+    -- Two live showtimes on the same screen may not overlap
+    CONSTRAINT showtimes_screen_id_overlap_excl EXCLUDE USING gist (
+        screen_id WITH =,
+        tstzrange(start_time, end_time) WITH &&
+    ) WHERE (deleted_at IS NULL)
+);
+
+CREATE INDEX showtimes_movie_id_start_time_idx ON public.showtimes (movie_id, start_time) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE public.booking_seats;
+DROP TABLE public.bookings;
+
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TABLE public.bookings (
+    id bigserial PRIMARY KEY,
+    showtime_id bigint NOT NULL REFERENCES public.showtimes (id) ON DELETE CASCADE,
+    user_id character varying(255) NOT NULL,
+    -- hold_id is the seat hold confirmed into this booking, a hold is confirmed at most once
+    hold_id uuid NOT NULL UNIQUE,
+    total_price bigint NOT NULL CHECK (total_price >= 0),
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX bookings_user_id_idx ON public.bookings (user_id, created_at DESC);
+
+CREATE TABLE public.booking_seats (
+    id bigserial PRIMARY KEY,
+    booking_id bigint NOT NULL REFERENCES public.bookings (id) ON DELETE CASCADE,
+    showtime_id bigint NOT NULL REFERENCES public.showtimes (id) ON DELETE CASCADE,
+    seat character varying(10) NOT NULL,
+    -- A seat is booked at most once for each showtime, even when its hold expired
+    CONSTRAINT booking_seats_showtime_id_seat_key UNIQUE (showtime_id, seat)
+);
+
+CREATE INDEX booking_seats_booking_id_idx ON public.booking_seats (booking_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE public.movies DROP COLUMN image_keys;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Storage key of uploaded poster per size, e.g. {"original": "movies/1/<uuid>/original.jpg"},
+-- image column stay as free text url for movie without upload
+ALTER TABLE public.movies ADD COLUMN image_keys jsonb NOT NULL DEFAULT '{}';
+
+COMMIT;
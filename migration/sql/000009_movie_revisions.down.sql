@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE public.movie_revisions;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Revision of every movie mutation, before is null for create and
+-- snapshot hold the editable field and linked genre id
+CREATE TABLE IF NOT EXISTS public.movie_revisions
+(
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL REFERENCES public.movies (id) ON DELETE CASCADE,
+    revision integer NOT NULL,
+    action character varying(20) NOT NULL,
+    actor character varying(255) NOT NULL DEFAULT '',
+    request_id character varying(100) NOT NULL DEFAULT '',
+    before jsonb,
+    after jsonb NOT NULL,
+    diff jsonb NOT NULL DEFAULT '{}',
+    revert_of integer,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT movie_revisions_movie_id_revision_key UNIQUE (movie_id, revision)
+);
+
+COMMIT;
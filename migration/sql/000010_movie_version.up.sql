@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Version of the movie representation, it is increased by every write of the
+-- movie row and returned as ETag for optimistic concurrency
+ALTER TABLE public.movies ADD COLUMN version integer NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX public.movies_title_trgm_idx;
+DROP INDEX public.movies_normalized_title_key;
+
+COMMIT;
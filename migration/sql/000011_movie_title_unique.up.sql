@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Movies whose titles are equal after normalization keep the lowest id, the others are
+-- soft deleted so the unique index can be built. They are listed by GET /Movies/deleted
+-- and can be renamed then restored
+UPDATE public.movies m
+SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP, version = m.version + 1
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY lower(btrim(regexp_replace(title, '[\t\n\v\f\r \u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+', ' ', 'g'), ' ')) ORDER BY id) AS n
+    FROM public.movies
+    WHERE deleted_at IS NULL
+) duplicate
+WHERE m.id = duplicate.id AND duplicate.n > 1;
+
+-- Title is unique after normalization, the expression must match
+-- contract.NormalizeTitle: the class is every rune of Go unicode.IsSpace because
+-- \s of postgres depends on the locale. Release year is to be added to the key once
+-- movie has it. Soft deleted movie does not reserve its title
+CREATE UNIQUE INDEX movies_normalized_title_key ON public.movies (lower(btrim(regexp_replace(title, '[\t\n\v\f\r \u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+', ' ', 'g'), ' '))) WHERE deleted_at IS NULL;
+
+-- Trigram index for near duplicate title report
+CREATE INDEX movies_title_trgm_idx ON public.movies USING gin (lower(title) gin_trgm_ops) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX public.movies_search_vector_idx;
+ALTER TABLE public.movies DROP COLUMN search_vector;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Full text search vector of title (weight A) and description (weight B).
+-- Lexeme of every supported text search configuration is kept in the one
+-- column so query built with any of them match, see contract.SearchConfigs
+ALTER TABLE public.movies ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english'::regconfig, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('indonesian'::regconfig, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('indonesian'::regconfig, coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX movies_search_vector_idx ON public.movies USING gin (search_vector) WHERE deleted_at IS NULL;
+
+COMMIT;
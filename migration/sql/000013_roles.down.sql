@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE public.role_permissions;
+DROP TABLE public.roles;
+
+COMMIT;
@@ -0,0 +1,46 @@
+BEGIN;
+
+CREATE TABLE public.roles (
+    name character varying(50) PRIMARY KEY,
+    description text NOT NULL DEFAULT '',
+    built_in boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- Permission is checked by the Authorize middleware, the value must be one of entity.Permissions
+CREATE TABLE public.role_permissions (
+    role character varying(50) NOT NULL REFERENCES public.roles (name) ON DELETE CASCADE,
+    permission character varying(50) NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO public.roles (name, description, built_in) VALUES
+    ('viewer', 'Read the catalog', true),
+    ('editor', 'Create and edit movie, genre and people', true),
+    ('publisher', 'Edit, delete and import movie and manage cinema schedule', true),
+    ('admin', 'Every permission including role management', true);
+
+INSERT INTO public.role_permissions (role, permission) VALUES
+    ('editor', 'movie:create'),
+    ('editor', 'movie:update'),
+    ('editor', 'movie:audit'),
+    ('editor', 'catalog:manage'),
+    ('publisher', 'movie:create'),
+    ('publisher', 'movie:update'),
+    ('publisher', 'movie:delete'),
+    ('publisher', 'import:run'),
+    ('publisher', 'movie:audit'),
+    ('publisher', 'catalog:manage'),
+    ('publisher', 'cinema:manage'),
+    ('admin', 'movie:create'),
+    ('admin', 'movie:update'),
+    ('admin', 'movie:delete'),
+    ('admin', 'movie:purge'),
+    ('admin', 'import:run'),
+    ('admin', 'movie:audit'),
+    ('admin', 'catalog:manage'),
+    ('admin', 'cinema:manage'),
+    ('admin', 'role:manage');
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DELETE FROM public.role_permissions WHERE permission = 'apikey:manage';
+DROP TABLE public.api_keys;
+
+COMMIT;
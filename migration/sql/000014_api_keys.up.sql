@@ -0,0 +1,24 @@
+BEGIN;
+
+-- Key is mk_<prefix>_<secret>, prefix find the key and only sha256 of the secret is stored.
+-- Scopes is permission granted to the key, the value must be one of entity.Permissions
+CREATE TABLE public.api_keys (
+    id bigserial PRIMARY KEY,
+    name character varying(100) NOT NULL,
+    owner character varying(255) NOT NULL,
+    prefix character varying(16) NOT NULL UNIQUE,
+    secret_hash character(64) NOT NULL,
+    scopes text[] NOT NULL DEFAULT '{}',
+    daily_quota bigint NOT NULL CHECK (daily_quota > 0),
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX api_keys_owner_idx ON public.api_keys (owner);
+
+INSERT INTO public.role_permissions (role, permission) VALUES ('admin', 'apikey:manage');
+
+COMMIT;
@@ -31,15 +31,60 @@ type (
 		Password string `mapstructure:"REDIS_PASSWORD"`
 	}
 
+	Booking struct {
+		SeatHoldDuration time.Duration `mapstructure:"SEAT_HOLD_DURATION"` //Optional, default to '0s' which is replaced by 10m in booking service
+	}
+
+	Storage struct {
+		LocalDir string `mapstructure:"STORAGE_LOCAL_DIR"` //Optional, default to 'uploads' in storage
+		BaseURL  string `mapstructure:"STORAGE_BASE_URL"`  //Optional, default to '/uploads' which is served by the router
+	}
+
+	Movie struct {
+		RetentionDays  int  `mapstructure:"MOVIE_RETENTION_DAYS"`   //Optional, default to 0 which keep soft deleted movie forever
+		RequireIfMatch bool `mapstructure:"MOVIE_REQUIRE_IF_MATCH"` //Optional, default to false which allow update and delete without If-Match
+	}
+
+	Idempotency struct {
+		KeyTTL time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"` //Optional, default to '0s' which is replaced by 24h in idempotency middleware
+	}
+
+	JWT struct {
+		Issuer           string        `mapstructure:"JWT_ISSUER" validate:"required"`
+		Audience         string        `mapstructure:"JWT_AUDIENCE" validate:"required"`
+		HMACSecret       string        `mapstructure:"JWT_HMAC_SECRET"`         //Optional, HS256 token is rejected when no secret is set
+		RSAPublicKeyFile string        `mapstructure:"JWT_RSA_PUBLIC_KEY_FILE"` //Optional, PEM file verifying RS256 token without kid
+		JWKSFile         string        `mapstructure:"JWT_JWKS_FILE"`           //Optional, local JWKS file, RS256 key is matched by kid
+		Leeway           time.Duration `mapstructure:"JWT_LEEWAY"`              //Optional, default to '0s' which allow no clock skew on exp and nbf
+	}
+
+	RateLimit struct {
+		Disabled bool   `mapstructure:"RATE_LIMIT_DISABLED"` //Optional, default to false which limit every route group
+		Default  string `mapstructure:"RATE_LIMIT_DEFAULT"`  //Optional, default to '' which is replaced by '300/1m,ip:120/1m' in ratelimit middleware
+		Movies   string `mapstructure:"RATE_LIMIT_MOVIES"`   //Optional, default to '' which use RATE_LIMIT_DEFAULT
+		Catalog  string `mapstructure:"RATE_LIMIT_CATALOG"`  //Optional, genre and people route, default to '' which use RATE_LIMIT_DEFAULT
+		Cinemas  string `mapstructure:"RATE_LIMIT_CINEMAS"`  //Optional, cinema, screen and showtime route, default to '' which use RATE_LIMIT_DEFAULT
+		Booking  string `mapstructure:"RATE_LIMIT_BOOKING"`  //Optional, hold and booking route, default to '' which use RATE_LIMIT_DEFAULT
+		Admin    string `mapstructure:"RATE_LIMIT_ADMIN"`    //Optional, role and api key route, default to '' which use RATE_LIMIT_DEFAULT
+	}
+
 	Configuration struct {
 		ServiceName string      `mapstructure:"SERVICE_NAME"`
 		Postgres    Postgres    `mapstructure:",squash"`
 		Redis       Redis       `mapstructure:",squash"`
 		Translation Translation `mapstructure:",squash"`
+		Booking     Booking     `mapstructure:",squash"`
+		Storage     Storage     `mapstructure:",squash"`
+		Movie       Movie       `mapstructure:",squash"`
+		Idempotency Idempotency `mapstructure:",squash"`
+		JWT         JWT         `mapstructure:",squash"`
+		RateLimit   RateLimit   `mapstructure:",squash"`
 
 		Environment string `mapstructure:"ENV" validate:"required,oneof=development staging production"`
 		BindAddress int    `mapstructure:"BIND_ADDRESS" validate:"required"`
 		LogLevel    int    `mapstructure:"LOG_LEVEL" validate:"required"`
+		// TrustedProxies is ip or cidr of the proxies in front of the service, e.g. 10.0.0.0/8
+		TrustedProxies string `mapstructure:"TRUSTED_PROXIES"` //Optional, default to '' which ignore X-Forwarded-For and X-Real-IP
 	}
 )
 
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKey authenticate a partner without interactive login, the secret is
+// only returned when the key is issued or rotated
+type APIKey struct {
+	ModelID
+	Name       string         `db:"name"`
+	Owner      string         `db:"owner"`
+	Prefix     string         `db:"prefix"`
+	SecretHash string         `db:"secret_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	DailyQuota int64          `db:"daily_quota"`
+	ExpiresAt  *time.Time     `db:"expires_at"`
+	LastUsedAt *time.Time     `db:"last_used_at"`
+	RevokedAt  *time.Time     `db:"revoked_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+// Active report whether the key is not revoked nor expired at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// NextQuotaReset is the start of the next UTC day, daily quota of every key is reset then
+func NextQuotaReset(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
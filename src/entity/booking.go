@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Hold is seats of a showtime reserved for a user until ExpiresAt,
+// it only lives in redis and is confirmed into a Booking
+type Hold struct {
+	ID         string    `json:"id"`
+	ShowtimeID int64     `json:"showtime_id"`
+	UserID     string    `json:"user_id"`
+	Seats      []string  `json:"seats"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type Booking struct {
+	ModelID
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	BookingData
+
+	// Seats is read only and maintained through booking_seats table
+	Seats pq.StringArray `db:"seats"`
+}
+
+type BookingData struct {
+	ShowtimeID int64  `db:"showtime_id"`
+	UserID     string `db:"user_id"`
+	HoldID     string `db:"hold_id"`
+	TotalPrice int64  `db:"total_price"`
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Cinema struct {
+	ModelID
+	ModelLogTime
+	CinemaData
+}
+
+type CinemaData struct {
+	Name    string `db:"name"`
+	City    string `db:"city"`
+	Address string `db:"address"`
+}
+
+type Screen struct {
+	ModelID
+	ModelLogTime
+	ScreenData
+}
+
+type ScreenData struct {
+	CinemaID int64   `db:"cinema_id"`
+	Name     string  `db:"name"`
+	SeatMap  SeatMap `db:"seat_map"`
+}
+
+// SeatMap is the seat layout of a screen, row is named by letter and seat is
+// named by row and number starting from one, e.g. A1 is the first seat of row A
+type SeatMap struct {
+	Rows []SeatRow `json:"rows"`
+}
+
+type SeatRow struct {
+	Row   string `json:"row"`
+	Seats int    `json:"seats"`
+}
+
+// Capacity return number of seat in the screen
+func (m SeatMap) Capacity() int {
+	var capacity int
+	for _, r := range m.Rows {
+		capacity += r.Seats
+	}
+	return capacity
+}
+
+// SeatIDs return every seat of the screen ordered by row then number
+func (m SeatMap) SeatIDs() []string {
+	seats := make([]string, 0, m.Capacity())
+	for _, r := range m.Rows {
+		for n := 1; n <= r.Seats; n++ {
+			seats = append(seats, r.Row+strconv.Itoa(n))
+		}
+	}
+	return seats
+}
+
+// Has report whether seat, e.g. A1, exist in the screen
+func (m SeatMap) Has(seat string) bool {
+	for _, r := range m.Rows {
+		if !strings.HasPrefix(seat, r.Row) {
+			continue
+		}
+
+		n, err := strconv.Atoi(seat[len(r.Row):])
+		if err == nil && n >= 1 && n <= r.Seats && seat == r.Row+strconv.Itoa(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value store seat map as jsonb
+func (m SeatMap) Value() (driver.Value, error) {
+	if m.Rows == nil {
+		m.Rows = []SeatRow{}
+	}
+	return json.Marshal(m)
+}
+
+func (m *SeatMap) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	case nil:
+		*m = SeatMap{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported seat map type: %T", v)
+	}
+}
@@ -0,0 +1,11 @@
+package entity
+
+type Genre struct {
+	ModelID
+	ModelLogTime
+	GenreData
+}
+
+type GenreData struct {
+	Name string `db:"name"`
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+
+	ImportRowCreated = "created"
+	ImportRowUpdated = "updated"
+	ImportRowSkipped = "skipped"
+	ImportRowError   = "error"
+)
+
+// ImportJob is progress and per row report of a movie import, it only lives
+// in redis so the status can be read from any instance. UpdatedAt is set on
+// every save so a job whose instance stopped can be told from a running one
+type ImportJob struct {
+	ID         string            `json:"id"`
+	Status     string            `json:"status"`
+	DryRun     bool              `json:"dry_run"`
+	Total      int               `json:"total"`
+	Processed  int               `json:"processed"`
+	Rows       []ImportRowResult `json:"rows"`
+	Error      string            `json:"error"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	FinishedAt *time.Time        `json:"finished_at"`
+}
+
+// ImportRowResult is outcome of a row, Line is line number in the file
+type ImportRowResult struct {
+	Line    int    `json:"line"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	MovieID int64  `json:"movie_id"`
+	Reason  string `json:"reason"`
+}
@@ -1,9 +1,37 @@
 package entity
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ImageSizeOriginal = "original"
+	ImageSizeSmall    = "small"
+	ImageSizeMedium   = "medium"
+	ImageSizeLarge    = "large"
+)
+
 type Movie struct {
 	ModelID
 	ModelLogTime
 	MovieData
+
+	// Genres is name of genre linked to the movie, it is read only
+	// and maintained through movie_genres table
+	Genres pq.StringArray `db:"genres"`
+
+	// ImageKeys is storage key of uploaded poster per size, it is only
+	// written by poster upload
+	ImageKeys ImageKeys `db:"image_keys"`
+
+	// Version is increased by every write of the movie row, it is the ETag of the movie
+	Version int `db:"version"`
+
+	MovieRating
 }
 
 type MovieData struct {
@@ -11,4 +39,60 @@ type MovieData struct {
 	Description string  `db:"description"`
 	Rating      float32 `db:"rating"`
 	Image       string  `db:"image"`
+	Runtime     int     `db:"runtime"`
+}
+
+// MovieSearchResult is a movie matching full text search with its rank, highlight
+// wrap matched word with contract.SearchHighlightStart and contract.SearchHighlightStop
+type MovieSearchResult struct {
+	Movie
+
+	Rank                 float32 `db:"rank"`
+	TitleHighlight       string  `db:"title_highlight"`
+	DescriptionHighlight string  `db:"description_highlight"`
+}
+
+// MovieSuggestion is a title matching typeahead query, Score combine prefix match,
+// title similarity, rating and number of review
+type MovieSuggestion struct {
+	ModelID
+
+	Title  string  `db:"title"`
+	Image  string  `db:"image"`
+	Rating float32 `db:"rating"`
+	Score  float32 `db:"score"`
+}
+
+// MovieDuplicate is a pair of movie with similar title, MovieID is lower than DuplicateID
+type MovieDuplicate struct {
+	MovieID        int64   `db:"movie_id"`
+	MovieTitle     string  `db:"movie_title"`
+	DuplicateID    int64   `db:"duplicate_id"`
+	DuplicateTitle string  `db:"duplicate_title"`
+	Similarity     float64 `db:"similarity"`
+}
+
+// ImageKeys map image size, e.g. ImageSizeSmall, to its storage key
+type ImageKeys map[string]string
+
+// Value store image keys as jsonb
+func (k ImageKeys) Value() (driver.Value, error) {
+	if k == nil {
+		k = ImageKeys{}
+	}
+	return json.Marshal(k)
+}
+
+func (k *ImageKeys) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, k)
+	case string:
+		return json.Unmarshal([]byte(v), k)
+	case nil:
+		*k = ImageKeys{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported image keys type: %T", v)
+	}
 }
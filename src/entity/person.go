@@ -0,0 +1,33 @@
+package entity
+
+const (
+	CreditRoleDirector = "director"
+	CreditRoleWriter   = "writer"
+	CreditRoleActor    = "actor"
+)
+
+type Person struct {
+	ModelID
+	ModelLogTime
+	PersonData
+}
+
+type PersonData struct {
+	Name      string `db:"name"`
+	Biography string `db:"biography"`
+	Image     string `db:"image"`
+}
+
+// MovieCredit is a person credited on a movie, PersonName and MovieTitle
+// is read only and joined from people and movies table
+type MovieCredit struct {
+	ModelID
+	MovieID       int64  `db:"movie_id"`
+	PersonID      int64  `db:"person_id"`
+	Role          string `db:"role"`
+	CharacterName string `db:"character_name"`
+	BillingOrder  int    `db:"billing_order"`
+
+	PersonName string `db:"person_name"`
+	MovieTitle string `db:"movie_title"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+type Review struct {
+	ModelID
+	ModelLogTime
+	ReviewData
+}
+
+type ReviewData struct {
+	MovieID int64  `db:"movie_id"`
+	UserID  string `db:"user_id"`
+	Score   int    `db:"score"`
+	Content string `db:"content"`
+}
+
+// MovieRating is audience rating aggregated from reviews, it is read only
+// and maintained by review repository
+type MovieRating struct {
+	AverageRating float32 `db:"average_rating"`
+	RatingCount   int64   `db:"rating_count"`
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionDelete  = "delete"
+	RevisionActionRestore = "restore"
+	RevisionActionRevert  = "revert"
+)
+
+type MovieRevision struct {
+	ModelID
+	MovieID   int64  `db:"movie_id"`
+	Revision  int    `db:"revision"`
+	Action    string `db:"action"`
+	Actor     string `db:"actor"`
+	RequestID string `db:"request_id"`
+
+	// Before is nil for create
+	Before *MovieSnapshot `db:"before"`
+	After  MovieSnapshot  `db:"after"`
+	Diff   RevisionDiff   `db:"diff"`
+
+	// RevertOf is the revision restored by a revert
+	RevertOf  *int      `db:"revert_of"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// MovieSnapshot is the editable state of a movie kept in a revision
+type MovieSnapshot struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Rating      float32 `json:"rating"`
+	Image       string  `json:"image"`
+	Runtime     int     `json:"runtime"`
+	GenreIDs    []int64 `json:"genre_ids"`
+	Deleted     bool    `json:"deleted"`
+	Version     int     `json:"version"`
+}
+
+// FieldChange hold json value of a snapshot field, From is null for create
+type FieldChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// RevisionDiff map snapshot json field to its change
+type RevisionDiff map[string]FieldChange
+
+// DiffSnapshots return every field of after that differ from before
+func DiffSnapshots(before *MovieSnapshot, after MovieSnapshot) (RevisionDiff, error) {
+	var from, to map[string]json.RawMessage
+
+	if before != nil {
+		if err := remarshal(before, &from); err != nil {
+			return nil, err
+		}
+	}
+	if err := remarshal(after, &to); err != nil {
+		return nil, err
+	}
+
+	diff := RevisionDiff{}
+	for field, value := range to {
+		old, ok := from[field]
+		if ok && bytes.Equal(old, value) {
+			continue
+		}
+		if !ok {
+			old = json.RawMessage("null")
+		}
+		diff[field] = FieldChange{From: old, To: value}
+	}
+
+	return diff, nil
+}
+
+func remarshal(src interface{}, dst *map[string]json.RawMessage) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// Value store snapshot as jsonb
+func (s MovieSnapshot) Value() (driver.Value, error) {
+	if s.GenreIDs == nil {
+		s.GenreIDs = []int64{}
+	}
+	return json.Marshal(s)
+}
+
+func (s *MovieSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported movie snapshot type: %T", v)
+	}
+}
+
+// Value store diff as jsonb
+func (d RevisionDiff) Value() (driver.Value, error) {
+	if d == nil {
+		d = RevisionDiff{}
+	}
+	return json.Marshal(d)
+}
+
+func (d *RevisionDiff) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	case nil:
+		*d = RevisionDiff{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported revision diff type: %T", v)
+	}
+}
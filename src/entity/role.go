@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// Built in role, a token without roles claim is a viewer
+	RoleViewer    = "viewer"
+	RoleEditor    = "editor"
+	RolePublisher = "publisher"
+	RoleAdmin     = "admin"
+
+	PermissionMovieCreate = "movie:create"
+	PermissionMovieUpdate = "movie:update"
+	PermissionMovieDelete = "movie:delete"
+	PermissionMoviePurge  = "movie:purge"
+	PermissionImportRun   = "import:run"
+	PermissionRoleManage  = "role:manage"
+
+	// PermissionMovieAudit read revision history and deleted movie, history has the actor and request id
+	PermissionMovieAudit = "movie:audit"
+	// PermissionCatalogManage create, update and delete genre and people
+	PermissionCatalogManage = "catalog:manage"
+	// PermissionCinemaManage create, update and delete cinema, screen and showtime
+	PermissionCinemaManage = "cinema:manage"
+
+	// PermissionAPIKeyManage issue, rotate and revoke api key of partner
+	PermissionAPIKeyManage = "apikey:manage"
+)
+
+// Permissions is every permission that can be granted to a role
+var Permissions = []string{
+	PermissionMovieCreate,
+	PermissionMovieUpdate,
+	PermissionMovieDelete,
+	PermissionMoviePurge,
+	PermissionImportRun,
+	PermissionRoleManage,
+	PermissionMovieAudit,
+	PermissionCatalogManage,
+	PermissionCinemaManage,
+	PermissionAPIKeyManage,
+}
+
+type Role struct {
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	BuiltIn     bool      `db:"built_in"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+
+	// Permissions is read only and maintained through role_permissions table
+	Permissions pq.StringArray `db:"permissions"`
+}
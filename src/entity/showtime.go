@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Showtime is a movie played on a screen, MovieTitle, ScreenName, CinemaID,
+// CinemaName and City is read only and joined from movies, screens and cinemas table
+type Showtime struct {
+	ModelID
+	ModelLogTime
+	ShowtimeData
+
+	MovieTitle string `db:"movie_title"`
+	ScreenName string `db:"screen_name"`
+	CinemaID   int64  `db:"cinema_id"`
+	CinemaName string `db:"cinema_name"`
+	City       string `db:"city"`
+}
+
+type ShowtimeData struct {
+	MovieID   int64     `db:"movie_id"`
+	ScreenID  int64     `db:"screen_id"`
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+	// Price is in the smallest unit of the currency
+	Price int64 `db:"price"`
+}
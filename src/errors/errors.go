@@ -5,6 +5,62 @@ import (
 )
 
 var (
-	ErrMovieIdNotFound = i18n_err.NewI18nError("err_movie_id_not_found")
-	ErrDuplicatemovie  = i18n_err.NewI18nError("err_movie_duplicate")
+	ErrMovieIdNotFound  = i18n_err.NewI18nError("err_movie_id_not_found")
+	ErrDuplicatemovie   = i18n_err.NewI18nError("err_movie_duplicate")
+	ErrMovieHasBookings = i18n_err.NewI18nError("err_movie_has_bookings")
+
+	ErrMovieRevisionNotFound = i18n_err.NewI18nError("err_movie_revision_not_found")
+	ErrImportJobNotFound     = i18n_err.NewI18nError("err_import_job_not_found")
+	ErrImportTooLarge        = i18n_err.NewI18nError("err_import_too_large")
+	ErrImportBusy            = i18n_err.NewI18nError("err_import_busy")
+
+	ErrPreconditionFailed   = i18n_err.NewI18nError("err_precondition_failed")
+	ErrPreconditionRequired = i18n_err.NewI18nError("err_precondition_required")
+
+	ErrUnsupportedContentType = i18n_err.NewI18nError("err_unsupported_content_type")
+
+	ErrForbidden = i18n_err.NewI18nError("err_forbidden")
+
+	ErrRoleNotFound     = i18n_err.NewI18nError("err_role_not_found")
+	ErrDuplicateRole    = i18n_err.NewI18nError("err_role_duplicate")
+	ErrRoleBuiltIn      = i18n_err.NewI18nError("err_role_built_in")
+	ErrRoleAdminLockout = i18n_err.NewI18nError("err_role_admin_lockout")
+
+	ErrAPIKeyNotFound      = i18n_err.NewI18nError("err_api_key_not_found")
+	ErrAPIKeyRevoked       = i18n_err.NewI18nError("err_api_key_revoked")
+	ErrInvalidAPIKey       = i18n_err.NewI18nError("err_api_key_invalid")
+	ErrAPIKeyQuotaExceeded = i18n_err.NewI18nError("err_api_key_quota_exceeded")
+
+	ErrRateLimitExceeded = i18n_err.NewI18nError("err_rate_limit_exceeded")
+
+	ErrIdempotencyKeyMismatch   = i18n_err.NewI18nError("err_idempotency_key_mismatch")
+	ErrIdempotencyKeyInProgress = i18n_err.NewI18nError("err_idempotency_key_in_progress")
+
+	ErrRequestTooLarge = i18n_err.NewI18nError("err_request_too_large")
+
+	ErrImageTooLarge        = i18n_err.NewI18nError("err_image_too_large")
+	ErrUnsupportedImageType = i18n_err.NewI18nError("err_image_unsupported_type")
+	ErrInvalidImage         = i18n_err.NewI18nError("err_image_invalid")
+
+	ErrGenreIdNotFound = i18n_err.NewI18nError("err_genre_id_not_found")
+	ErrDuplicateGenre  = i18n_err.NewI18nError("err_genre_duplicate")
+
+	ErrPersonIdNotFound = i18n_err.NewI18nError("err_person_id_not_found")
+
+	ErrReviewIdNotFound = i18n_err.NewI18nError("err_review_id_not_found")
+	ErrDuplicateReview  = i18n_err.NewI18nError("err_review_duplicate")
+
+	ErrCinemaIdNotFound = i18n_err.NewI18nError("err_cinema_id_not_found")
+	ErrScreenIdNotFound = i18n_err.NewI18nError("err_screen_id_not_found")
+	ErrDuplicateScreen  = i18n_err.NewI18nError("err_screen_duplicate")
+
+	ErrShowtimeIdNotFound = i18n_err.NewI18nError("err_showtime_id_not_found")
+	ErrShowtimeOverlap    = i18n_err.NewI18nError("err_showtime_overlap")
+	ErrMovieRuntimeNotSet = i18n_err.NewI18nError("err_movie_runtime_not_set")
+	ErrShowtimeStarted    = i18n_err.NewI18nError("err_showtime_started")
+
+	ErrSeatNotFound      = i18n_err.NewI18nError("err_seat_not_found")
+	ErrSeatTaken         = i18n_err.NewI18nError("err_seat_taken")
+	ErrHoldIdNotFound    = i18n_err.NewI18nError("err_hold_id_not_found")
+	ErrBookingIdNotFound = i18n_err.NewI18nError("err_booking_id_not_found")
 )
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/middleware/response"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+const (
+	APIKeyHeader  = "X-API-Key"
+	apiKeyIDClaim = "api_key_id"
+)
+
+// APIKeyService authenticate the key of a partner and count it in the daily quota
+type APIKeyService interface {
+	Authenticate(ctx context.Context, key string) (entity.APIKey, error)
+}
+
+// APIKeyContext authenticate request with X-API-Key header, it must run after JWTContext and the rate limiter.
+// The owner of the key is the subject and its scopes is the permission checked by Authorize,
+// so a key is never resolved through roles. Request without the header is passed through
+func APIKeyContext(svc APIKeyService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			plain := r.Header.Get(APIKeyHeader)
+			if plain == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if GetClaims(ctx) != nil {
+				log.Println("api key err: request has both bearer token and api key")
+				unauthorized(ctx, w, "invalid_request")
+				return
+			}
+
+			key, err := svc.Authenticate(ctx, plain)
+			if err != nil {
+				switch err {
+				case appErr.ErrInvalidAPIKey:
+					response.JSONUnauthorizedResponse(ctx, w)
+				case appErr.ErrAPIKeyQuotaExceeded:
+					now := time.Now()
+					retryAfter := int(entity.NextQuotaReset(now).Sub(now).Seconds()) + 1
+					w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+					response.JSONError(ctx, w, http.StatusTooManyRequests, err)
+				default:
+					response.JSONInternalErrorResponse(ctx, w)
+				}
+				return
+			}
+
+			scopes := []string(key.Scopes)
+			claims := &Claims{
+				Subject: key.Owner,
+				Raw: map[string]interface{}{
+					apiKeyIDClaim: key.Id,
+					"owner":       key.Owner,
+					"scopes":      scopes,
+				},
+			}
+
+			r = withClaims(r, claims)
+			r = r.WithContext(context.WithValue(r.Context(), CtxKeyPermissions, scopes))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GetAPIKeyID return id of the api key that authenticate the request, ok is false for token and anonymous request
+func GetAPIKeyID(ctx context.Context) (id int64, ok bool) {
+	if claims := GetClaims(ctx); claims != nil {
+		id, ok = claims.Raw[apiKeyIDClaim].(int64)
+	}
+	return
+}
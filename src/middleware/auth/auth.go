@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Risuii/movie/src/middleware/response"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	userIDHeader        = "X-User-ID"
+)
+
+type ctxKeyClaims struct{}
+
+var CtxKeyClaims = ctxKeyClaims{}
+
+// JWTContext verify the bearer token and put its claims in the context, request without
+// token is passed through as anonymous so route decide whether it need RequireAuth.
+// Token that fail verification is rejected even on public route.
+// Subject of the token replace X-User-ID and the header is removed from anonymous request,
+// so the user can not be spoofed by the header
+func JWTContext(verifier *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			authorization := r.Header.Get(authorizationHeader)
+			if authorization == "" {
+				next.ServeHTTP(w, anonymous(r))
+				return
+			}
+
+			if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+				log.Println("authorization header err: not a bearer token")
+				unauthorized(ctx, w, "invalid_request")
+				return
+			}
+
+			claims, err := verifier.Verify(strings.TrimSpace(authorization[len(bearerPrefix):]))
+			if err != nil {
+				log.Println("verify token err: ", err)
+				unauthorized(ctx, w, "invalid_token")
+				return
+			}
+
+			next.ServeHTTP(w, withClaims(r, claims))
+		})
+	}
+}
+
+// withClaims put the claims in the context of the request, GetSubject is the only way to read the user of a request.
+// X-User-ID is overwritten so a proxy or log reading the header see the same user
+func withClaims(r *http.Request, claims *Claims) *http.Request {
+	r.Header.Set(userIDHeader, claims.Subject)
+	return r.WithContext(context.WithValue(r.Context(), CtxKeyClaims, claims))
+}
+
+// anonymous remove the user sent by the client, the user of a request only come from a verified token or api key
+func anonymous(r *http.Request) *http.Request {
+	r.Header.Del(userIDHeader)
+	return r
+}
+
+// RequireAuth reject request without a verified token or api key, it is used on route that mutate data
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetClaims(r.Context()) == nil {
+			unauthorized(r.Context(), w, "")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func unauthorized(ctx context.Context, w http.ResponseWriter, code string) {
+	challenge := "Bearer"
+	if code != "" {
+		challenge += ` error="` + code + `"`
+	}
+
+	w.Header().Set("WWW-Authenticate", challenge)
+	response.JSONUnauthorizedResponse(ctx, w)
+}
+
+// GetClaims return claims of the verified token, it is nil for anonymous request
+func GetClaims(ctx context.Context) *Claims {
+	if v, ok := ctx.Value(CtxKeyClaims).(*Claims); ok {
+		return v
+	}
+	return nil
+}
+
+// GetSubject return subject of the verified token, it is empty for anonymous request
+func GetSubject(ctx context.Context) string {
+	if claims := GetClaims(ctx); claims != nil {
+		return claims.Subject
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/middleware/response"
+)
+
+// PermissionService resolve permission granted to roles, the mapping is kept in the database
+type PermissionService interface {
+	GetPermissions(ctx context.Context, roles []string) (permissions []string, err error)
+}
+
+type ctxKeyPermissions struct{}
+
+var CtxKeyPermissions = ctxKeyPermissions{}
+
+type Authorizer struct {
+	svc PermissionService
+}
+
+func InitAuthorizer(svc PermissionService) *Authorizer {
+	return &Authorizer{svc: svc}
+}
+
+// Authorize reject request whose roles does not grant the permission, anonymous request is 401
+// and authenticated one is 403. Token without roles claim is a viewer and api key use its scopes
+// that APIKeyContext already put in the context. Permission is resolved
+// once per request and kept in the context, so Authorize can be chained and handler can
+// check a permission that depend on the request with HasPermission
+func (a *Authorizer) Authorize(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			claims := GetClaims(ctx)
+			if claims == nil {
+				unauthorized(ctx, w, "")
+				return
+			}
+
+			permissions, ok := ctx.Value(CtxKeyPermissions).([]string)
+			if !ok {
+				roles := []string(claims.Roles)
+				if len(roles) == 0 {
+					roles = []string{entity.RoleViewer}
+				}
+
+				var err error
+				permissions, err = a.svc.GetPermissions(ctx, roles)
+				if err != nil {
+					log.Println("get permissions err: ", err)
+					response.JSONInternalErrorResponse(ctx, w)
+					return
+				}
+
+				ctx = context.WithValue(ctx, CtxKeyPermissions, permissions)
+			}
+
+			if !HasPermission(ctx, permission) {
+				log.Println("forbidden: ", claims.Subject, " has no ", permission)
+				response.JSONForbiddenResponse(ctx, w)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// HasPermission report whether the permission is granted to the request, it is false
+// on route without Authorize
+func HasPermission(ctx context.Context, permission string) bool {
+	permissions, _ := ctx.Value(CtxKeyPermissions).([]string)
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
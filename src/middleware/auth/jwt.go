@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+var (
+	ErrMalformedToken   = errors.New("token is malformed")
+	ErrUnsupportedAlg   = errors.New("token alg must be HS256 or RS256")
+	ErrUnknownKey       = errors.New("no key to verify the token")
+	ErrInvalidSignature = errors.New("token signature is invalid")
+	ErrTokenExpired     = errors.New("token is expired")
+	ErrTokenNotYetValid = errors.New("token is not valid yet")
+	ErrInvalidIssuer    = errors.New("token issuer is invalid")
+	ErrInvalidAudience  = errors.New("token audience is invalid")
+	ErrMissingSubject   = errors.New("token has no subject")
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// StringList is claim that is a string or an array of string in the token, e.g. aud
+type StringList []string
+
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = StringList{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+
+	*l = multiple
+	return nil
+}
+
+func (l StringList) contains(value string) bool {
+	for _, v := range l {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims is registered claim and roles of a verified token, Raw has every claim including the private one
+type Claims struct {
+	Subject   string     `json:"sub"`
+	Issuer    string     `json:"iss"`
+	Audience  StringList `json:"aud"`
+	ExpiresAt int64      `json:"exp"`
+	NotBefore int64      `json:"nbf"`
+	IssuedAt  int64      `json:"iat"`
+
+	// Roles is granted by the issuer, its permission is looked up by Authorizer
+	Roles StringList `json:"roles"`
+
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Options configure Verifier, at least one of HMACSecret, RSAPublicKeyFile or JWKSFile must be set.
+// Leeway is clock skew allowed when checking exp and nbf
+type Options struct {
+	Issuer           string
+	Audience         string
+	HMACSecret       string
+	RSAPublicKeyFile string
+	JWKSFile         string
+	Leeway           time.Duration
+}
+
+// Verifier check signature, expiry, issuer and audience of a compact serialized JWT
+type Verifier struct {
+	issuer   string
+	audience string
+	leeway   time.Duration
+	keys     *keySet
+	now      func() time.Time
+}
+
+func InitVerifier(opts Options) (*Verifier, error) {
+	keys, err := loadKeySet(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		issuer:   opts.Issuer,
+		audience: opts.Audience,
+		leeway:   opts.Leeway,
+		keys:     keys,
+		now:      time.Now,
+	}, nil
+}
+
+// Verify return claims of the token, exp is required and alg of the header
+// must match the type of the key so a public key is never used as hmac secret
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if err = v.verifySignature(head, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if err = decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, err
+	}
+
+	if err = v.validateClaims(&claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+func (v *Verifier) verifySignature(head header, signed string, signature []byte) error {
+	switch head.Alg {
+	case AlgHS256:
+		if len(v.keys.hmacSecret) == 0 {
+			return ErrUnknownKey
+		}
+
+		mac := hmac.New(sha256.New, v.keys.hmacSecret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+	case AlgRS256:
+		key := v.keys.rsaKey(head.Kid)
+		if key == nil {
+			return ErrUnknownKey
+		}
+
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedAlg
+	}
+
+	return nil
+}
+
+func (v *Verifier) validateClaims(claims *Claims) error {
+	now := v.now()
+
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(v.leeway)) {
+		return ErrTokenExpired
+	}
+
+	if claims.NotBefore != 0 && now.Before(time.Unix(claims.NotBefore, 0).Add(-v.leeway)) {
+		return ErrTokenNotYetValid
+	}
+
+	if claims.Issuer != v.issuer {
+		return ErrInvalidIssuer
+	}
+
+	if !claims.Audience.contains(v.audience) {
+		return ErrInvalidAudience
+	}
+
+	if strings.TrimSpace(claims.Subject) == "" {
+		return ErrMissingSubject
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformedToken
+	}
+
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,437 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/app"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testIssuer   = "https://auth.example.com/"
+	testAudience = "movie"
+	testSecret   = "hmac-secret"
+)
+
+var testNow = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+}
+
+func segment(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, head, claims map[string]interface{}, secret []byte) string {
+	signed := segment(t, head) + "." + segment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, head, claims map[string]interface{}, key *rsa.PrivateKey) string {
+	signed := segment(t, head) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":   "user-1",
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"exp":   testNow.Add(time.Hour).Unix(),
+		"iat":   testNow.Unix(),
+		"roles": []string{"editor"},
+	}
+}
+
+func withClaim(name string, value interface{}) map[string]interface{} {
+	claims := validClaims()
+	if value == nil {
+		delete(claims, name)
+	} else {
+		claims[name] = value
+	}
+	return claims
+}
+
+func writePublicKey(t *testing.T, key *rsa.PublicKey) (path string, pemBytes []byte) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pemBytes = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	path = filepath.Join(t.TempDir(), "public.pem")
+	if err = os.WriteFile(path, pemBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path, pemBytes
+}
+
+func newVerifier(t *testing.T, opts Options) *Verifier {
+	opts.Issuer = testIssuer
+	opts.Audience = testAudience
+
+	verifier, err := InitVerifier(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.now = func() time.Time { return testNow }
+	return verifier
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeyFile, publicPEM := writePublicKey(t, &rsaKey.PublicKey)
+
+	hmacVerifier := newVerifier(t, Options{HMACSecret: testSecret, Leeway: 30 * time.Second})
+	rsaVerifier := newVerifier(t, Options{RSAPublicKeyFile: publicKeyFile})
+
+	hs256 := map[string]interface{}{"alg": AlgHS256, "typ": "JWT"}
+	rs256 := map[string]interface{}{"alg": AlgRS256, "typ": "JWT"}
+
+	tests := []struct {
+		name     string
+		verifier *Verifier
+		token    string
+		wantErr  error
+	}{
+		{
+			name:     "success hs256",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, validClaims(), []byte(testSecret)),
+		},
+		{
+			name:     "success rs256",
+			verifier: rsaVerifier,
+			token:    signRS256(t, rs256, validClaims(), rsaKey),
+		},
+		{
+			name:     "success aud as array",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("aud", []string{"other", testAudience}), []byte(testSecret)),
+		},
+		{
+			name:     "success expired within leeway",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("exp", testNow.Add(-10*time.Second).Unix()), []byte(testSecret)),
+		},
+		{
+			name:     "success nbf within leeway",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("nbf", testNow.Add(10*time.Second).Unix()), []byte(testSecret)),
+		},
+		{
+			name:     "error malformed",
+			verifier: hmacVerifier,
+			token:    "not.a-token",
+			wantErr:  ErrMalformedToken,
+		},
+		{
+			name:     "error bad hs256 signature",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, validClaims(), []byte("other-secret")),
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "error bad rs256 signature",
+			verifier: rsaVerifier,
+			token:    signRS256(t, rs256, validClaims(), otherKey),
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "error alg none",
+			verifier: hmacVerifier,
+			token:    segment(t, map[string]interface{}{"alg": "none"}) + "." + segment(t, validClaims()) + ".",
+			wantErr:  ErrUnsupportedAlg,
+		},
+		{
+			name:     "error rs256 header signed with hmac over the public key",
+			verifier: rsaVerifier,
+			token:    signHS256(t, rs256, validClaims(), publicPEM),
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "error hs256 header signed with the public key when only rsa is configured",
+			verifier: rsaVerifier,
+			token:    signHS256(t, hs256, validClaims(), publicPEM),
+			wantErr:  ErrUnknownKey,
+		},
+		{
+			name:     "error expired",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("exp", testNow.Add(-time.Minute).Unix()), []byte(testSecret)),
+			wantErr:  ErrTokenExpired,
+		},
+		{
+			name:     "error missing exp",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("exp", nil), []byte(testSecret)),
+			wantErr:  ErrTokenExpired,
+		},
+		{
+			name:     "error nbf in the future",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("nbf", testNow.Add(time.Minute).Unix()), []byte(testSecret)),
+			wantErr:  ErrTokenNotYetValid,
+		},
+		{
+			name:     "error wrong issuer",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("iss", "https://evil.example.com/"), []byte(testSecret)),
+			wantErr:  ErrInvalidIssuer,
+		},
+		{
+			name:     "error wrong audience",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("aud", "billing"), []byte(testSecret)),
+			wantErr:  ErrInvalidAudience,
+		},
+		{
+			name:     "error audience array without the audience",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("aud", []string{"billing", "search"}), []byte(testSecret)),
+			wantErr:  ErrInvalidAudience,
+		},
+		{
+			name:     "error missing subject",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("sub", nil), []byte(testSecret)),
+			wantErr:  ErrMissingSubject,
+		},
+		{
+			name:     "error blank subject",
+			verifier: hmacVerifier,
+			token:    signHS256(t, hs256, withClaim("sub", "  "), []byte(testSecret)),
+			wantErr:  ErrMissingSubject,
+		},
+		{
+			name:     "error unknown kid",
+			verifier: rsaVerifier,
+			token:    signRS256(t, map[string]interface{}{"alg": AlgRS256, "kid": "unknown"}, validClaims(), rsaKey),
+			wantErr:  ErrUnknownKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.verifier.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, "user-1", claims.Subject)
+				assert.Equal(t, StringList{"editor"}, claims.Roles)
+				assert.Equal(t, "user-1", claims.Raw["sub"])
+			}
+		})
+	}
+}
+
+func TestVerifyJWKS(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptionKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaJWK := func(kid, use string, key *rsa.PublicKey) map[string]interface{} {
+		return map[string]interface{}{
+			"kty": "RSA",
+			"kid": kid,
+			"use": use,
+			"alg": AlgRS256,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	}
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]interface{}{
+			rsaJWK("k1", "sig", &key1.PublicKey),
+			rsaJWK("k2", "", &key2.PublicKey),
+			rsaJWK("enc", "enc", &encryptionKey.PublicKey),
+			{"kty": "oct", "kid": "shared", "alg": AlgHS256, "k": base64.RawURLEncoding.EncodeToString([]byte("jwks-secret"))},
+			{"kty": "EC", "kid": "ec", "crv": "P-256"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(jwksFile, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// secret of the jwks replace the configured one
+	verifier := newVerifier(t, Options{HMACSecret: testSecret, JWKSFile: jwksFile})
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "success rsa key by kid",
+			token: signRS256(t, map[string]interface{}{"alg": AlgRS256, "kid": "k1"}, validClaims(), key1),
+		},
+		{
+			name:  "success rsa key without use",
+			token: signRS256(t, map[string]interface{}{"alg": AlgRS256, "kid": "k2"}, validClaims(), key2),
+		},
+		{
+			name:  "success oct key",
+			token: signHS256(t, map[string]interface{}{"alg": AlgHS256}, validClaims(), []byte("jwks-secret")),
+		},
+		{
+			name:    "error configured secret replaced by oct key",
+			token:   signHS256(t, map[string]interface{}{"alg": AlgHS256}, validClaims(), []byte(testSecret)),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error key of another kid",
+			token:   signRS256(t, map[string]interface{}{"alg": AlgRS256, "kid": "k2"}, validClaims(), key1),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error encryption key is skipped",
+			token:   signRS256(t, map[string]interface{}{"alg": AlgRS256, "kid": "enc"}, validClaims(), encryptionKey),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "error rs256 without kid when jwks has many rsa key",
+			token:   signRS256(t, map[string]interface{}{"alg": AlgRS256}, validClaims(), key1),
+			wantErr: ErrUnknownKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInitVerifierWithoutKey(t *testing.T) {
+	_, err := InitVerifier(Options{Issuer: testIssuer, Audience: testAudience})
+	assert.ErrorIs(t, err, ErrNoKeyConfigured)
+}
+
+func TestJWTContext(t *testing.T) {
+	verifier := newVerifier(t, Options{HMACSecret: testSecret})
+	valid := signHS256(t, map[string]interface{}{"alg": AlgHS256}, validClaims(), []byte(testSecret))
+
+	tests := []struct {
+		name          string
+		authorization string
+		userIDHeader  string
+		statusCode    int
+		challenge     string
+		wantSubject   string
+	}{
+		{
+			name:         "success anonymous drop user header",
+			userIDHeader: "victim",
+			statusCode:   http.StatusOK,
+		},
+		{
+			name:          "success bearer token",
+			authorization: "bearer " + valid,
+			userIDHeader:  "victim",
+			statusCode:    http.StatusOK,
+			wantSubject:   "user-1",
+		},
+		{
+			name:          "error not a bearer token",
+			authorization: "Basic dXNlcjpwYXNz",
+			statusCode:    http.StatusUnauthorized,
+			challenge:     `Bearer error="invalid_request"`,
+		},
+		{
+			name:          "error bearer without token",
+			authorization: "Bearer",
+			statusCode:    http.StatusUnauthorized,
+			challenge:     `Bearer error="invalid_request"`,
+		},
+		{
+			name:          "error invalid token",
+			authorization: "Bearer " + valid + "x",
+			statusCode:    http.StatusUnauthorized,
+			challenge:     `Bearer error="invalid_token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var subject, header string
+			h := JWTContext(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				subject = GetSubject(r.Context())
+				header = r.Header.Get(userIDHeader)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/Movies", nil)
+			if tt.authorization != "" {
+				req.Header.Set(authorizationHeader, tt.authorization)
+			}
+			if tt.userIDHeader != "" {
+				req.Header.Set(userIDHeader, tt.userIDHeader)
+			}
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			assert.Equal(t, tt.challenge, rr.Header().Get("WWW-Authenticate"))
+			assert.Equal(t, tt.wantSubject, subject)
+			assert.Equal(t, tt.wantSubject, header)
+		})
+	}
+}
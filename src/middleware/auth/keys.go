@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+var (
+	ErrNoKeyConfigured = errors.New("jwt needs hmac secret, rsa public key or jwks file")
+	ErrInvalidPEM      = errors.New("rsa public key file has no pem block")
+	ErrNotRSAKey       = errors.New("public key is not rsa")
+)
+
+// keySet is every key accepted by Verifier, rsaKeys is keyed by kid and
+// defaultRSA verify RS256 token without kid
+type keySet struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	defaultRSA *rsa.PublicKey
+}
+
+// jwk is the member of a json web key used by HS256 and RS256, other key type is skipped
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+func loadKeySet(opts Options) (*keySet, error) {
+	keys := &keySet{
+		hmacSecret: []byte(opts.HMACSecret),
+		rsaKeys:    make(map[string]*rsa.PublicKey),
+	}
+
+	if opts.RSAPublicKeyFile != "" {
+		key, err := loadRSAPublicKeyFile(opts.RSAPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		keys.defaultRSA = key
+	}
+
+	if opts.JWKSFile != "" {
+		if err := keys.loadJWKSFile(opts.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(keys.hmacSecret) == 0 && keys.defaultRSA == nil && len(keys.rsaKeys) == 0 {
+		return nil, ErrNoKeyConfigured
+	}
+
+	return keys, nil
+}
+
+// rsaKey find the key of the token kid, token without kid use the configured public key
+// or the only key of the jwks file
+func (ks *keySet) rsaKey(kid string) *rsa.PublicKey {
+	if kid != "" {
+		return ks.rsaKeys[kid]
+	}
+
+	if ks.defaultRSA != nil {
+		return ks.defaultRSA
+	}
+
+	if len(ks.rsaKeys) == 1 {
+		for _, key := range ks.rsaKeys {
+			return key
+		}
+	}
+
+	return nil
+}
+
+func loadRSAPublicKeyFile(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrNotRSAKey
+	}
+
+	return rsaKey, nil
+}
+
+// loadJWKSFile add RSA key and symmetric key of the file, a symmetric key
+// replace HMACSecret of the config
+func (ks *keySet) loadJWKSFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var set jwks
+	if err = json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("parse jwks file: %w", err)
+	}
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		switch key.Kty {
+		case "RSA":
+			if key.Alg != "" && key.Alg != AlgRS256 {
+				continue
+			}
+
+			rsaKey, err := parseRSAJWK(key)
+			if err != nil {
+				return fmt.Errorf("parse jwk %s: %w", key.Kid, err)
+			}
+			ks.rsaKeys[key.Kid] = rsaKey
+		case "oct":
+			if key.Alg != "" && key.Alg != AlgHS256 {
+				continue
+			}
+
+			secret, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil {
+				return fmt.Errorf("parse jwk %s: %w", key.Kid, err)
+			}
+			ks.hmacSecret = secret
+		}
+	}
+
+	return nil
+}
+
+func parseRSAJWK(key jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 {
+		return nil, ErrNotRSAKey
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
@@ -0,0 +1,288 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/redis/go-redis/v9"
+
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	ReplayedHeader       = "Idempotent-Replayed"
+
+	// Redis Key
+	IdempotencyRedisKey = "movie:idempotency:%s:%s"
+
+	maxKeyLength = 255
+	// maxBodySize is the largest request body that is read to be hashed
+	maxBodySize = 1 << 20
+	defaultTTL  = 24 * time.Hour
+	// lockTTL keep the key reserved while the first request is processed,
+	// it outlive the request timeout of the router
+	lockTTL = 2 * time.Minute
+)
+
+// replayHeaders is response header that is stored and replayed with the body
+var replayHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// record is stored under the key, Completed is false while the first
+// request is still processed
+type record struct {
+	RequestHash string            `json:"request_hash"`
+	Completed   bool              `json:"completed"`
+	StatusCode  int               `json:"status_code,omitempty"`
+	Header      map[string]string `json:"header,omitempty"`
+	Body        json.RawMessage   `json:"body,omitempty"`
+}
+
+var ErrUnsupportedRedis = errors.New("idempotency need redis client connection")
+
+// store keep the record of every key, reserve must be atomic so only one request of a key run the handler
+type store interface {
+	reserve(ctx context.Context, key string, stored record, ttl time.Duration) (bool, error)
+	get(ctx context.Context, key string) (record, bool, error)
+	set(ctx context.Context, key string, stored record, ttl time.Duration) error
+	del(ctx context.Context, key string) error
+}
+
+// Middleware make route idempotent for request sending Idempotency-Key header,
+// response of the first request is replayed for retry with the same request
+// and retry with a different request is rejected. Request without the header
+// is passed through, so route can opt in without breaking existing client.
+// Server error is not stored so the request can be retried, body above maxBodySize is rejected.
+// It need redis connection of frsRedis to reserve the key with SET NX
+func Middleware(rds frsRedis.Redis, ttl time.Duration) (func(http.Handler) http.Handler, error) {
+	cfg, ok := rds.(*frsRedis.RedisCfg)
+	if !ok || cfg.Conn == nil {
+		return nil, ErrUnsupportedRedis
+	}
+
+	return middleware(&redisStore{conn: cfg.Conn}, ttl), nil
+}
+
+func middleware(keys store, ttl time.Duration) func(http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				log.Println("invalid idempotency key length: ", len(key))
+				response.JSONBadRequestResponse(ctx, w)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if err != nil {
+				log.Println("read request body err: ", err)
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					response.JSONError(ctx, w, http.StatusRequestEntityTooLarge, appErr.ErrRequestTooLarge)
+					return
+				}
+				response.JSONBadRequestResponse(ctx, w)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// key is scoped to the user so client can not replay response of another user
+			redisKey := fmt.Sprintf(IdempotencyRedisKey, auth.GetSubject(ctx), key)
+			hash := requestHash(r, body)
+
+			reserved, err := keys.reserve(ctx, redisKey, record{RequestHash: hash}, lockTTL)
+			if err != nil {
+				response.JSONInternalErrorResponse(ctx, w)
+				return
+			}
+
+			if !reserved {
+				stored, found, err := keys.get(ctx, redisKey)
+				if err != nil {
+					response.JSONInternalErrorResponse(ctx, w)
+					return
+				}
+
+				switch {
+				case found && stored.RequestHash != hash:
+					log.Println("idempotency key err: ", appErr.ErrIdempotencyKeyMismatch, key)
+					response.JSONUnprocessableEntity(ctx, w, appErr.ErrIdempotencyKeyMismatch)
+				case !found || !stored.Completed:
+					// not found is a key released by a failed first request after the reserve, retry will run it
+					log.Println("idempotency key err: ", appErr.ErrIdempotencyKeyInProgress, key)
+					response.JSONError(ctx, w, http.StatusConflict, appErr.ErrIdempotencyKeyInProgress)
+				default:
+					replay(w, stored)
+				}
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// the handler may have finished after the request timeout or the client left,
+			// the key must still be stored or released so a retry does not run it again
+			ctx = context.WithoutCancel(ctx)
+
+			if rec.statusCode >= http.StatusInternalServerError {
+				if err = keys.del(ctx, redisKey); err != nil {
+					log.Println("delete idempotency key err: ", err)
+				}
+				return
+			}
+
+			stored := record{
+				RequestHash: hash,
+				Completed:   true,
+				StatusCode:  rec.statusCode,
+				Header:      make(map[string]string),
+				Body:        rec.body.Bytes(),
+			}
+			for _, name := range replayHeaders {
+				if value := rec.Header().Get(name); value != "" {
+					stored.Header[name] = value
+				}
+			}
+
+			if !json.Valid(stored.Body) {
+				stored.Body = nil
+			}
+
+			if err = keys.set(ctx, redisKey, stored, ttl); err != nil {
+				log.Println("store idempotent response err: ", err)
+			}
+		})
+	}
+}
+
+// requestHash identify the request by its method, path and body, json body is
+// compacted so whitespace does not change the hash
+func requestHash(r *http.Request, body []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err == nil {
+		body = compact.Bytes()
+	}
+
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// redisStore is store on redis, the key is reserved with SET NX PX so two concurrent request can not both run
+type redisStore struct {
+	conn *redis.Client
+}
+
+func (rs *redisStore) reserve(ctx context.Context, key string, stored record, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(stored)
+	if err != nil {
+		log.Println("marshal idempotency key err: ", err)
+		return false, err
+	}
+
+	ok, err := rs.conn.SetNX(ctx, key, data, ttl).Result()
+	if err != nil {
+		log.Println("reserve idempotency key err: ", err)
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (rs *redisStore) get(ctx context.Context, key string) (record, bool, error) {
+	var stored record
+
+	val, err := rs.conn.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return stored, false, nil
+		}
+		log.Println("get idempotency key err: ", err)
+		return stored, false, err
+	}
+
+	if err = json.Unmarshal([]byte(val), &stored); err != nil {
+		log.Println("unmarshal idempotency key err: ", err)
+		return stored, false, err
+	}
+
+	return stored, true, nil
+}
+
+func (rs *redisStore) set(ctx context.Context, key string, stored record, ttl time.Duration) error {
+	data, err := json.Marshal(stored)
+	if err != nil {
+		log.Println("marshal idempotency key err: ", err)
+		return err
+	}
+
+	if err = rs.conn.Set(ctx, key, data, ttl).Err(); err != nil {
+		log.Println("set idempotency key err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (rs *redisStore) del(ctx context.Context, key string) error {
+	return rs.conn.Del(ctx, key).Err()
+}
+
+func replay(w http.ResponseWriter, stored record) {
+	for name, value := range stored.Header {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Body)
+}
+
+// recorder write the response to the client and keep a copy to be stored
+type recorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(statusCode int) {
+	if !rec.wroteHeader {
+		rec.statusCode = statusCode
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
@@ -0,0 +1,268 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/app"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+}
+
+// memoryStore is store in memory, reserve is atomic like SET NX and
+// canceled context fail like redis client
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]record)}
+}
+
+func (ms *memoryStore) reserve(_ context.Context, key string, stored record, _ time.Duration) (bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, found := ms.records[key]; found {
+		return false, nil
+	}
+	ms.records[key] = stored
+	return true, nil
+}
+
+func (ms *memoryStore) get(_ context.Context, key string) (record, bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	stored, found := ms.records[key]
+	return stored, found, nil
+}
+
+func (ms *memoryStore) set(ctx context.Context, key string, stored record, _ time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.records[key] = stored
+	return nil
+}
+
+func (ms *memoryStore) del(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	delete(ms.records, key)
+	return nil
+}
+
+func serve(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/Movies", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestMiddleware(t *testing.T) {
+	created := func(calls *int32) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(calls, 1)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":1}`))
+		})
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(keys *memoryStore)
+		key        string
+		body       string
+		statusCode int
+		wantCalls  int32
+		replayed   bool
+	}{
+		{
+			name:       "success without key",
+			body:       `{"title":"a"}`,
+			statusCode: http.StatusCreated,
+			wantCalls:  1,
+		},
+		{
+			name:       "error key too long",
+			key:        strings.Repeat("k", maxKeyLength+1),
+			body:       `{"title":"a"}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "success first call",
+			key:        "key-1",
+			body:       `{"title":"a"}`,
+			statusCode: http.StatusCreated,
+			wantCalls:  1,
+		},
+		{
+			name: "success replay with whitespace difference",
+			setup: func(keys *memoryStore) {
+				h := middleware(keys, time.Hour)(created(new(int32)))
+				serve(h, "key-1", `{"title":"a"}`)
+			},
+			key:        "key-1",
+			body:       `{ "title": "a" }`,
+			statusCode: http.StatusCreated,
+			replayed:   true,
+		},
+		{
+			name: "error body mismatch",
+			setup: func(keys *memoryStore) {
+				h := middleware(keys, time.Hour)(created(new(int32)))
+				serve(h, "key-1", `{"title":"a"}`)
+			},
+			key:        "key-1",
+			body:       `{"title":"b"}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "error body too large",
+			key:        "key-1",
+			body:       `{"title":"` + strings.Repeat("a", maxBodySize) + `"}`,
+			statusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "error in progress",
+			setup: func(keys *memoryStore) {
+				req := httptest.NewRequest(http.MethodPost, "/Movies", nil)
+				keys.reserve(context.Background(), "movie:idempotency::key-1", record{RequestHash: requestHash(req, []byte(`{"title":"a"}`))}, lockTTL)
+			},
+			key:        "key-1",
+			body:       `{"title":"a"}`,
+			statusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := newMemoryStore()
+			if tt.setup != nil {
+				tt.setup(keys)
+			}
+
+			var calls int32
+			rr := serve(middleware(keys, time.Hour)(created(&calls)), tt.key, tt.body)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.replayed {
+				assert.Equal(t, "true", rr.Header().Get(ReplayedHeader))
+				assert.Equal(t, `{"id":1}`, rr.Body.String())
+				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestMiddlewareReleaseKeyOnServerError(t *testing.T) {
+	keys := newMemoryStore()
+
+	var calls int32
+	status := http.StatusInternalServerError
+	h := middleware(keys, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(status)
+	}))
+
+	rr := serve(h, "key-1", `{}`)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Empty(t, keys.records)
+
+	status = http.StatusOK
+	rr = serve(h, "key-1", `{}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestMiddlewareConcurrentRequest(t *testing.T) {
+	keys := newMemoryStore()
+
+	var calls int32
+	release := make(chan struct{})
+	h := middleware(keys, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	codes := make(chan int, 5)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serve(h, "key-1", `{}`).Code
+		}()
+	}
+
+	// every request but the one running the handler is rejected while it is in progress
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, http.StatusConflict, <-codes)
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, http.StatusCreated, <-codes)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestMiddlewareStoreAfterRequestCanceled(t *testing.T) {
+	for _, status := range []int{http.StatusCreated, http.StatusInternalServerError} {
+		keys := newMemoryStore()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		h := middleware(keys, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// request timeout or client disconnect while the handler finish its work
+			cancel()
+			w.WriteHeader(status)
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/Movies", strings.NewReader(`{}`)).WithContext(ctx)
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		stored, found := keys.records["movie:idempotency::key-1"]
+		if status == http.StatusCreated {
+			assert.True(t, found)
+			assert.True(t, stored.Completed)
+			assert.Equal(t, http.StatusCreated, stored.StatusCode)
+		} else {
+			assert.False(t, found)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IdentityIP     = "ip"
+	IdentityUser   = "user"
+	IdentityAPIKey = "apikey"
+
+	// DefaultPolicy is used when RATE_LIMIT_DEFAULT is empty, anonymous client share nothing but its ip
+	// so it has a lower limit than user and api key
+	DefaultPolicy = "300/1m,ip:120/1m"
+)
+
+var ErrInvalidPolicy = errors.New("rate limit policy must be [identity:]limit/window separated by comma, e.g. 300/1m,ip:120/1m")
+
+// Rule allow Limit request in Window, request is spread so the whole limit is available again after Window
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Policy is the rule of a route group, identity without its own rule use Default.
+// Zero Default means the group fall back to the default policy
+type Policy struct {
+	Default    Rule
+	Identities map[string]Rule
+}
+
+// ParsePolicy read policy like 300/1m,ip:120/1m, rule without identity is the default of the policy
+func ParsePolicy(spec string) (Policy, error) {
+	policy := Policy{Identities: make(map[string]Rule)}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		identity, ruleSpec, found := strings.Cut(entry, ":")
+		if !found {
+			identity, ruleSpec = "", entry
+		}
+
+		rule, err := parseRule(ruleSpec)
+		if err != nil {
+			return policy, fmt.Errorf("%w: %s", ErrInvalidPolicy, entry)
+		}
+
+		switch identity {
+		case "":
+			policy.Default = rule
+		case IdentityIP, IdentityUser, IdentityAPIKey:
+			policy.Identities[identity] = rule
+		default:
+			return policy, fmt.Errorf("%w: unknown identity %s", ErrInvalidPolicy, identity)
+		}
+	}
+
+	return policy, nil
+}
+
+func parseRule(spec string) (rule Rule, err error) {
+	limit, window, found := strings.Cut(strings.TrimSpace(spec), "/")
+	if !found {
+		return rule, ErrInvalidPolicy
+	}
+
+	if rule.Limit, err = strconv.Atoi(limit); err != nil || rule.Limit <= 0 {
+		return rule, ErrInvalidPolicy
+	}
+
+	if rule.Window, err = time.ParseDuration(window); err != nil || rule.Window < time.Second {
+		return rule, ErrInvalidPolicy
+	}
+
+	return rule, nil
+}
+
+// rule return the rule of the identity in the policy, ok is false when the policy has no rule for it
+func (p Policy) rule(identity string) (rule Rule, ok bool) {
+	if rule, ok = p.Identities[identity]; ok {
+		return rule, true
+	}
+
+	return p.Default, p.Default.Limit > 0
+}
+
+// String format the rule as RateLimit-Policy header, window is in second
+func (r Rule) String() string {
+	return fmt.Sprintf("%d;w=%d", r.Limit, int(r.Window.Seconds()))
+}
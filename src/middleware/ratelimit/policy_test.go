@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    Policy
+		wantErr bool
+	}{
+		{
+			name: "success default and identity",
+			spec: "300/1m, ip:120/1m,apikey:600/1h",
+			want: Policy{
+				Default: Rule{Limit: 300, Window: time.Minute},
+				Identities: map[string]Rule{
+					IdentityIP:     {Limit: 120, Window: time.Minute},
+					IdentityAPIKey: {Limit: 600, Window: time.Hour},
+				},
+			},
+		},
+		{
+			name: "success identity only",
+			spec: "user:10/30s,",
+			want: Policy{Identities: map[string]Rule{IdentityUser: {Limit: 10, Window: 30 * time.Second}}},
+		},
+		{
+			name: "success empty",
+			spec: "",
+			want: Policy{Identities: map[string]Rule{}},
+		},
+		{
+			name:    "error unknown identity",
+			spec:    "admin:10/1m",
+			wantErr: true,
+		},
+		{
+			name:    "error without window",
+			spec:    "300",
+			wantErr: true,
+		},
+		{
+			name:    "error zero limit",
+			spec:    "0/1m",
+			wantErr: true,
+		},
+		{
+			name:    "error window under a second",
+			spec:    "10/500ms",
+			wantErr: true,
+		},
+		{
+			name:    "error invalid window",
+			spec:    "10/minute",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolicy(tt.spec)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPolicy)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	assert.Equal(t, "120;w=60", Rule{Limit: 120, Window: time.Minute}.String())
+	assert.Equal(t, "10;w=3600", Rule{Limit: 10, Window: time.Hour}.String())
+}
@@ -0,0 +1,274 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/redis/go-redis/v9"
+
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	appErr "github.com/Risuii/movie/src/errors"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	PolicyHeader     = "RateLimit-Policy"
+	LimitHeader      = "RateLimit-Limit"
+	RemainingHeader  = "RateLimit-Remaining"
+	ResetHeader      = "RateLimit-Reset"
+	RetryAfterHeader = "Retry-After"
+
+	// Redis Key
+	RateLimitRedisKey = "movie:ratelimit:%s:%s:%s"
+
+	// identityAPIKeyFailure count the rejected api key of an ip
+	identityAPIKeyFailure = "apikeyfailure"
+)
+
+var ErrUnsupportedRedis = errors.New("rate limit need redis client connection")
+
+// gcraScript is a token bucket stored as the theoretical arrival time of the next request (GCRA),
+// the whole check and update is one script so limit hold across replicas. Time is taken from redis
+// so clock of the replicas does not matter. Argument is limit and window in microsecond and whether the
+// request is taken, 0 only check the bucket. It return allowed, remaining, retry after and reset, both in microsecond
+var gcraScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local consume = ARGV[3] == '1'
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local emission = window / limit
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local newTat = tat + emission
+local allowAt = newTat - window
+if now < allowAt then
+	return {0, 0, math.ceil(allowAt - now), math.ceil(tat - now)}
+end
+
+if consume then
+	redis.call('SET', KEYS[1], string.format('%.0f', newTat), 'PX', math.ceil((newTat - now) / 1000))
+end
+return {1, math.floor((window - (newTat - now)) / emission), 0, math.ceil(newTat - now)}
+`)
+
+// Options configure Limiter, Groups is the policy spec of each route group and
+// group without spec use Default
+type Options struct {
+	Disabled bool
+	Default  string
+	Groups   map[string]string
+}
+
+// Limiter limit request of each identity in a route group, identity is the api key,
+// the user of the token or the ip of anonymous request
+type Limiter struct {
+	conn          *redis.Client
+	disabled      bool
+	defaultPolicy Policy
+	groups        map[string]Policy
+}
+
+type result struct {
+	allowed    bool
+	remaining  int64
+	retryAfter time.Duration
+	reset      time.Duration
+}
+
+// InitLimiter need redis connection of frsRedis to run the limit script
+func InitLimiter(rds frsRedis.Redis, opts Options) (*Limiter, error) {
+	cfg, ok := rds.(*frsRedis.RedisCfg)
+	if !ok || cfg.Conn == nil {
+		return nil, ErrUnsupportedRedis
+	}
+
+	if opts.Default == "" {
+		opts.Default = DefaultPolicy
+	}
+
+	defaultPolicy, err := ParsePolicy(opts.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaultPolicy.Default.Limit == 0 {
+		return nil, fmt.Errorf("%w: default policy need a rule without identity", ErrInvalidPolicy)
+	}
+
+	groups := make(map[string]Policy)
+	for group, spec := range opts.Groups {
+		if spec == "" {
+			continue
+		}
+
+		if groups[group], err = ParsePolicy(spec); err != nil {
+			return nil, fmt.Errorf("group %s: %w", group, err)
+		}
+	}
+
+	return &Limiter{
+		conn:          cfg.Conn,
+		disabled:      opts.Disabled,
+		defaultPolicy: defaultPolicy,
+		groups:        groups,
+	}, nil
+}
+
+// Limit count request of the route group and reject it with 429 when the identity is over the limit,
+// it must run after JWTContext and RealIP so the identity is known and before APIKeyContext so a rejected
+// request does not count in the quota of the key. Client whose api key is rejected is limited by its ip
+// with the ip rule of the group. Request is allowed when redis fail so the api is not down with the limiter
+func (l *Limiter) Limit(group string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if l.disabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			identity, id := identify(r)
+			if identity != IdentityAPIKey {
+				if l.allow(w, r, group, identity, id, true) {
+					next.ServeHTTP(w, r)
+				}
+				return
+			}
+
+			// the key is authenticated after the limiter, failed key is counted once the response is known
+			ip := clientIP(r)
+			if !l.allow(w, r, group, identityAPIKeyFailure, ip, false) || !l.allow(w, r, group, identity, id, true) {
+				return
+			}
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			if ww.Status() == http.StatusUnauthorized {
+				rule := l.rule(group, identityAPIKeyFailure)
+				if _, err := l.take(ctx, fmt.Sprintf(RateLimitRedisKey, group, identityAPIKeyFailure, ip), rule, true); err != nil {
+					log.Println("rate limit err: ", err)
+				}
+			}
+		})
+	}
+}
+
+// allow take the request from the bucket of the identity and write the limit header, the request is rejected
+// with 429 when it return false. Without consume the bucket is only checked and header is written on reject
+func (l *Limiter) allow(w http.ResponseWriter, r *http.Request, group, identity, id string, consume bool) bool {
+	ctx := r.Context()
+	rule := l.rule(group, identity)
+
+	res, err := l.take(ctx, fmt.Sprintf(RateLimitRedisKey, group, identity, id), rule, consume)
+	if err != nil {
+		log.Println("rate limit err: ", err)
+		return true
+	}
+
+	header := w.Header()
+	if consume || !res.allowed {
+		setHeader(header, rule, res)
+	}
+
+	if !res.allowed {
+		log.Println("rate limit exceeded: ", group, identity, id)
+		header.Set(RetryAfterHeader, strconv.Itoa(seconds(res.retryAfter)))
+		response.JSONError(ctx, w, http.StatusTooManyRequests, appErr.ErrRateLimitExceeded)
+		return false
+	}
+
+	return true
+}
+
+// setHeader write the RateLimit header of the rule, reset and retry after are rounded up to second
+func setHeader(header http.Header, rule Rule, res result) {
+	header.Set(PolicyHeader, rule.String())
+	header.Set(LimitHeader, strconv.Itoa(rule.Limit))
+	header.Set(RemainingHeader, strconv.FormatInt(res.remaining, 10))
+	header.Set(ResetHeader, strconv.Itoa(seconds(res.reset)))
+}
+
+// rule of the group for the identity, group rule win over default policy and identity rule win over default rule.
+// Failed api key use the ip rule because the client is not known
+func (l *Limiter) rule(group, identity string) Rule {
+	if identity == identityAPIKeyFailure {
+		identity = IdentityIP
+	}
+
+	if rule, ok := l.groups[group].rule(identity); ok {
+		return rule
+	}
+
+	rule, _ := l.defaultPolicy.rule(identity)
+	return rule
+}
+
+func (l *Limiter) take(ctx context.Context, key string, rule Rule, consume bool) (res result, err error) {
+	taken := 0
+	if consume {
+		taken = 1
+	}
+
+	vals, err := gcraScript.Run(ctx, l.conn, []string{key}, rule.Limit, rule.Window.Microseconds(), taken).Int64Slice()
+	if err != nil {
+		return res, err
+	}
+
+	if len(vals) != 4 {
+		return res, fmt.Errorf("rate limit script return %d values", len(vals))
+	}
+
+	return result{
+		allowed:    vals[0] == 1,
+		remaining:  vals[1],
+		retryAfter: time.Duration(vals[2]) * time.Microsecond,
+		reset:      time.Duration(vals[3]) * time.Microsecond,
+	}, nil
+}
+
+// identify return the identity kind and id of the request, api key is checked first because
+// its owner is not the user of a token. The key is not authenticated yet so its id is the hash of the key
+func identify(r *http.Request) (identity, id string) {
+	if key := r.Header.Get(auth.APIKeyHeader); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		return IdentityAPIKey, hex.EncodeToString(sum[:16])
+	}
+
+	if subject := auth.GetSubject(r.Context()); subject != "" {
+		return IdentityUser, subject
+	}
+
+	return IdentityIP, clientIP(r)
+}
+
+// clientIP is the host of RemoteAddr, it is the real ip of the client once RealIP run
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// seconds round up so client never retry before the limit allow it
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLimiter(t *testing.T, groups map[string]string) *Limiter {
+	defaultPolicy, err := ParsePolicy(DefaultPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Limiter{defaultPolicy: defaultPolicy, groups: make(map[string]Policy)}
+	for group, spec := range groups {
+		if l.groups[group], err = ParsePolicy(spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return l
+}
+
+func TestLimiterRule(t *testing.T) {
+	l := testLimiter(t, map[string]string{
+		"booking": "60/1m,apikey:600/1m",
+		"admin":   "ip:10/1m",
+	})
+
+	tests := []struct {
+		name     string
+		group    string
+		identity string
+		want     Rule
+	}{
+		{
+			name:     "group identity rule",
+			group:    "booking",
+			identity: IdentityAPIKey,
+			want:     Rule{Limit: 600, Window: time.Minute},
+		},
+		{
+			name:     "group default rule win over default ip rule",
+			group:    "booking",
+			identity: IdentityIP,
+			want:     Rule{Limit: 60, Window: time.Minute},
+		},
+		{
+			name:     "group without default fall back to default policy",
+			group:    "admin",
+			identity: IdentityUser,
+			want:     Rule{Limit: 300, Window: time.Minute},
+		},
+		{
+			name:     "group identity rule of ip",
+			group:    "admin",
+			identity: IdentityIP,
+			want:     Rule{Limit: 10, Window: time.Minute},
+		},
+		{
+			name:     "group without policy use default ip rule",
+			group:    "movies",
+			identity: IdentityIP,
+			want:     Rule{Limit: 120, Window: time.Minute},
+		},
+		{
+			name:     "failed api key use ip rule",
+			group:    "booking",
+			identity: identityAPIKeyFailure,
+			want:     Rule{Limit: 60, Window: time.Minute},
+		},
+		{
+			name:     "failed api key use default ip rule",
+			group:    "movies",
+			identity: identityAPIKeyFailure,
+			want:     Rule{Limit: 120, Window: time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, l.rule(tt.group, tt.identity))
+		})
+	}
+}
+
+func TestSetHeader(t *testing.T) {
+	header := http.Header{}
+	setHeader(header, Rule{Limit: 120, Window: time.Minute}, result{
+		allowed:   true,
+		remaining: 119,
+		reset:     500 * time.Millisecond,
+	})
+
+	assert.Equal(t, "120;w=60", header.Get(PolicyHeader))
+	assert.Equal(t, "120", header.Get(LimitHeader))
+	assert.Equal(t, "119", header.Get(RemainingHeader))
+	assert.Equal(t, "1", header.Get(ResetHeader))
+}
+
+func TestSeconds(t *testing.T) {
+	assert.Equal(t, 0, seconds(0))
+	assert.Equal(t, 1, seconds(time.Microsecond))
+	assert.Equal(t, 1, seconds(time.Second))
+	assert.Equal(t, 2, seconds(time.Second+time.Millisecond))
+}
+
+func TestIdentify(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      func() *http.Request
+		wantIdentity string
+		wantID       string
+	}{
+		{
+			name: "api key win over token",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/Movies", nil)
+				req.Header.Set(auth.APIKeyHeader, "mk_key")
+				return req.WithContext(context.WithValue(req.Context(), auth.CtxKeyClaims, &auth.Claims{Subject: "user-1"}))
+			},
+			wantIdentity: IdentityAPIKey,
+		},
+		{
+			name: "user of the token",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/Movies", nil)
+				return req.WithContext(context.WithValue(req.Context(), auth.CtxKeyClaims, &auth.Claims{Subject: "user-1"}))
+			},
+			wantIdentity: IdentityUser,
+			wantID:       "user-1",
+		},
+		{
+			name: "ip of anonymous request",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/Movies", nil)
+				req.RemoteAddr = "203.0.113.7:52000"
+				return req
+			},
+			wantIdentity: IdentityIP,
+			wantID:       "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, id := identify(tt.request())
+			assert.Equal(t, tt.wantIdentity, identity)
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantID, id)
+			}
+		})
+	}
+}
+
+func TestIdentifyAPIKeyIsHashed(t *testing.T) {
+	request := func(key string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/Movies", nil)
+		req.Header.Set(auth.APIKeyHeader, key)
+		return req
+	}
+
+	_, first := identify(request("mk_key"))
+	_, again := identify(request("mk_key"))
+	_, other := identify(request("mk_other"))
+
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first, other)
+	assert.NotContains(t, first, "mk_key")
+}
@@ -0,0 +1,117 @@
+package request
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	xHeaderForwardedFor = "X-Forwarded-For"
+	xHeaderRealIP       = "X-Real-IP"
+)
+
+var ErrInvalidTrustedProxy = errors.New("trusted proxy must be ip or cidr separated by comma, e.g. 10.0.0.0/8,127.0.0.1")
+
+// ParseTrustedProxies read trusted proxy like 10.0.0.0/8,127.0.0.1, ip without mask is a single address
+func ParseTrustedProxies(spec string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, ErrInvalidTrustedProxy
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, ErrInvalidTrustedProxy
+		}
+		proxies = append(proxies, network)
+	}
+
+	return proxies, nil
+}
+
+// RealIP set RemoteAddr to the client ip of X-Forwarded-For or X-Real-IP. Any client can send the headers
+// so they are only read when the peer is a trusted proxy, X-Forwarded-For is read from the right and the
+// first address that is not a trusted proxy is the client. Without trusted proxy RemoteAddr is kept
+func RealIP(trusted []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(trusted) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := realIP(r, trusted); ip != "" {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func realIP(r *http.Request, trusted []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !isTrusted(net.ParseIP(host), trusted) {
+		return ""
+	}
+
+	// every proxy append the address of its peer, so only the right part written by trusted proxy can be believed
+	if values := r.Header.Values(xHeaderForwardedFor); len(values) > 0 {
+		forwarded := strings.Split(strings.Join(values, ","), ",")
+		client := ""
+		for i := len(forwarded) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+			if ip == nil {
+				return ""
+			}
+
+			client = ip.String()
+			if !isTrusted(ip, trusted) {
+				break
+			}
+		}
+		return client
+	}
+
+	// X-Real-IP is a single address, it is believed only because the trusted proxy overwrite it
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get(xHeaderRealIP))); ip != nil {
+		return ip.String()
+	}
+
+	return ""
+}
+
+func isTrusted(ip net.IP, trusted []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
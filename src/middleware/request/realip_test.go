@@ -0,0 +1,121 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 127.0.0.1,::1,")
+	assert.NoError(t, err)
+	if assert.Len(t, proxies, 3) {
+		assert.Equal(t, "10.0.0.0/8", proxies[0].String())
+		assert.Equal(t, "127.0.0.1/32", proxies[1].String())
+		assert.Equal(t, "::1/128", proxies[2].String())
+	}
+
+	proxies, err = ParseTrustedProxies("")
+	assert.NoError(t, err)
+	assert.Empty(t, proxies)
+
+	_, err = ParseTrustedProxies("10.0.0.0/8,proxy")
+	assert.ErrorIs(t, err, ErrInvalidTrustedProxy)
+
+	_, err = ParseTrustedProxies("10.0.0.0/40")
+	assert.ErrorIs(t, err, ErrInvalidTrustedProxy)
+}
+
+func TestRealIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		trusted    bool
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{
+			name:       "header ignored without trusted proxy",
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "10.0.0.2:1234",
+		},
+		{
+			name:       "header ignored from untrusted peer",
+			trusted:    true,
+			remoteAddr: "203.0.113.7:1234",
+			forwarded:  []string{"198.51.100.1"},
+			realIP:     "198.51.100.2",
+			want:       "203.0.113.7:1234",
+		},
+		{
+			name:       "forwarded for of trusted peer",
+			trusted:    true,
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "spoofed forwarded for is skipped",
+			trusted:    true,
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"1.1.1.1, 198.51.100.1", "10.0.0.3"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "every forwarded for is trusted",
+			trusted:    true,
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"10.0.0.4, 10.0.0.3"},
+			want:       "10.0.0.4",
+		},
+		{
+			name:       "invalid forwarded for keep peer",
+			trusted:    true,
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"198.51.100.1, unknown"},
+			realIP:     "198.51.100.2",
+			want:       "10.0.0.2:1234",
+		},
+		{
+			name:       "real ip of trusted peer",
+			trusted:    true,
+			remoteAddr: "10.0.0.2:1234",
+			realIP:     "198.51.100.2",
+			want:       "198.51.100.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwarded {
+				req.Header.Add(xHeaderForwardedFor, v)
+			}
+			if tt.realIP != "" {
+				req.Header.Set(xHeaderRealIP, tt.realIP)
+			}
+
+			proxies := trusted
+			if !tt.trusted {
+				proxies = nil
+			}
+
+			var got string
+			RealIP(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			})).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
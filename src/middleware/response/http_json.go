@@ -6,6 +6,8 @@ import (
 
 	i18n_err "github.com/Risuii/frs-lib/i18n/errors"
 	"github.com/Risuii/movie/src/middleware/request"
+
+	appErr "github.com/Risuii/movie/src/errors"
 )
 
 func JSONSuccessResponse(ctx context.Context, w http.ResponseWriter, data interface{}) {
@@ -17,6 +19,12 @@ func JSONUnauthorizedResponse(ctx context.Context, w http.ResponseWriter) {
 		http.StatusUnauthorized)
 }
 
+// JSONForbiddenResponse is for authenticated request without the permission of the route
+func JSONForbiddenResponse(ctx context.Context, w http.ResponseWriter) {
+	JSONResponse(ctx, w, createErrorResponse(appErr.ErrForbidden, request.GetRequestID(ctx), request.GetLanguage(ctx)),
+		http.StatusForbidden)
+}
+
 func JSONInternalErrorResponse(ctx context.Context, w http.ResponseWriter) {
 	JSONResponse(ctx, w, createErrorResponse(i18n_err.ErrInternalServer, request.GetRequestID(ctx), request.GetLanguage(ctx)),
 		http.StatusInternalServerError)
@@ -0,0 +1,134 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+func (ar *APIKeysRepository) GetList(ctx context.Context) ([]*entity.APIKey, error) {
+	var keys []*entity.APIKey
+	if err := ar.masterStmts[GetList].SelectContext(ctx, &keys); err != nil {
+		log.Println("GetAPIKeyList err: ", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (ar *APIKeysRepository) Get(ctx context.Context, id int64) (entity.APIKey, error) {
+	var key entity.APIKey
+	if err := ar.masterStmts[GetByID].GetContext(ctx, &key, id); err != nil {
+		log.Println("GetAPIKey err: ", err)
+		return key, err
+	}
+
+	return key, nil
+}
+
+// GetByPrefix is called on every request with X-API-Key so the key is cached
+func (ar *APIKeysRepository) GetByPrefix(ctx context.Context, prefix string) (entity.APIKey, error) {
+	var key entity.APIKey
+	err := ar.redis.WithCache(ctx, fmt.Sprintf(GetPrefixAPIKeysRedisKey, prefix), &key, func() (interface{}, error) {
+		var keyData entity.APIKey
+		err := ar.masterStmts[GetByPrefix].GetContext(ctx, &keyData, prefix)
+		return keyData, err
+	})
+
+	if err != nil {
+		log.Println("GetAPIKeyByPrefix err: ", err)
+		return key, err
+	}
+
+	return key, nil
+}
+
+func (ar *APIKeysRepository) Create(ctx context.Context, data *entity.APIKey) (entity.APIKey, error) {
+	var res entity.APIKey
+	if err := ar.masterNamedStmpts[InsertAPIKey].GetContext(ctx, &res, data); err != nil {
+		log.Println("insert api key err: ", err)
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Rotate replace prefix and secret hash of a key that is not revoked
+func (ar *APIKeysRepository) Rotate(ctx context.Context, data *entity.APIKey) (entity.APIKey, error) {
+	var res entity.APIKey
+	if err := ar.masterNamedStmpts[RotateAPIKey].GetContext(ctx, &res, data); err != nil {
+		log.Println("rotate api key err: ", err)
+		return res, err
+	}
+
+	ar.invalidateCache(ctx)
+
+	return res, nil
+}
+
+func (ar *APIKeysRepository) Revoke(ctx context.Context, id int64) error {
+	res, err := ar.masterStmts[Revoke].ExecContext(ctx, id)
+	if err != nil {
+		log.Println("revoke api key err: ", err)
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("api key not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	ar.invalidateCache(ctx)
+
+	return nil
+}
+
+// IncrementUsage count a request of the key in the UTC day of now and return the count,
+// the counter expire after the day is over
+func (ar *APIKeysRepository) IncrementUsage(ctx context.Context, id int64, now time.Time) (int64, error) {
+	key := fmt.Sprintf(APIKeyQuotaRedisKey, id, now.UTC().Format("2006-01-02"))
+
+	var incr *redis.IntCmd
+	_, err := ar.conn.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireAt(ctx, key, entity.NextQuotaReset(now).Add(time.Hour))
+		return nil
+	})
+	if err != nil {
+		log.Println("increment api key usage err: ", err)
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+// TouchLastUsed set last_used_at at most once per LastUsedInterval of a key
+func (ar *APIKeysRepository) TouchLastUsed(ctx context.Context, id int64) error {
+	ok, err := ar.conn.SetNX(ctx, fmt.Sprintf(APIKeyLastUsedRedisKey, id), 1, LastUsedInterval).Result()
+	if err != nil {
+		log.Println("throttle api key last used err: ", err)
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	if _, err = ar.masterStmts[UpdateLastUsed].ExecContext(ctx, id); err != nil {
+		log.Println("update api key last used err: ", err)
+		return err
+	}
+
+	return nil
+}
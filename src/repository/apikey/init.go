@@ -0,0 +1,102 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
+
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	AllFields = `id, name, owner, prefix, secret_hash, scopes, daily_quota, expires_at, last_used_at, revoked_at, created_at, updated_at`
+
+	GetByID = iota + 100
+	GetByPrefix
+	GetList
+	Revoke
+	UpdateLastUsed
+
+	InsertAPIKey = iota + 200
+	RotateAPIKey
+
+	// Redis Key
+
+	GetPrefixAPIKeysRedisKey    = "movie:apikeys:prefix:%s"
+	DeletePrefixAPIKeysRedisKey = "movie:apikeys:prefix:*"
+	// APIKeyQuotaRedisKey count request of a key in a UTC day
+	APIKeyQuotaRedisKey = "movie:apikeys:quota:%d:%s"
+	// APIKeyLastUsedRedisKey throttle write of last_used_at to once per LastUsedInterval
+	APIKeyLastUsedRedisKey = "movie:apikeys:lastused:%d"
+
+	LastUsedInterval = time.Minute
+)
+
+var ErrUnsupportedRedis = errors.New("api key quota need redis client connection")
+
+var (
+	masterQueries = []string{
+		GetByID:        fmt.Sprintf("SELECT %s FROM api_keys WHERE id = $1", AllFields),
+		GetByPrefix:    fmt.Sprintf("SELECT %s FROM api_keys WHERE prefix = $1", AllFields),
+		GetList:        fmt.Sprintf("SELECT %s FROM api_keys ORDER BY id", AllFields),
+		Revoke:         `UPDATE api_keys SET (revoked_at, updated_at) = (now(), now()) WHERE id = $1 AND revoked_at IS NULL`,
+		UpdateLastUsed: `UPDATE api_keys SET last_used_at = now() WHERE id = $1`,
+	}
+
+	masterNamedQueries = []string{
+		InsertAPIKey: fmt.Sprintf(`INSERT INTO api_keys (name, owner, prefix, secret_hash, scopes, daily_quota, expires_at, created_at)
+			VALUES (:name, :owner, :prefix, :secret_hash, :scopes, :daily_quota, :expires_at, now()) RETURNING %s`, AllFields),
+		RotateAPIKey: fmt.Sprintf(`UPDATE api_keys SET (prefix, secret_hash, updated_at) = (:prefix, :secret_hash, now())
+			WHERE id = :id AND revoked_at IS NULL RETURNING %s`, AllFields),
+	}
+)
+
+type APIKeysRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+	redis             frsRedis.Redis
+	conn              *redis.Client
+}
+
+// InitAPIKeysRepository need redis connection of frsRedis to count the quota with INCR
+func InitAPIKeysRepository(ctx context.Context, db *sqlx.DB, rds frsRedis.Redis) (*APIKeysRepository, error) {
+	cfg, ok := rds.(*frsRedis.RedisCfg)
+	if !ok || cfg.Conn == nil {
+		log.Println("InitAPIKeysRepository err:", ErrUnsupportedRedis)
+		return nil, ErrUnsupportedRedis
+	}
+
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &APIKeysRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+		redis:             rds,
+		conn:              cfg.Conn,
+	}, nil
+}
+
+// invalidateCache drop key cached by prefix so rotated and revoked key stop working at once
+func (r *APIKeysRepository) invalidateCache(ctx context.Context) {
+	if err := r.redis.DelWithPattern(ctx, DeletePrefixAPIKeysRedisKey); err != nil {
+		log.Println("delete redis err: ", err)
+	}
+}
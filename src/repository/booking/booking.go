@@ -0,0 +1,90 @@
+package booking
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
+	"github.com/lib/pq"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func (br *BookingsRepository) Get(ctx context.Context, id int64) (entity.Booking, error) {
+	var Booking entity.Booking
+
+	err := br.masterStmts[GetByID].GetContext(ctx, &Booking, id)
+	if err != nil {
+		log.Println("get booking err: ", err)
+		return Booking, err
+	}
+
+	return Booking, nil
+}
+
+func (br *BookingsRepository) GetByUser(ctx context.Context, userID string) ([]*entity.Booking, error) {
+	var Booking []*entity.Booking
+
+	err := br.masterStmts[GetByUserID].SelectContext(ctx, &Booking, userID)
+	if err != nil {
+		log.Println("get booking by user err: ", err)
+		return nil, err
+	}
+
+	return Booking, nil
+}
+
+// GetBookedSeats return every booked seat of the showtime
+func (br *BookingsRepository) GetBookedSeats(ctx context.Context, showtimeID int64) ([]string, error) {
+	var seats []string
+
+	stmt, err := br.getStatement(ctx, GetBookedSeats)
+	if err != nil {
+		log.Println("getStatement err: ", err)
+		return nil, err
+	}
+
+	if err = stmt.SelectContext(ctx, &seats, showtimeID); err != nil {
+		log.Println("get booked seats err: ", err)
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+// Create insert booking and its seats, it return ErrSeatTaken when one of the seat
+// is already booked or the hold is already confirmed so it must run in a transaction
+func (br *BookingsRepository) Create(ctx context.Context, data *entity.Booking) (entity.Booking, error) {
+	res := *data
+
+	namedStmt, err := br.getNamedStatement(ctx, InsertBooking)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return res, err
+	}
+
+	if err = namedStmt.QueryRowxContext(ctx, data).Scan(&res.Id, &res.CreatedAt, &res.UpdatedAt); err != nil {
+		log.Println("insert booking err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrSeatTaken
+		}
+		return res, err
+	}
+
+	stmt, err := br.getStatement(ctx, InsertBookingSeats)
+	if err != nil {
+		log.Println("getStatement err: ", err)
+		return res, err
+	}
+
+	if _, err = stmt.ExecContext(ctx, res.Id, data.ShowtimeID, pq.Array(data.Seats)); err != nil {
+		log.Println("insert booking seats err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrSeatTaken
+		}
+		return res, err
+	}
+
+	return res, nil
+}
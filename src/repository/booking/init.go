@@ -0,0 +1,96 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	AllFields = `b.id, b.showtime_id, b.user_id, b.hold_id, b.total_price, b.created_at, b.updated_at,
+		ARRAY(SELECT s.seat FROM booking_seats s WHERE s.booking_id = b.id ORDER BY s.seat) AS seats`
+
+	GetByID = iota + 100
+	GetByUserID
+	GetBookedSeats
+	InsertBookingSeats
+
+	InsertBooking = iota + 200
+)
+
+var (
+	masterQueries = []string{
+		GetByID:            fmt.Sprintf("SELECT %s FROM bookings b WHERE b.id = $1", AllFields),
+		GetByUserID:        fmt.Sprintf("SELECT %s FROM bookings b WHERE b.user_id = $1 ORDER BY b.created_at DESC, b.id DESC", AllFields),
+		GetBookedSeats:     `SELECT seat FROM booking_seats WHERE showtime_id = $1`,
+		InsertBookingSeats: `INSERT INTO booking_seats (booking_id, showtime_id, seat) SELECT $1, $2, unnest(CAST($3 AS text[]))`,
+	}
+
+	masterNamedQueries = []string{
+		InsertBooking: `INSERT INTO bookings (showtime_id, user_id, hold_id, total_price, created_at)
+			VALUES (:showtime_id, :user_id, :hold_id, :total_price, now()) RETURNING id, created_at, updated_at`,
+	}
+)
+
+type BookingsRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+}
+
+// InitBookingsRepository does not take redis, booking is never cached
+func InitBookingsRepository(ctx context.Context, db *sqlx.DB) (*BookingsRepository, error) {
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &BookingsRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+	}, nil
+}
+
+func (r *BookingsRepository) getStatement(ctx context.Context, queryId int) (*sqlx.Stmt, error) {
+	var err error
+	var statement *sqlx.Stmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			statement, err = atomicSession.Tx().PreparexContext(ctx, masterQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		statement = r.masterStmts[queryId]
+	}
+	return statement, err
+}
+
+func (r *BookingsRepository) getNamedStatement(ctx context.Context, queryId int) (*sqlx.NamedStmt, error) {
+	var err error
+	var namedStmt *sqlx.NamedStmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			namedStmt, err = atomicSession.Tx().PrepareNamedContext(ctx, masterNamedQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		namedStmt = r.masterNamedStmpts[queryId]
+	}
+	return namedStmt, err
+}
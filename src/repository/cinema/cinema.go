@@ -0,0 +1,140 @@
+package cinema
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/sqlutil"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+func (cr *CinemasRepository) GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Cinema, error) {
+	var Cinema []*entity.Cinema
+
+	param, err := json.Marshal(params)
+	if err != nil {
+		log.Println("marshal err: ", err)
+		return nil, err
+	}
+
+	err = cr.redis.WithCache(ctx, fmt.Sprintf(GetListCinemasRedisKey, param), &Cinema, func() (interface{}, error) {
+		var CinemaData []*entity.Cinema
+		err := cr.masterStmts[GetList].SelectContext(ctx, &CinemaData, sqlutil.EscapeLike(params.Keyword), params.Limit, params.Offset)
+		return CinemaData, err
+	})
+
+	if err != nil {
+		log.Println("GetCinemaList err: ", err)
+		return nil, err
+	}
+
+	return Cinema, nil
+}
+
+func (cr *CinemasRepository) GetCinemaCount(ctx context.Context, param contract.GetListParam) (int64, error) {
+	var count int64
+
+	err := cr.redis.WithCache(ctx, fmt.Sprintf(GetCinemasCountRedisKey, param.Keyword), &count, func() (interface{}, error) {
+		var countData int64
+		err := cr.masterStmts[GetCountList].GetContext(ctx, &countData, sqlutil.EscapeLike(param.Keyword))
+		return countData, err
+	})
+
+	if err != nil {
+		log.Println("GetCinemaCount err: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (cr *CinemasRepository) Get(ctx context.Context, id int) (entity.Cinema, error) {
+	var Cinema entity.Cinema
+	err := cr.redis.WithCache(ctx, fmt.Sprintf(GetDetailCinemasRedisKey, id), &Cinema, func() (interface{}, error) {
+		var CinemaData entity.Cinema
+		err := cr.masterStmts[GetByID].GetContext(ctx, &CinemaData, id)
+		return CinemaData, err
+	})
+
+	if err != nil {
+		log.Println(err)
+		return Cinema, err
+	}
+
+	return Cinema, nil
+}
+
+func (cr *CinemasRepository) Create(ctx context.Context, data *entity.Cinema) (entity.Cinema, error) {
+	var res entity.Cinema
+
+	namedStmt, err := cr.getNamedStatement(ctx, InsertCinema)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return res, err
+	}
+
+	if err = namedStmt.GetContext(ctx, &res, data); err != nil {
+		log.Println("insert cinema err: ", err)
+		return res, err
+	}
+
+	cr.invalidateCache(ctx)
+
+	return res, nil
+}
+
+func (cr *CinemasRepository) Update(ctx context.Context, data *entity.Cinema) error {
+	namedStmt, err := cr.getNamedStatement(ctx, UpdateCinema)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	res, err := namedStmt.ExecContext(ctx, data)
+	return cr.execUpdate(ctx, res, err)
+}
+
+func (cr *CinemasRepository) Delete(ctx context.Context, id int64) error {
+	stmt, err := cr.getStatement(ctx, Delete)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	cr.invalidateCache(ctx)
+
+	return nil
+}
+
+// execUpdate return sql.ErrNoRows when update statement does not touch any row
+func (cr *CinemasRepository) execUpdate(ctx context.Context, res sql.Result, err error) error {
+	if err != nil {
+		log.Println("exec err: ", err)
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("ID not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	cr.invalidateCache(ctx)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package cinema
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	AllFields       = `id, name, city, address, created_at, updated_at`
+	AllScreenFields = `id, cinema_id, name, seat_map, created_at, updated_at`
+
+	GetByID = iota + 100
+	GetList
+	GetCountList
+	Delete
+	GetScreenByID
+	GetScreens
+	DeleteScreen
+
+	InsertCinema = iota + 200
+	UpdateCinema
+	InsertScreen
+	UpdateScreen
+
+	// Redis Key
+
+	GetListCinemasRedisKey   = "movie:cinemas:getlist:%s"
+	GetCinemasCountRedisKey  = "movie:cinemas:getcount:%s"
+	GetDetailCinemasRedisKey = "movie:cinemas:getdetail:%d"
+	GetScreensRedisKey       = "movie:cinemas:getscreens:%d"
+	GetDetailScreensRedisKey = "movie:cinemas:getscreen:%d"
+	DeleteCinemaRedisKey     = "movie:cinemas:*"
+)
+
+// keywordFilter matches $1 against cinema name or city, an empty keyword matches every row
+const keywordFilter = `($1 = '' OR name ILIKE '%' || $1 || '%' ESCAPE '\' OR city ILIKE '%' || $1 || '%' ESCAPE '\')`
+
+var (
+	masterQueries = []string{
+		GetByID:       fmt.Sprintf("SELECT %s FROM cinemas WHERE id = $1 AND deleted_at IS NULL", AllFields),
+		GetList:       fmt.Sprintf("SELECT %s FROM cinemas WHERE deleted_at IS NULL AND %s ORDER BY city, name, id LIMIT $2 OFFSET $3", AllFields, keywordFilter),
+		GetCountList:  fmt.Sprintf("SELECT COUNT(*) FROM cinemas WHERE deleted_at IS NULL AND %s", keywordFilter),
+		Delete:        `UPDATE cinemas SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
+		GetScreenByID: fmt.Sprintf("SELECT %s FROM screens WHERE id = $1 AND deleted_at IS NULL", AllScreenFields),
+		GetScreens:    fmt.Sprintf("SELECT %s FROM screens WHERE cinema_id = $1 AND deleted_at IS NULL ORDER BY name, id", AllScreenFields),
+		DeleteScreen:  `UPDATE screens SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
+	}
+
+	masterNamedQueries = []string{
+		InsertCinema: fmt.Sprintf(`INSERT INTO cinemas (name, city, address, created_at) VALUES (:name, :city, :address, now()) RETURNING %s`, AllFields),
+		UpdateCinema: `UPDATE cinemas SET (name, city, address, updated_at) = (:name, :city, :address, now()) WHERE id = :id AND deleted_at IS NULL`,
+		InsertScreen: fmt.Sprintf(`INSERT INTO screens (cinema_id, name, seat_map, created_at) VALUES (:cinema_id, :name, :seat_map, now()) RETURNING %s`, AllScreenFields),
+		UpdateScreen: `UPDATE screens SET (name, seat_map, updated_at) = (:name, :seat_map, now()) WHERE id = :id AND deleted_at IS NULL`,
+	}
+)
+
+type CinemasRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+	redis             frsRedis.Redis
+}
+
+func InitCinemasRepository(ctx context.Context, db *sqlx.DB, redis frsRedis.Redis) (*CinemasRepository, error) {
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &CinemasRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+		redis:             redis,
+	}, nil
+}
+
+func (r *CinemasRepository) getStatement(ctx context.Context, queryId int) (*sqlx.Stmt, error) {
+	var err error
+	var statement *sqlx.Stmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			statement, err = atomicSession.Tx().PreparexContext(ctx, masterQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		statement = r.masterStmts[queryId]
+	}
+	return statement, err
+}
+
+func (r *CinemasRepository) getNamedStatement(ctx context.Context, queryId int) (*sqlx.NamedStmt, error) {
+	var err error
+	var namedStmt *sqlx.NamedStmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			namedStmt, err = atomicSession.Tx().PrepareNamedContext(ctx, masterNamedQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		namedStmt = r.masterNamedStmpts[queryId]
+	}
+	return namedStmt, err
+}
+
+func (r *CinemasRepository) invalidateCache(ctx context.Context) {
+	if err := r.redis.DelWithPattern(ctx, DeleteCinemaRedisKey); err != nil {
+		log.Println("delete redis err: ", err)
+	}
+}
@@ -0,0 +1,101 @@
+package cinema
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func (cr *CinemasRepository) GetScreens(ctx context.Context, cinemaID int64) ([]*entity.Screen, error) {
+	var Screen []*entity.Screen
+
+	err := cr.redis.WithCache(ctx, fmt.Sprintf(GetScreensRedisKey, cinemaID), &Screen, func() (interface{}, error) {
+		var ScreenData []*entity.Screen
+		err := cr.masterStmts[GetScreens].SelectContext(ctx, &ScreenData, cinemaID)
+		return ScreenData, err
+	})
+
+	if err != nil {
+		log.Println("GetScreens err: ", err)
+		return nil, err
+	}
+
+	return Screen, nil
+}
+
+func (cr *CinemasRepository) GetScreen(ctx context.Context, id int) (entity.Screen, error) {
+	var Screen entity.Screen
+	err := cr.redis.WithCache(ctx, fmt.Sprintf(GetDetailScreensRedisKey, id), &Screen, func() (interface{}, error) {
+		var ScreenData entity.Screen
+		err := cr.masterStmts[GetScreenByID].GetContext(ctx, &ScreenData, id)
+		return ScreenData, err
+	})
+
+	if err != nil {
+		log.Println(err)
+		return Screen, err
+	}
+
+	return Screen, nil
+}
+
+func (cr *CinemasRepository) CreateScreen(ctx context.Context, data *entity.Screen) (entity.Screen, error) {
+	var res entity.Screen
+
+	namedStmt, err := cr.getNamedStatement(ctx, InsertScreen)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return res, err
+	}
+
+	if err = namedStmt.GetContext(ctx, &res, data); err != nil {
+		log.Println("insert screen err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrDuplicateScreen
+		}
+		return res, err
+	}
+
+	cr.invalidateCache(ctx)
+
+	return res, nil
+}
+
+func (cr *CinemasRepository) UpdateScreen(ctx context.Context, data *entity.Screen) error {
+	namedStmt, err := cr.getNamedStatement(ctx, UpdateScreen)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	res, err := namedStmt.ExecContext(ctx, data)
+	if pgerr.IsUniqueViolation(err) {
+		log.Println("update screen err: ", err)
+		return appErr.ErrDuplicateScreen
+	}
+
+	return cr.execUpdate(ctx, res, err)
+}
+
+func (cr *CinemasRepository) DeleteScreen(ctx context.Context, id int64) error {
+	stmt, err := cr.getStatement(ctx, DeleteScreen)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	cr.invalidateCache(ctx)
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package genre
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func (gr *GenresRepository) GetList(ctx context.Context) ([]*entity.Genre, error) {
+	var Genre []*entity.Genre
+
+	err := gr.redis.WithCache(ctx, GetListGenresRedisKey, &Genre, func() (interface{}, error) {
+		var GenreData []*entity.Genre
+		err := gr.masterStmts[GetList].SelectContext(ctx, &GenreData)
+		return GenreData, err
+	})
+
+	if err != nil {
+		log.Println("GetGenreList err: ", err)
+		return nil, err
+	}
+
+	return Genre, nil
+}
+
+func (gr *GenresRepository) Get(ctx context.Context, id int) (entity.Genre, error) {
+	var Genre entity.Genre
+	err := gr.redis.WithCache(ctx, fmt.Sprintf(GetDetailGenresRedisKey, id), &Genre, func() (interface{}, error) {
+		var GenreData entity.Genre
+		err := gr.masterStmts[GetByID].GetContext(ctx, &GenreData, id)
+		return GenreData, err
+	})
+
+	if err != nil {
+		log.Println(err)
+		return Genre, err
+	}
+
+	return Genre, nil
+}
+
+func (gr *GenresRepository) Create(ctx context.Context, data *entity.Genre) (entity.Genre, error) {
+	var res entity.Genre
+
+	namedStmt, err := gr.getNamedStatement(ctx, InsertGenre)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return res, err
+	}
+
+	if err = namedStmt.GetContext(ctx, &res, data); err != nil {
+		log.Println("insert genre err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrDuplicateGenre
+		}
+		return res, err
+	}
+
+	gr.invalidateCache(ctx)
+
+	return res, nil
+}
+
+// Update rename the genre and increase version of its movies in one statement,
+// sql.ErrNoRows is returned when the genre does not exist
+func (gr *GenresRepository) Update(ctx context.Context, data *entity.Genre) error {
+	namedStmt, err := gr.getNamedStatement(ctx, UpdateGenre)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	var id int64
+	if err = namedStmt.GetContext(ctx, &id, data); err != nil {
+		log.Println("update genre err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrDuplicateGenre
+		}
+		return err
+	}
+
+	gr.invalidateCache(ctx)
+
+	return nil
+}
+
+// Delete soft delete the genre and increase version of its movies in one statement
+func (gr *GenresRepository) Delete(ctx context.Context, id int64) error {
+	stmt, err := gr.getStatement(ctx, Delete)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	gr.invalidateCache(ctx)
+
+	return nil
+}
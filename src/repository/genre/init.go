@@ -0,0 +1,120 @@
+package genre
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	AllFields = `id, name, created_at, updated_at`
+
+	GetByID = iota + 100
+	GetList
+	Delete
+
+	InsertGenre = iota + 200
+	UpdateGenre
+
+	// Redis Key
+
+	GetListGenresRedisKey   = "movie:genres:getlist"
+	GetDetailGenresRedisKey = "movie:genres:getdetail:%d"
+	DeleteGenreRedisKey     = "movie:genres:*"
+
+	// DeleteMovieRedisKey is invalidated too because movie response embed genre name
+	DeleteMovieRedisKey = "movie:movies:*"
+
+	// TouchGenreMovies increase version of the movies linked to the genre of the CTE named genre,
+	// movie response carry the genre name so its ETag change with the genre
+	TouchGenreMovies = `UPDATE movies SET version = version + 1, updated_at = now() ` +
+		`WHERE id IN (SELECT mg.movie_id FROM movie_genres mg JOIN genre g ON g.id = mg.genre_id)`
+)
+
+var (
+	masterQueries = []string{
+		GetByID: fmt.Sprintf("SELECT %s FROM genres WHERE id = $1 AND deleted_at IS NULL", AllFields),
+		GetList: fmt.Sprintf("SELECT %s FROM genres WHERE deleted_at IS NULL ORDER BY name", AllFields),
+		Delete: `WITH genre AS (UPDATE genres SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL RETURNING id) ` +
+			TouchGenreMovies,
+	}
+
+	masterNamedQueries = []string{
+		InsertGenre: fmt.Sprintf(`INSERT INTO genres (name, created_at) VALUES (:name, now()) RETURNING %s`, AllFields),
+		UpdateGenre: `WITH genre AS (UPDATE genres SET (name, updated_at) = (:name, now()) WHERE id = :id AND deleted_at IS NULL RETURNING id), ` +
+			`touched AS (` + TouchGenreMovies + `) SELECT id FROM genre`,
+	}
+)
+
+type GenresRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+	redis             frsRedis.Redis
+}
+
+func InitGenresRepository(ctx context.Context, db *sqlx.DB, redis frsRedis.Redis) (*GenresRepository, error) {
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &GenresRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+		redis:             redis,
+	}, nil
+}
+
+func (r *GenresRepository) getStatement(ctx context.Context, queryId int) (*sqlx.Stmt, error) {
+	var err error
+	var statement *sqlx.Stmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			statement, err = atomicSession.Tx().PreparexContext(ctx, masterQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		statement = r.masterStmts[queryId]
+	}
+	return statement, err
+}
+
+func (r *GenresRepository) getNamedStatement(ctx context.Context, queryId int) (*sqlx.NamedStmt, error) {
+	var err error
+	var namedStmt *sqlx.NamedStmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			namedStmt, err = atomicSession.Tx().PrepareNamedContext(ctx, masterNamedQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		namedStmt = r.masterNamedStmpts[queryId]
+	}
+	return namedStmt, err
+}
+
+func (r *GenresRepository) invalidateCache(ctx context.Context) {
+	for _, pattern := range []string{DeleteGenreRedisKey, DeleteMovieRedisKey} {
+		if err := r.redis.DelWithPattern(ctx, pattern); err != nil {
+			log.Println("delete redis err: ", err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package hold
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/redis/go-redis/v9"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func seatKeys(showtimeID int64, seats []string) []string {
+	keys := make([]string, len(seats))
+	for i, seat := range seats {
+		keys[i] = fmt.Sprintf(SeatHoldRedisKey, showtimeID, seat)
+	}
+	return keys
+}
+
+// Create lock every seat of the hold until it expire, it return ErrSeatTaken
+// with the seats that is already held when any of them is not free
+func (hr *HoldsRepository) Create(ctx context.Context, hold *entity.Hold, ttl time.Duration) ([]string, error) {
+	keys := seatKeys(hold.ShowtimeID, hold.Seats)
+
+	taken, err := acquireSeats.Run(ctx, hr.conn, keys, hold.ID, ttl.Milliseconds()).Int64Slice()
+	if err != nil {
+		log.Println("acquire seats err: ", err)
+		return nil, err
+	}
+
+	if len(taken) > 0 {
+		seats := make([]string, len(taken))
+		for i, idx := range taken {
+			seats[i] = hold.Seats[idx-1]
+		}
+		log.Println("acquire seats err: ", appErr.ErrSeatTaken, seats)
+		return seats, appErr.ErrSeatTaken
+	}
+
+	data, err := json.Marshal(hold)
+	if err != nil {
+		log.Println("marshal err: ", err)
+		hr.releaseSeats(ctx, hold)
+		return nil, err
+	}
+
+	if err = hr.redis.Set(ctx, fmt.Sprintf(HoldRedisKey, hold.ID), string(data), ttl); err != nil {
+		log.Println("set hold err: ", err)
+		hr.releaseSeats(ctx, hold)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Get return ErrHoldIdNotFound when the hold does not exist or is expired
+func (hr *HoldsRepository) Get(ctx context.Context, id string) (entity.Hold, error) {
+	var hold entity.Hold
+
+	val, err := hr.redis.Get(ctx, fmt.Sprintf(HoldRedisKey, id))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			err = appErr.ErrHoldIdNotFound
+		}
+		log.Println("get hold err: ", err)
+		return hold, err
+	}
+
+	if err = json.Unmarshal([]byte(val), &hold); err != nil {
+		log.Println("unmarshal hold err: ", err)
+		return hold, err
+	}
+
+	return hold, nil
+}
+
+// Extend check that every seat is still held by the hold and keep them held for at
+// least ttl, it return ErrHoldIdNotFound when any seat expired or is held by another hold
+func (hr *HoldsRepository) Extend(ctx context.Context, hold *entity.Hold, ttl time.Duration) error {
+	owned, err := extendSeats.Run(ctx, hr.conn, seatKeys(hold.ShowtimeID, hold.Seats), hold.ID, ttl.Milliseconds()).Int()
+	if err != nil {
+		log.Println("extend seats err: ", err)
+		return err
+	}
+
+	if owned == 0 {
+		log.Println("extend seats err: ", appErr.ErrHoldIdNotFound, hold.ID)
+		return appErr.ErrHoldIdNotFound
+	}
+
+	return nil
+}
+
+// GetHeldSeats return seats of the showtime that is currently held
+func (hr *HoldsRepository) GetHeldSeats(ctx context.Context, showtimeID int64, seats []string) (map[string]bool, error) {
+	held := make(map[string]bool)
+	if len(seats) == 0 {
+		return held, nil
+	}
+
+	values, err := hr.conn.MGet(ctx, seatKeys(showtimeID, seats)...).Result()
+	if err != nil {
+		log.Println("get held seats err: ", err)
+		return nil, err
+	}
+
+	for i, v := range values {
+		if v != nil {
+			held[seats[i]] = true
+		}
+	}
+
+	return held, nil
+}
+
+// Release unlock seats of the hold and delete it
+func (hr *HoldsRepository) Release(ctx context.Context, hold *entity.Hold) error {
+	if err := hr.releaseSeats(ctx, hold); err != nil {
+		return err
+	}
+
+	if err := hr.redis.Del(ctx, fmt.Sprintf(HoldRedisKey, hold.ID)); err != nil {
+		log.Println("delete hold err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (hr *HoldsRepository) releaseSeats(ctx context.Context, hold *entity.Hold) error {
+	err := releaseSeats.Run(ctx, hr.conn, seatKeys(hold.ShowtimeID, hold.Seats), hold.ID).Err()
+	if err != nil {
+		log.Println("release seats err: ", err)
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+
+	frsRedis "github.com/Risuii/frs-lib/redis"
+)
+
+const (
+	// Redis Key
+
+	// seat key of a showtime share one hash tag so scripts touching
+	// several seats stay on one slot
+	SeatHoldRedisKey = "movie:holds:seat:{%d}:%s"
+	HoldRedisKey     = "movie:holds:hold:%s"
+)
+
+var ErrUnsupportedRedis = errors.New("seat hold need redis client connection")
+
+var (
+	// acquireSeats set every seat key to the hold id only when none of them exist,
+	// it return index (1 based) of seat that is already held
+	acquireSeats = redis.NewScript(`
+		local taken = {}
+		for i, key in ipairs(KEYS) do
+			if redis.call('EXISTS', key) == 1 then
+				table.insert(taken, i)
+			end
+		end
+		if #taken > 0 then
+			return taken
+		end
+		for _, key in ipairs(KEYS) do
+			redis.call('SET', key, ARGV[1], 'PX', ARGV[2])
+		end
+		return taken
+	`)
+
+	// extendSeats keep every seat key alive for at least ARGV[2] milliseconds
+	// only when all of them is still held by the hold id, it return 0 otherwise
+	extendSeats = redis.NewScript(`
+		for _, key in ipairs(KEYS) do
+			if redis.call('GET', key) ~= ARGV[1] then
+				return 0
+			end
+		end
+		for _, key in ipairs(KEYS) do
+			if redis.call('PTTL', key) < tonumber(ARGV[2]) then
+				redis.call('PEXPIRE', key, ARGV[2])
+			end
+		end
+		return 1
+	`)
+
+	// releaseSeats delete seat key that is still held by the hold id
+	releaseSeats = redis.NewScript(`
+		for _, key in ipairs(KEYS) do
+			if redis.call('GET', key) == ARGV[1] then
+				redis.call('DEL', key)
+			end
+		end
+		return 1
+	`)
+)
+
+type HoldsRepository struct {
+	redis frsRedis.Redis
+	conn  *redis.Client
+}
+
+// InitHoldsRepository need redis connection of frsRedis to run lock script atomically
+func InitHoldsRepository(ctx context.Context, rds frsRedis.Redis) (*HoldsRepository, error) {
+	cfg, ok := rds.(*frsRedis.RedisCfg)
+	if !ok || cfg.Conn == nil {
+		log.Println("InitHoldsRepository err:", ErrUnsupportedRedis)
+		return nil, ErrUnsupportedRedis
+	}
+
+	return &HoldsRepository{
+		redis: rds,
+		conn:  cfg.Conn,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package movie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+// GetByIDs return active movie of the ids keyed by id, missing or deleted movie is not in the map,
+// cached movie is read with one MGET of the detail key, the misses is loaded with one query
+// and written back to the detail key so the next Get and GetByIDs hit the cache
+func (mr *MoviesRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]*entity.Movie, error) {
+	movies := make(map[int64]*entity.Movie, len(ids))
+	if len(ids) == 0 {
+		return movies, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf(GetDetailMoviesRedisKey, id)
+	}
+
+	// like WithCache, cache error is logged and every id is loaded from the database
+	values, err := mr.conn.MGet(ctx, keys...).Result()
+	if err != nil {
+		log.Println("mget movie err: ", err)
+		values = make([]interface{}, len(ids))
+	}
+
+	var misses []int64
+	for i, value := range values {
+		if cached, ok := value.(string); ok {
+			var dataMovie entity.Movie
+			if err := json.Unmarshal([]byte(cached), &dataMovie); err == nil {
+				movies[ids[i]] = &dataMovie
+				continue
+			}
+		}
+
+		misses = append(misses, ids[i])
+	}
+
+	if len(misses) == 0 {
+		return movies, nil
+	}
+
+	var loaded []*entity.Movie
+	err = mr.masterStmts[GetByIDs].SelectContext(ctx, &loaded, pq.Array(misses))
+	if err != nil {
+		log.Println("get movie by ids err: ", err)
+		return nil, err
+	}
+
+	for _, m := range loaded {
+		movies[m.Id] = m
+	}
+
+	_, err = mr.conn.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, m := range loaded {
+			dataJSON, err := json.Marshal(m)
+			if err != nil {
+				return err
+			}
+			pipe.Set(ctx, fmt.Sprintf(GetDetailMoviesRedisKey, m.Id), dataJSON, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("backfill movie cache err: ", err)
+	}
+
+	return movies, nil
+}
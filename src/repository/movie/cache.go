@@ -0,0 +1,50 @@
+package movie
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+type ctxKeyCacheInvalidation struct{}
+
+// cacheInvalidation remember that a write happened while invalidation is deferred
+type cacheInvalidation struct {
+	mu      sync.Mutex
+	pending bool
+}
+
+// DeferCacheInvalidation return context under which write only mark the movie cache as stale,
+// the returned func delete the cache once when any write happened. It is used by batch write so
+// the cache is not scanned and deleted for every row, caller start the transaction from the returned context
+func (mr *MoviesRepository) DeferCacheInvalidation(ctx context.Context) (context.Context, func()) {
+	deferred := &cacheInvalidation{}
+
+	return context.WithValue(ctx, ctxKeyCacheInvalidation{}, deferred), func() {
+		deferred.mu.Lock()
+		pending := deferred.pending
+		deferred.mu.Unlock()
+
+		if pending {
+			mr.deleteCache(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+// invalidateCache delete every cached movie key, it is called after every write
+func (mr *MoviesRepository) invalidateCache(ctx context.Context) {
+	if deferred, ok := ctx.Value(ctxKeyCacheInvalidation{}).(*cacheInvalidation); ok {
+		deferred.mu.Lock()
+		deferred.pending = true
+		deferred.mu.Unlock()
+		return
+	}
+
+	mr.deleteCache(ctx)
+}
+
+func (mr *MoviesRepository) deleteCache(ctx context.Context) {
+	if err := mr.redis.DelWithPattern(ctx, DeleteMovieRedisKey); err != nil {
+		log.Println("delete movie cache err: ", err)
+	}
+}
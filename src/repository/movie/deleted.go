@@ -0,0 +1,136 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+// GetDeletedList return soft deleted movie, latest deleted first, it is not cached
+func (mr *MoviesRepository) GetDeletedList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error) {
+	var Movie []*entity.Movie
+
+	err := mr.masterStmts[GetDeletedList].SelectContext(ctx, &Movie, params.Limit, params.Offset)
+	if err != nil {
+		log.Println("get deleted movie err: ", err)
+		return nil, err
+	}
+
+	return Movie, nil
+}
+
+func (mr *MoviesRepository) GetDeletedCount(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := mr.masterStmts[GetDeletedCount].GetContext(ctx, &count)
+	if err != nil {
+		log.Println("get deleted movie count err: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Restore undo soft delete, it return sql.ErrNoRows when the movie is not deleted
+func (mr *MoviesRepository) Restore(ctx context.Context, id int64) error {
+	stmt, err := mr.getStatement(ctx, Restore)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		log.Println("restore movie err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			// title is taken by another movie while this one is deleted
+			err = appErr.ErrDuplicatemovie
+		}
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("ID not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	mr.invalidateCache(ctx)
+
+	return nil
+}
+
+// HasBookings report whether any showtime of the movie is booked
+func (mr *MoviesRepository) HasBookings(ctx context.Context, id int64) (bool, error) {
+	var exist bool
+
+	stmt, err := mr.getStatement(ctx, HasBookings)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return false, err
+	}
+
+	if err = stmt.GetContext(ctx, &exist, id); err != nil {
+		log.Println("get movie bookings err: ", err)
+		return false, err
+	}
+
+	return exist, nil
+}
+
+// Purge permanently delete the movie and every row linked to it, it return
+// poster keys of the movie so caller can remove them from storage.
+// sql.ErrNoRows is returned when the movie does not exist or is booked
+func (mr *MoviesRepository) Purge(ctx context.Context, id int64) (entity.ImageKeys, error) {
+	var keys entity.ImageKeys
+
+	stmt, err := mr.getStatement(ctx, Purge)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return nil, err
+	}
+
+	if err = stmt.GetContext(ctx, &keys, id); err != nil {
+		log.Println("purge movie err: ", err)
+		return nil, err
+	}
+
+	mr.invalidateCache(ctx)
+
+	return keys, nil
+}
+
+// PurgeDeletedBefore permanently delete movie soft deleted before the time,
+// booked movie is kept. It return poster keys of every purged movie
+func (mr *MoviesRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) ([]entity.ImageKeys, error) {
+	var keys []entity.ImageKeys
+
+	stmt, err := mr.getStatement(ctx, PurgeDeletedBefore)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return nil, err
+	}
+
+	if err = stmt.SelectContext(ctx, &keys, before); err != nil {
+		log.Println("purge deleted movie err: ", err)
+		return nil, err
+	}
+
+	if len(keys) > 0 {
+		mr.invalidateCache(ctx)
+	}
+
+	return keys, nil
+}
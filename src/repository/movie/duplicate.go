@@ -0,0 +1,34 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// GetDuplicates return pair of active movie with similar title, most similar first, it is not cached
+func (mr *MoviesRepository) GetDuplicates(ctx context.Context, params contract.GetDuplicateParam) ([]*entity.MovieDuplicate, error) {
+	var Duplicate []*entity.MovieDuplicate
+
+	err := mr.masterStmts[GetDuplicates].SelectContext(ctx, &Duplicate, params.Threshold, params.Limit, params.Offset)
+	if err != nil {
+		log.Println("get movie duplicates err: ", err)
+		return nil, err
+	}
+
+	return Duplicate, nil
+}
+
+func (mr *MoviesRepository) GetDuplicateCount(ctx context.Context, threshold float64) (int64, error) {
+	var count int64
+
+	err := mr.masterStmts[GetDuplicateCount].GetContext(ctx, &count, threshold)
+	if err != nil {
+		log.Println("get movie duplicate count err: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,37 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// Export call fn for every movie matching the list filter in params.Sort order,
+// row is scanned from the result set one at a time and is not cached so the catalog is never held in memory,
+// iteration stop at the first error of fn
+func (mr *MoviesRepository) Export(ctx context.Context, params contract.GetListParam, fn func(m *entity.Movie) error) error {
+	query, args := buildListQuery(params).exportQuery(params)
+
+	rows, err := mr.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		log.Println("export movie query err: ", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dataMovie entity.Movie
+		if err = rows.StructScan(&dataMovie); err != nil {
+			log.Println("export movie scan err: ", err)
+			return err
+		}
+
+		if err = fn(&dataMovie); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,90 @@
+package movie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+// ImportJobTTL is how long report of an import is kept after its last update
+const ImportJobTTL = 24 * time.Hour
+
+// LockMovieIDByTitle return active movie with the normalized title and lock
+// it until the transaction end, it return sql.ErrNoRows when there is none
+func (mr *MoviesRepository) LockMovieIDByTitle(ctx context.Context, title string) (int64, error) {
+	var id int64
+
+	stmt, err := mr.getStatement(ctx, LockMovieIDByTitle)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return id, err
+	}
+
+	if err = stmt.GetContext(ctx, &id, title); err != nil {
+		log.Println("lock movie by title err: ", err)
+		return id, err
+	}
+
+	return id, nil
+}
+
+// GetGenreIDs return which of the genre id exist
+func (mr *MoviesRepository) GetGenreIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	var genreIDs []int64
+
+	err := mr.masterStmts[GetGenreIDs].SelectContext(ctx, &genreIDs, pq.Array(ids))
+	if err != nil {
+		log.Println("get genre ids err: ", err)
+		return nil, err
+	}
+
+	return genreIDs, nil
+}
+
+// SaveImportJob set UpdatedAt of the job, it is the heartbeat of a running import
+func (mr *MoviesRepository) SaveImportJob(ctx context.Context, job *entity.ImportJob) error {
+	job.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		log.Println("marshal err: ", err)
+		return err
+	}
+
+	if err = mr.redis.Set(ctx, fmt.Sprintf(ImportJobRedisKey, job.ID), string(data), ImportJobTTL); err != nil {
+		log.Println("set import job err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetImportJob return ErrImportJobNotFound when the job does not exist or is expired
+func (mr *MoviesRepository) GetImportJob(ctx context.Context, id string) (entity.ImportJob, error) {
+	var job entity.ImportJob
+
+	val, err := mr.redis.Get(ctx, fmt.Sprintf(ImportJobRedisKey, id))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			err = appErr.ErrImportJobNotFound
+		}
+		log.Println("get import job err: ", err)
+		return job, err
+	}
+
+	if err = json.Unmarshal([]byte(val), &job); err != nil {
+		log.Println("unmarshal import job err: ", err)
+		return job, err
+	}
+
+	return job, nil
+}
@@ -2,10 +2,12 @@ package movie
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
 
 	frsAtomic "github.com/Risuii/frs-lib/atomic"
 	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
@@ -14,39 +16,157 @@ import (
 )
 
 const (
-	AllFields = `id, title, description, rating, image, created_at, updated_at`
+	AllFields = `id, title, description, rating, average_rating, rating_count, image, image_keys, runtime, version, created_at, updated_at, ` + GenreNamesField
+
+	// GenreNamesField select name of genre linked to each movie row
+	GenreNamesField = `ARRAY(SELECT g.name FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id ` +
+		`WHERE mg.movie_id = movies.id AND g.deleted_at IS NULL ORDER BY g.name) AS genres`
 
 	GetByID = iota + 100
 	GetByMovieID
-	GetList
-	GetCountList
 	GetLatestMovieID
 	Delete
+	DeleteMovieGenres
+	InsertMovieGenres
+	GetMovieCredits
+	DeleteMovieCredits
+	UpdateMovieImageKeys
+	GetDeletedList
+	GetDeletedCount
+	Restore
+	HasBookings
+	Purge
+	PurgeDeletedBefore
+	TouchMovie
+	GetMovieSnapshot
+	LockMovieSnapshot
+	GetRevisions
+	GetRevisionCount
+	GetRevision
+	GetDuplicates
+	GetDuplicateCount
+	LockMovieIDByTitle
+	GetGenreIDs
+	GetByIDs
+	SearchMovies
+	GetSearchCount
+	SuggestMovies
 
 	InsertMovie = iota + 200
 	UpdateMovie
+	InsertMovieCredit
+	InsertMovieRevision
 
 	// Redis Key
 
-	GetListMoviesRedisKey   = "movie:movies:getlist:%s"
-	GetDetailMoviesRedisKey = "movie:movies:getdetail:%d"
-	GetMoviesCountRedisKey  = "movie:movies:getcount:%s"
-	DeleteMovieRedisKey     = "movie:movies:*"
+	GetListMoviesRedisKey       = "movie:movies:getlist:%s"
+	GetCursorListMoviesRedisKey = "movie:movies:getcursorlist:%s"
+	GetDetailMoviesRedisKey     = "movie:movies:getdetail:%d"
+	GetMoviesCountRedisKey      = "movie:movies:getcount:%s"
+	GetMovieCreditsRedisKey     = "movie:movies:getcredits:%d"
+	DeleteMovieRedisKey         = "movie:movies:*"
+	ImportJobRedisKey           = "movie:imports:job:%s"
+	// SuggestMoviesRedisKey match DeleteMovieRedisKey so suggestion is invalidated by every movie write
+	SuggestMoviesRedisKey = "movie:movies:suggest:%d:%s"
+
+	// NormalizedTitleField is the key of movies_normalized_title_key index, the class is the whitespace of strings.Fields
+	NormalizedTitleField = `lower(btrim(regexp_replace(title, '[\t\n\v\f\r \u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+', ' ', 'g'), ' '))`
+
+	// MovieSnapshotField build entity.MovieSnapshot of a movie row as jsonb
+	MovieSnapshotField = `jsonb_build_object('title', title, 'description', description, 'rating', rating, 'image', image, 'runtime', runtime,
+		'genre_ids', ARRAY(SELECT mg.genre_id FROM movie_genres mg WHERE mg.movie_id = movies.id ORDER BY mg.genre_id),
+		'deleted', deleted_at IS NOT NULL, 'version', version)`
+
+	RevisionFields = `id, movie_id, revision, action, actor, request_id, before, after, diff, revert_of, created_at`
+
+	// DuplicateCondition pair active movie whose title similarity is at least $1, % use
+	// the trigram index with pg_trgm.similarity_threshold as the lower bound
+	// SearchCondition match active movie with to_tsquery of $2 using configuration $1
+	SearchCondition = `FROM movies, to_tsquery($1::regconfig, $2) query WHERE deleted_at IS NULL AND search_vector @@ query`
+
+	// SearchHighlightOption is ts_headline option of description, title is highlighted whole
+	SearchHighlightOption = `MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=" ... "`
+
+	// SuggestScore weigh title starting with the query highest, then word similarity of the query
+	// to the title, editorial rating out of 10 and number of review as popularity
+	SuggestScore = `0.5 * (lower(title) LIKE $1 || '%' ESCAPE '\')::int
+		+ 0.3 * word_similarity($2, lower(title))
+		+ 0.1 * LEAST(COALESCE(rating, 0) / 10, 1)
+		+ 0.1 * LEAST(ln(1 + rating_count) / ln(1000), 1)`
+
+	DuplicateCondition = `FROM movies a JOIN movies b ON a.id < b.id AND lower(a.title) % lower(b.title)
+		WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL AND similarity(lower(a.title), lower(b.title)) >= $1`
 )
 
+var ErrUnsupportedRedis = errors.New("movie batch get need redis client connection")
+
 var (
 	masterQueries = []string{
-		GetByID:          fmt.Sprintf("SELECT %s FROM movies WHERE id = $1 AND deleted_at IS NULL", AllFields),
-		GetByMovieID:     fmt.Sprintf("SELECT %s FROM movies WHERE id = $1 And deleted_at IS NULL", AllFields),
-		GetList:          fmt.Sprintf(`SELECT %s FROM movies WHERE deleted_at IS NULL`, AllFields),
-		GetCountList:     `SELECT COUNT(*) FROM movies WHERE deleted_at IS NULL`,
-		GetLatestMovieID: `SELECT MAX(id) FROM movies`,
-		Delete:           `UPDATE movies set deleted_at=now() WHERE id = $1`,
+		GetByID:           fmt.Sprintf("SELECT %s FROM movies WHERE id = $1 AND deleted_at IS NULL", AllFields),
+		GetByMovieID:      fmt.Sprintf("SELECT %s FROM movies WHERE id = $1 And deleted_at IS NULL", AllFields),
+		GetLatestMovieID:  `SELECT MAX(id) FROM movies`,
+		Delete:            `UPDATE movies set deleted_at=now(), version = version + 1 WHERE id = $1`,
+		DeleteMovieGenres: `DELETE FROM movie_genres WHERE movie_id = $1`,
+		InsertMovieGenres: `WITH inserted AS (
+			INSERT INTO movie_genres (movie_id, genre_id)
+			SELECT CAST($1 AS bigint), id FROM genres WHERE id = ANY($2) AND deleted_at IS NULL
+			ON CONFLICT DO NOTHING RETURNING genre_id
+		) SELECT g.name FROM inserted i JOIN genres g ON g.id = i.genre_id ORDER BY g.name`,
+		GetMovieCredits: `SELECT c.id, c.movie_id, c.person_id, c.role, c.character_name, c.billing_order, p.name AS person_name, m.title AS movie_title
+			FROM movie_credits c
+			JOIN people p ON p.id = c.person_id AND p.deleted_at IS NULL
+			JOIN movies m ON m.id = c.movie_id
+			WHERE c.movie_id = $1
+			ORDER BY CASE c.role WHEN 'director' THEN 0 WHEN 'writer' THEN 1 ELSE 2 END, c.billing_order, c.id`,
+		DeleteMovieCredits:   `DELETE FROM movie_credits WHERE movie_id = $1`,
+		UpdateMovieImageKeys: `UPDATE movies SET (image_keys, version, updated_at) = ($2, version + 1, now()) WHERE id = $1 AND deleted_at IS NULL`,
+		GetDeletedList:       fmt.Sprintf("SELECT %s, deleted_at FROM movies WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT $1 OFFSET $2", AllFields),
+		GetDeletedCount:      `SELECT COUNT(*) FROM movies WHERE deleted_at IS NOT NULL`,
+		Restore:              `UPDATE movies SET deleted_at = NULL, version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NOT NULL`,
+		HasBookings:          `SELECT EXISTS (SELECT 1 FROM showtimes s JOIN bookings b ON b.showtime_id = s.id WHERE s.movie_id = $1)`,
+		// Purge and PurgeDeletedBefore keep movie with booking because
+		// deleting it would cascade to customer booking
+		Purge: `DELETE FROM movies m WHERE m.id = $1 AND NOT EXISTS (
+			SELECT 1 FROM showtimes s JOIN bookings b ON b.showtime_id = s.id WHERE s.movie_id = m.id
+		) RETURNING m.image_keys`,
+		PurgeDeletedBefore: `DELETE FROM movies m WHERE m.deleted_at < $1 AND NOT EXISTS (
+			SELECT 1 FROM showtimes s JOIN bookings b ON b.showtime_id = s.id WHERE s.movie_id = m.id
+		) RETURNING m.image_keys`,
+		// TouchMovie increase version when row linked to the movie is changed
+		TouchMovie:        `UPDATE movies SET version = version + 1, updated_at = now() WHERE id = $1`,
+		GetMovieSnapshot:  fmt.Sprintf("SELECT %s FROM movies WHERE id = $1", MovieSnapshotField),
+		LockMovieSnapshot: fmt.Sprintf("SELECT %s FROM movies WHERE id = $1 FOR UPDATE", MovieSnapshotField),
+		GetRevisions:      fmt.Sprintf("SELECT %s FROM movie_revisions WHERE movie_id = $1 ORDER BY revision DESC LIMIT $2 OFFSET $3", RevisionFields),
+		GetRevisionCount:  `SELECT COUNT(*) FROM movie_revisions WHERE movie_id = $1`,
+		GetRevision:       fmt.Sprintf("SELECT %s FROM movie_revisions WHERE movie_id = $1 AND revision = $2", RevisionFields),
+		GetDuplicates: fmt.Sprintf(`SELECT a.id AS movie_id, a.title AS movie_title, b.id AS duplicate_id, b.title AS duplicate_title,
+			similarity(lower(a.title), lower(b.title)) AS similarity %s ORDER BY similarity DESC, a.id, b.id LIMIT $2 OFFSET $3`, DuplicateCondition),
+		GetDuplicateCount:  fmt.Sprintf("SELECT COUNT(*) %s", DuplicateCondition),
+		LockMovieIDByTitle: fmt.Sprintf("SELECT id FROM movies WHERE %s = $1 AND deleted_at IS NULL FOR UPDATE", NormalizedTitleField),
+		GetGenreIDs:        `SELECT id FROM genres WHERE id = ANY($1) AND deleted_at IS NULL`,
+		GetByIDs:           fmt.Sprintf("SELECT %s FROM movies WHERE id = ANY($1) AND deleted_at IS NULL", AllFields),
+		// only the page is highlighted, ts_headline read the whole text of every row it is called with
+		SearchMovies: fmt.Sprintf(`SELECT m.*,
+			ts_headline($1::regconfig, m.title, to_tsquery($1::regconfig, $2), 'HighlightAll=true, ' || $5) AS title_highlight,
+			ts_headline($1::regconfig, COALESCE(m.description, ''), to_tsquery($1::regconfig, $2), '%s, ' || $5) AS description_highlight
+			FROM (SELECT %s, ts_rank(search_vector, query) AS rank %s ORDER BY rank DESC, id LIMIT $3 OFFSET $4) m
+			ORDER BY m.rank DESC, m.id`, SearchHighlightOption, AllFields, SearchCondition),
+		GetSearchCount: fmt.Sprintf("SELECT COUNT(*) %s", SearchCondition),
+		// prefix and <% both use the trigram index of lower(title)
+		SuggestMovies: fmt.Sprintf(`SELECT id, title, COALESCE(image, '') AS image, COALESCE(rating, 0) AS rating, %s AS score
+			FROM movies WHERE deleted_at IS NULL AND (lower(title) LIKE $1 || '%%' ESCAPE '\' OR $2 <%% lower(title))
+			ORDER BY score DESC, id LIMIT $3`, SuggestScore),
 	}
 
 	masterNamedQueries = []string{
-		InsertMovie: `INSERT INTO movies (title, description, rating, image, created_at) VALUES (:title, :description, :rating, :image, now()) RETURNING id, title, description, rating, image, created_at, updated_at`,
-		UpdateMovie: `UPDATE movies SET (title, description, rating, image, updated_at) = (:title, :description, :rating, :image, now()) WHERE id = :id`,
+		InsertMovie: `INSERT INTO movies (title, description, rating, image, runtime, created_at) VALUES (:title, :description, :rating, :image, :runtime, now()) RETURNING id, title, description, rating, image, runtime, version, created_at, updated_at`,
+		UpdateMovie: `UPDATE movies SET (title, description, rating, image, runtime, version, updated_at) = (:title, :description, :rating, :image, :runtime, version + 1, now()) WHERE id = :id RETURNING version`,
+		InsertMovieCredit: `INSERT INTO movie_credits (movie_id, person_id, role, character_name, billing_order)
+			SELECT CAST(:movie_id AS bigint), id, CAST(:role AS varchar), CAST(:character_name AS varchar), CAST(:billing_order AS integer) FROM people WHERE id = :person_id AND deleted_at IS NULL`,
+		// revision number is sequential per movie, caller lock the movie row first
+		InsertMovieRevision: `INSERT INTO movie_revisions (movie_id, revision, action, actor, request_id, before, after, diff, revert_of, created_at)
+			VALUES (:movie_id, (SELECT COALESCE(MAX(revision), 0) + 1 FROM movie_revisions WHERE movie_id = :movie_id),
+			:action, :actor, :request_id, :before, :after, :diff, :revert_of, now()) RETURNING id, revision, created_at`,
 	}
 )
 
@@ -55,9 +175,17 @@ type MoviesRepository struct {
 	masterStmts       []*sqlx.Stmt
 	masterNamedStmpts []*sqlx.NamedStmt
 	redis             frsRedis.Redis
+	conn              *redis.Client
 }
 
-func InitMoviesRepository(ctx context.Context, db *sqlx.DB, redis frsRedis.Redis) (*MoviesRepository, error) {
+// InitMoviesRepository need redis connection of frsRedis to read many cached movie with MGET
+func InitMoviesRepository(ctx context.Context, db *sqlx.DB, rds frsRedis.Redis) (*MoviesRepository, error) {
+	cfg, ok := rds.(*frsRedis.RedisCfg)
+	if !ok || cfg.Conn == nil {
+		log.Println("InitMoviesRepository err:", ErrUnsupportedRedis)
+		return nil, ErrUnsupportedRedis
+	}
+
 	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
 	if err != nil {
 		log.Println("PrepareQueries err:", err)
@@ -74,7 +202,8 @@ func InitMoviesRepository(ctx context.Context, db *sqlx.DB, redis frsRedis.Redis
 		db:                db,
 		masterStmts:       stmpts,
 		masterNamedStmpts: namedStmpts,
-		redis:             redis,
+		redis:             rds,
+		conn:              cfg.Conn,
 	}, nil
 }
 
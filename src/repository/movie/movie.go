@@ -8,14 +8,16 @@ import (
 	"log"
 
 	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
 	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/lib/pq"
+
+	appErr "github.com/Risuii/movie/src/errors"
 )
 
 func (mr *MoviesRepository) GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error) {
 	var Movie []*entity.Movie
 
-	stringQuery := masterQueries[GetList]
-
 	param, err := json.Marshal(params)
 	if err != nil {
 		log.Println("marshal err: ", err)
@@ -23,33 +25,65 @@ func (mr *MoviesRepository) GetList(ctx context.Context, params contract.GetList
 	}
 
 	err = mr.redis.WithCache(ctx, fmt.Sprintf(GetListMoviesRedisKey, param), &Movie, func() (interface{}, error) {
-		rows, err := mr.db.NamedQueryContext(ctx, stringQuery, params)
-		if err != nil {
-			log.Println("named query err: ", err)
-			return nil, err
-		}
+		query, args := buildListQuery(params).selectQuery(params)
+		return mr.selectMovies(ctx, query, args)
+	})
 
-		for rows.Next() {
-			var dataMovie entity.Movie
-			err = rows.StructScan(&dataMovie)
-			if err != nil {
-				return nil, err
-			}
+	if err != nil {
+		log.Println("GetMovieList err: ", err)
+		return nil, err
+	}
 
-			Movie = append(Movie, &dataMovie)
-		}
+	return Movie, nil
+}
+
+// GetCursorList return at most params.Limit+1 movie after params.Cursor,
+// movie is returned in reverse order when the cursor is a backward cursor
+func (mr *MoviesRepository) GetCursorList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error) {
+	var Movie []*entity.Movie
 
-		return Movie, nil
+	param, err := json.Marshal(params)
+	if err != nil {
+		log.Println("marshal err: ", err)
+		return nil, err
+	}
+
+	err = mr.redis.WithCache(ctx, fmt.Sprintf(GetCursorListMoviesRedisKey, param), &Movie, func() (interface{}, error) {
+		query, args := buildListQuery(params).cursorSelectQuery(params)
+		return mr.selectMovies(ctx, query, args)
 	})
 
 	if err != nil {
-		log.Println("GetMovieList err: ", err)
+		log.Println("GetCursorList err: ", err)
 		return nil, err
 	}
 
 	return Movie, nil
 }
 
+func (mr *MoviesRepository) selectMovies(ctx context.Context, query string, args []interface{}) ([]*entity.Movie, error) {
+	var Movie []*entity.Movie
+
+	rows, err := mr.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		log.Println("query err: ", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dataMovie entity.Movie
+		err = rows.StructScan(&dataMovie)
+		if err != nil {
+			return nil, err
+		}
+
+		Movie = append(Movie, &dataMovie)
+	}
+
+	return Movie, rows.Err()
+}
+
 func (mr *MoviesRepository) GetMovieCount(ctx context.Context, param contract.GetListParam) (int64, error) {
 	var count int64
 
@@ -61,7 +95,8 @@ func (mr *MoviesRepository) GetMovieCount(ctx context.Context, param contract.Ge
 
 	err = mr.redis.WithCache(ctx, fmt.Sprintf(GetMoviesCountRedisKey, params), &count, func() (interface{}, error) {
 		var countData int64
-		err := mr.masterStmts[GetCountList].Get(&countData)
+		query, args := buildListQuery(param).countQuery()
+		err := mr.db.GetContext(ctx, &countData, query, args...)
 		return countData, err
 	})
 
@@ -89,36 +124,24 @@ func (mr *MoviesRepository) Get(ctx context.Context, id int) (entity.Movie, erro
 	return Movie, nil
 }
 
+// Update write editable field of the movie and fill data.Version with the new version,
+// it return sql.ErrNoRows when the movie does not exist
 func (mr *MoviesRepository) Update(ctx context.Context, data *entity.Movie) error {
-	var rowsAffected int64
-
 	namedStmt, err := mr.getNamedStatement(ctx, UpdateMovie)
 	if err != nil {
 		log.Println("get named statement err: ", err)
 		return err
 	}
 
-	res, err := namedStmt.ExecContext(ctx, data)
-	if err != nil {
-		log.Println("exec err: ", err)
-		return err
-	}
-
-	rowsAffected, err = res.RowsAffected()
-	if err != nil {
-		log.Println("Get rows affected err: ", err)
+	if err = namedStmt.GetContext(ctx, &data.Version, data); err != nil {
+		log.Println("update movie err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrDuplicatemovie
+		}
 		return err
 	}
 
-	if rowsAffected == 0 {
-		log.Println("ID not exist err: ", sql.ErrNoRows)
-		return sql.ErrNoRows
-	}
-
-	redisErr := mr.redis.DelWithPattern(ctx, DeleteMovieRedisKey)
-	if redisErr != nil {
-		log.Println(redisErr)
-	}
+	mr.invalidateCache(ctx)
 
 	return nil
 }
@@ -134,13 +157,13 @@ func (mr *MoviesRepository) Create(ctx context.Context, data *entity.Movie) (con
 
 	if err = namedStmt.GetContext(ctx, &res, data); err != nil {
 		log.Println("get invoice err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrDuplicatemovie
+		}
 		return res, err
 	}
 
-	redisErr := mr.redis.DelWithPattern(ctx, DeleteMovieRedisKey)
-	if redisErr != nil {
-		log.Println(redisErr)
-	}
+	mr.invalidateCache(ctx)
 
 	return res, nil
 }
@@ -158,10 +181,148 @@ func (mr *MoviesRepository) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	err = mr.redis.DelWithPattern(ctx, DeleteMovieRedisKey)
+	mr.invalidateCache(ctx)
+
+	return nil
+}
+
+// ReplaceMovieGenres replace every genre linked to the movie with genreIDs,
+// it return name of linked genre so caller can detect unknown or deleted genre id
+func (mr *MoviesRepository) ReplaceMovieGenres(ctx context.Context, movieID int64, genreIDs []int64) ([]string, error) {
+	var names []string
+
+	stmt, err := mr.getStatement(ctx, DeleteMovieGenres)
 	if err != nil {
-		log.Println("delete redis err: ", err)
+		log.Println("get statement err: ", err)
+		return nil, err
+	}
+
+	if _, err = stmt.ExecContext(ctx, movieID); err != nil {
+		log.Println("delete movie genres err: ", err)
+		return nil, err
 	}
 
+	if len(genreIDs) > 0 {
+		stmt, err = mr.getStatement(ctx, InsertMovieGenres)
+		if err != nil {
+			log.Println("get statement err: ", err)
+			return nil, err
+		}
+
+		if err = stmt.SelectContext(ctx, &names, movieID, pq.Array(genreIDs)); err != nil {
+			log.Println("insert movie genres err: ", err)
+			return nil, err
+		}
+	}
+
+	mr.invalidateCache(ctx)
+
+	return names, nil
+}
+
+// GetMovieCredits return credit of the movie, director and writer first then actor by billing order
+func (mr *MoviesRepository) GetMovieCredits(ctx context.Context, movieID int64) ([]*entity.MovieCredit, error) {
+	var Credit []*entity.MovieCredit
+	err := mr.redis.WithCache(ctx, fmt.Sprintf(GetMovieCreditsRedisKey, movieID), &Credit, func() (interface{}, error) {
+		var CreditData []*entity.MovieCredit
+		err := mr.masterStmts[GetMovieCredits].SelectContext(ctx, &CreditData, movieID)
+		return CreditData, err
+	})
+
+	if err != nil {
+		log.Println("GetMovieCredits err: ", err)
+		return nil, err
+	}
+
+	return Credit, nil
+}
+
+// ReplaceMovieCredits replace every credit of the movie, it return sql.ErrNoRows
+// when one of the person does not exist or is deleted
+func (mr *MoviesRepository) ReplaceMovieCredits(ctx context.Context, movieID int64, credits []*entity.MovieCredit) error {
+	// cast is part of the movie response so it change the movie version
+	stmt, err := mr.getStatement(ctx, TouchMovie)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return err
+	}
+
+	if _, err = stmt.ExecContext(ctx, movieID); err != nil {
+		log.Println("touch movie err: ", err)
+		return err
+	}
+
+	stmt, err = mr.getStatement(ctx, DeleteMovieCredits)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return err
+	}
+
+	if _, err = stmt.ExecContext(ctx, movieID); err != nil {
+		log.Println("delete movie credits err: ", err)
+		return err
+	}
+
+	if len(credits) > 0 {
+		namedStmt, err := mr.getNamedStatement(ctx, InsertMovieCredit)
+		if err != nil {
+			log.Println("get named statement err: ", err)
+			return err
+		}
+
+		for _, credit := range credits {
+			credit.MovieID = movieID
+
+			res, err := namedStmt.ExecContext(ctx, credit)
+			if err != nil {
+				log.Println("insert movie credit err: ", err)
+				return err
+			}
+
+			rowsAffected, err := res.RowsAffected()
+			if err != nil {
+				log.Println("Get rows affected err: ", err)
+				return err
+			}
+
+			if rowsAffected == 0 {
+				log.Println("person not exist err: ", sql.ErrNoRows)
+				return sql.ErrNoRows
+			}
+		}
+	}
+
+	mr.invalidateCache(ctx)
+
+	return nil
+}
+
+// UpdateImageKeys replace storage key of the movie poster
+func (mr *MoviesRepository) UpdateImageKeys(ctx context.Context, movieID int64, keys entity.ImageKeys) error {
+	stmt, err := mr.getStatement(ctx, UpdateMovieImageKeys)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, movieID, keys)
+	if err != nil {
+		log.Println("update movie image keys err: ", err)
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("ID not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	mr.invalidateCache(ctx)
+
 	return nil
 }
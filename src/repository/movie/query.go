@@ -0,0 +1,190 @@
+package movie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Risuii/movie/src/repository/sqlutil"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/lib/pq"
+)
+
+type sortColumn struct {
+	name string
+	// cast is type of cursor value, cursor value is always passed as string
+	cast string
+}
+
+// sortColumns map sort field from request to column name,
+// only field listed here can be used in ORDER BY clause
+var sortColumns = map[string]sortColumn{
+	contract.SortFieldTitle:     {name: "title", cast: "text"},
+	contract.SortFieldRating:    {name: "rating", cast: "numeric"},
+	contract.SortFieldCreatedAt: {name: "created_at", cast: "timestamptz"},
+	contract.SortFieldUpdatedAt: {name: "updated_at", cast: "timestamptz"},
+}
+
+// listQuery build WHERE and ORDER BY clause for get list query,
+// every value is passed as positional argument and never concatenated to the query
+type listQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+func (q *listQuery) addArg(value interface{}) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *listQuery) where(condition string, values ...interface{}) {
+	placeholders := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = q.addArg(value)
+	}
+
+	q.conditions = append(q.conditions, fmt.Sprintf(condition, placeholders...))
+}
+
+func (q *listQuery) whereClause() string {
+	return "WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+func buildListQuery(params contract.GetListParam) *listQuery {
+	q := &listQuery{
+		conditions: []string{"deleted_at IS NULL"},
+	}
+
+	if params.Keyword != "" {
+		keyword := sqlutil.EscapeLike(params.Keyword)
+		q.where(`(title ILIKE '%%' || %[1]s || '%%' ESCAPE '\' OR description ILIKE '%%' || %[1]s || '%%' ESCAPE '\')`, keyword)
+	}
+
+	if params.RatingMin != nil {
+		q.where("rating >= %s::numeric", *params.RatingMin)
+	}
+
+	if params.RatingMax != nil {
+		q.where("rating <= %s::numeric", *params.RatingMax)
+	}
+
+	if params.CreatedFrom != nil {
+		q.where("created_at >= %s", *params.CreatedFrom)
+	}
+
+	if params.CreatedTo != nil {
+		q.where("created_at <= %s", *params.CreatedTo)
+	}
+
+	if params.UpdatedFrom != nil {
+		q.where("updated_at >= %s", *params.UpdatedFrom)
+	}
+
+	if params.UpdatedTo != nil {
+		q.where("updated_at <= %s", *params.UpdatedTo)
+	}
+
+	if len(params.GenreIDs) > 0 {
+		q.where("id IN (SELECT movie_id FROM movie_genres WHERE genre_id = ANY(%s))", pq.Array(params.GenreIDs))
+	}
+
+	if params.HasImage != nil {
+		if *params.HasImage {
+			q.where("COALESCE(image, '') <> ''")
+		} else {
+			q.where("COALESCE(image, '') = ''")
+		}
+	}
+
+	return q
+}
+
+// orderByClause return ORDER BY clause from whitelisted sort field,
+// id is always appended as tie breaker so the order is stable between pages.
+// reverse flip every direction, it is used to walk backward with cursor
+func orderByClause(sort []contract.SortField, reverse bool) string {
+	var orders []string
+	for _, s := range sort {
+		column, ok := sortColumns[s.Field]
+		if !ok {
+			continue
+		}
+
+		orders = append(orders, fmt.Sprintf("%s %s", column.name, sortDirection(s.Desc != reverse)))
+	}
+
+	orders = append(orders, "id "+sortDirection(reverse))
+
+	return "ORDER BY " + strings.Join(orders, ", ")
+}
+
+func sortDirection(desc bool) string {
+	if desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// whereAfterCursor add keyset condition so only row after the cursor is returned,
+// for sort (a, b, id) it is (a > $1) OR (a = $1 AND b > $2) OR (a = $1 AND b = $2 AND id > $3)
+// with comparison flipped on descending field and on backward cursor
+func (q *listQuery) whereAfterCursor(sort []contract.SortField, cursor *contract.Cursor) {
+	var equals, alternatives []string
+
+	for i, s := range sort {
+		column, ok := sortColumns[s.Field]
+		if !ok {
+			continue
+		}
+
+		value := fmt.Sprintf("%s::%s", q.addArg(cursor.Values[i]), column.cast)
+		alternatives = append(alternatives, keysetCondition(equals, column.name, keysetOperator(s.Desc != cursor.Backward), value))
+		equals = append(equals, fmt.Sprintf("%s = %s", column.name, value))
+	}
+
+	alternatives = append(alternatives, keysetCondition(equals, "id", keysetOperator(cursor.Backward), q.addArg(cursor.ID)))
+
+	q.conditions = append(q.conditions, "("+strings.Join(alternatives, " OR ")+")")
+}
+
+func keysetCondition(equals []string, column, operator, value string) string {
+	conditions := append(append([]string{}, equals...), fmt.Sprintf("%s %s %s", column, operator, value))
+	return "(" + strings.Join(conditions, " AND ") + ")"
+}
+
+func keysetOperator(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
+
+func (q *listQuery) selectQuery(params contract.GetListParam) (string, []interface{}) {
+	query := fmt.Sprintf("SELECT %s FROM movies %s %s LIMIT %s OFFSET %s",
+		AllFields, q.whereClause(), orderByClause(params.Sort, false), q.addArg(params.Limit), q.addArg(params.Offset))
+
+	return query, q.args
+}
+
+// cursorSelectQuery return one row more than limit so caller know whether there is another page,
+// row is ordered backward when cursor is a prev cursor
+func (q *listQuery) cursorSelectQuery(params contract.GetListParam) (string, []interface{}) {
+	reverse := false
+	if params.Cursor != nil {
+		q.whereAfterCursor(params.Sort, params.Cursor)
+		reverse = params.Cursor.Backward
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM movies %s %s LIMIT %s",
+		AllFields, q.whereClause(), orderByClause(params.Sort, reverse), q.addArg(params.Limit+1))
+
+	return query, q.args
+}
+
+func (q *listQuery) countQuery() (string, []interface{}) {
+	return fmt.Sprintf("SELECT COUNT(*) FROM movies %s", q.whereClause()), q.args
+}
+
+// exportQuery select every matching row without LIMIT, row is read one by one by the caller
+func (q *listQuery) exportQuery(params contract.GetListParam) (string, []interface{}) {
+	return fmt.Sprintf("SELECT %s FROM movies %s %s", AllFields, q.whereClause(), orderByClause(params.Sort, false)), q.args
+}
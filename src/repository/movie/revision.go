@@ -0,0 +1,100 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// GetSnapshot return current state of the movie whether it is soft deleted or not
+func (mr *MoviesRepository) GetSnapshot(ctx context.Context, id int64) (entity.MovieSnapshot, error) {
+	var snapshot entity.MovieSnapshot
+
+	stmt, err := mr.getStatement(ctx, GetMovieSnapshot)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return snapshot, err
+	}
+
+	if err = stmt.GetContext(ctx, &snapshot, id); err != nil {
+		log.Println("get movie snapshot err: ", err)
+		return snapshot, err
+	}
+
+	return snapshot, nil
+}
+
+// LockSnapshot is GetSnapshot that lock the movie row until the transaction end,
+// it keep revision number sequential when the movie is mutated concurrently
+func (mr *MoviesRepository) LockSnapshot(ctx context.Context, id int64) (entity.MovieSnapshot, error) {
+	var snapshot entity.MovieSnapshot
+
+	stmt, err := mr.getStatement(ctx, LockMovieSnapshot)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return snapshot, err
+	}
+
+	if err = stmt.GetContext(ctx, &snapshot, id); err != nil {
+		log.Println("lock movie snapshot err: ", err)
+		return snapshot, err
+	}
+
+	return snapshot, nil
+}
+
+// CreateRevision insert the next revision of the movie and fill its id, revision and created_at
+func (mr *MoviesRepository) CreateRevision(ctx context.Context, data *entity.MovieRevision) error {
+	namedStmt, err := mr.getNamedStatement(ctx, InsertMovieRevision)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	row := namedStmt.QueryRowxContext(ctx, data)
+	if err = row.Scan(&data.Id, &data.Revision, &data.CreatedAt); err != nil {
+		log.Println("insert movie revision err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetRevisions return revision of the movie, latest first
+func (mr *MoviesRepository) GetRevisions(ctx context.Context, movieID int64, params contract.GetListParam) ([]*entity.MovieRevision, error) {
+	var Revision []*entity.MovieRevision
+
+	err := mr.masterStmts[GetRevisions].SelectContext(ctx, &Revision, movieID, params.Limit, params.Offset)
+	if err != nil {
+		log.Println("get movie revisions err: ", err)
+		return nil, err
+	}
+
+	return Revision, nil
+}
+
+func (mr *MoviesRepository) GetRevisionCount(ctx context.Context, movieID int64) (int64, error) {
+	var count int64
+
+	err := mr.masterStmts[GetRevisionCount].GetContext(ctx, &count, movieID)
+	if err != nil {
+		log.Println("get movie revision count err: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (mr *MoviesRepository) GetRevision(ctx context.Context, movieID int64, revision int) (entity.MovieRevision, error) {
+	var Revision entity.MovieRevision
+
+	err := mr.masterStmts[GetRevision].GetContext(ctx, &Revision, movieID, revision)
+	if err != nil {
+		log.Println("get movie revision err: ", err)
+		return Revision, err
+	}
+
+	return Revision, nil
+}
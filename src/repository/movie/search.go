@@ -0,0 +1,38 @@
+package movie
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// searchHighlightSelector is StartSel and StopSel option of ts_headline
+var searchHighlightSelector = fmt.Sprintf("StartSel=%s, StopSel=%s", contract.SearchHighlightStart, contract.SearchHighlightStop)
+
+// Search return active movie matching the full text query, best rank first, it is not cached
+func (mr *MoviesRepository) Search(ctx context.Context, params contract.SearchParam) ([]*entity.MovieSearchResult, error) {
+	var Result []*entity.MovieSearchResult
+
+	err := mr.masterStmts[SearchMovies].SelectContext(ctx, &Result, params.Config, params.Query, params.Limit, params.Offset, searchHighlightSelector)
+	if err != nil {
+		log.Println("search movie err: ", err)
+		return nil, err
+	}
+
+	return Result, nil
+}
+
+func (mr *MoviesRepository) GetSearchCount(ctx context.Context, params contract.SearchParam) (int64, error) {
+	var count int64
+
+	err := mr.masterStmts[GetSearchCount].GetContext(ctx, &count, params.Config, params.Query)
+	if err != nil {
+		log.Println("get movie search count err: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,75 @@
+package movie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/sqlutil"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// SuggestCacheMaxLength is the longest cached query, short prefix is typed
+	// by most user so it is hot while longer query is mostly unique
+	SuggestCacheMaxLength = 5
+	SuggestCacheTTL       = 10 * time.Minute
+)
+
+// Suggest return title matching the typeahead query, best score first, suggestion of
+// short prefix is cached until SuggestCacheTTL or until any movie is written
+func (mr *MoviesRepository) Suggest(ctx context.Context, params contract.SuggestParam) ([]*entity.MovieSuggestion, error) {
+	if utf8.RuneCountInString(params.Query) > SuggestCacheMaxLength {
+		return mr.selectSuggestions(ctx, params)
+	}
+
+	key := fmt.Sprintf(SuggestMoviesRedisKey, params.Limit, params.Query)
+
+	cached, err := mr.redis.Get(ctx, key)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Println("get movie suggestion cache err: ", err)
+	}
+
+	if err == nil {
+		var Suggestion []*entity.MovieSuggestion
+		if err = json.Unmarshal([]byte(cached), &Suggestion); err == nil {
+			return Suggestion, nil
+		}
+		log.Println("unmarshal movie suggestion err: ", err)
+	}
+
+	Suggestion, err := mr.selectSuggestions(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(Suggestion)
+	if err != nil {
+		log.Println("marshal movie suggestion err: ", err)
+		return Suggestion, nil
+	}
+
+	if err = mr.redis.Set(ctx, key, string(value), SuggestCacheTTL); err != nil {
+		log.Println("set movie suggestion cache err: ", err)
+	}
+
+	return Suggestion, nil
+}
+
+func (mr *MoviesRepository) selectSuggestions(ctx context.Context, params contract.SuggestParam) ([]*entity.MovieSuggestion, error) {
+	var Suggestion []*entity.MovieSuggestion
+
+	err := mr.masterStmts[SuggestMovies].SelectContext(ctx, &Suggestion, sqlutil.EscapeLike(params.Query), params.Query, params.Limit)
+	if err != nil {
+		log.Println("suggest movie err: ", err)
+		return nil, err
+	}
+
+	return Suggestion, nil
+}
@@ -0,0 +1,136 @@
+package person
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	AllFields = `id, name, biography, image, created_at, updated_at`
+
+	GetByID = iota + 100
+	GetList
+	GetCountList
+	GetPersonCredits
+	Delete
+
+	InsertPerson = iota + 200
+	UpdatePerson
+
+	// Redis Key
+
+	GetListPeopleRedisKey   = "movie:people:getlist:%s"
+	GetPeopleCountRedisKey  = "movie:people:getcount:%s"
+	GetDetailPeopleRedisKey = "movie:people:getdetail:%d"
+	DeletePeopleRedisKey    = "movie:people:*"
+
+	// GetPersonCreditsRedisKey live under movie key so every movie mutation invalidate it
+	GetPersonCreditsRedisKey = "movie:movies:personcredits:%d"
+
+	// DeleteMovieRedisKey is invalidated too because movie response embed person name
+	DeleteMovieRedisKey = "movie:movies:*"
+
+	// TouchPersonMovies increase version of the movies crediting the person of the CTE named person,
+	// movie response carry the cast name so its ETag change with the person
+	TouchPersonMovies = `UPDATE movies SET version = version + 1, updated_at = now() ` +
+		`WHERE id IN (SELECT c.movie_id FROM movie_credits c JOIN person p ON p.id = c.person_id)`
+)
+
+// nameFilter matches $1 against person name, an empty keyword matches every row
+const nameFilter = `($1 = '' OR name ILIKE '%' || $1 || '%' ESCAPE '\')`
+
+var (
+	masterQueries = []string{
+		GetByID:      fmt.Sprintf("SELECT %s FROM people WHERE id = $1 AND deleted_at IS NULL", AllFields),
+		GetList:      fmt.Sprintf("SELECT %s FROM people WHERE deleted_at IS NULL AND %s ORDER BY name, id LIMIT $2 OFFSET $3", AllFields, nameFilter),
+		GetCountList: fmt.Sprintf("SELECT COUNT(*) FROM people WHERE deleted_at IS NULL AND %s", nameFilter),
+		GetPersonCredits: `SELECT c.id, c.movie_id, c.person_id, c.role, c.character_name, c.billing_order, p.name AS person_name, m.title AS movie_title
+			FROM movie_credits c
+			JOIN people p ON p.id = c.person_id AND p.deleted_at IS NULL
+			JOIN movies m ON m.id = c.movie_id AND m.deleted_at IS NULL
+			WHERE c.person_id = $1
+			ORDER BY m.created_at DESC, m.id, c.role`,
+		Delete: `WITH person AS (UPDATE people SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL RETURNING id) ` +
+			TouchPersonMovies,
+	}
+
+	masterNamedQueries = []string{
+		InsertPerson: fmt.Sprintf(`INSERT INTO people (name, biography, image, created_at) VALUES (:name, :biography, :image, now()) RETURNING %s`, AllFields),
+		UpdatePerson: `WITH person AS (UPDATE people SET (name, biography, image, updated_at) = (:name, :biography, :image, now()) WHERE id = :id AND deleted_at IS NULL RETURNING id), ` +
+			`touched AS (` + TouchPersonMovies + `) SELECT id FROM person`,
+	}
+)
+
+type PeopleRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+	redis             frsRedis.Redis
+}
+
+func InitPeopleRepository(ctx context.Context, db *sqlx.DB, redis frsRedis.Redis) (*PeopleRepository, error) {
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &PeopleRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+		redis:             redis,
+	}, nil
+}
+
+func (r *PeopleRepository) getStatement(ctx context.Context, queryId int) (*sqlx.Stmt, error) {
+	var err error
+	var statement *sqlx.Stmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			statement, err = atomicSession.Tx().PreparexContext(ctx, masterQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		statement = r.masterStmts[queryId]
+	}
+	return statement, err
+}
+
+func (r *PeopleRepository) getNamedStatement(ctx context.Context, queryId int) (*sqlx.NamedStmt, error) {
+	var err error
+	var namedStmt *sqlx.NamedStmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			namedStmt, err = atomicSession.Tx().PrepareNamedContext(ctx, masterNamedQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		namedStmt = r.masterNamedStmpts[queryId]
+	}
+	return namedStmt, err
+}
+
+func (r *PeopleRepository) invalidateCache(ctx context.Context) {
+	for _, pattern := range []string{DeletePeopleRedisKey, DeleteMovieRedisKey} {
+		if err := r.redis.DelWithPattern(ctx, pattern); err != nil {
+			log.Println("delete redis err: ", err)
+		}
+	}
+}
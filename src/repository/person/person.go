@@ -0,0 +1,143 @@
+package person
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/sqlutil"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+func (pr *PeopleRepository) GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Person, error) {
+	var Person []*entity.Person
+
+	param, err := json.Marshal(params)
+	if err != nil {
+		log.Println("marshal err: ", err)
+		return nil, err
+	}
+
+	err = pr.redis.WithCache(ctx, fmt.Sprintf(GetListPeopleRedisKey, param), &Person, func() (interface{}, error) {
+		var PersonData []*entity.Person
+		err := pr.masterStmts[GetList].SelectContext(ctx, &PersonData, sqlutil.EscapeLike(params.Keyword), params.Limit, params.Offset)
+		return PersonData, err
+	})
+
+	if err != nil {
+		log.Println("GetPeopleList err: ", err)
+		return nil, err
+	}
+
+	return Person, nil
+}
+
+func (pr *PeopleRepository) GetPeopleCount(ctx context.Context, param contract.GetListParam) (int64, error) {
+	var count int64
+
+	err := pr.redis.WithCache(ctx, fmt.Sprintf(GetPeopleCountRedisKey, param.Keyword), &count, func() (interface{}, error) {
+		var countData int64
+		err := pr.masterStmts[GetCountList].GetContext(ctx, &countData, sqlutil.EscapeLike(param.Keyword))
+		return countData, err
+	})
+
+	if err != nil {
+		log.Println("GetPeopleCount err: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (pr *PeopleRepository) Get(ctx context.Context, id int) (entity.Person, error) {
+	var Person entity.Person
+	err := pr.redis.WithCache(ctx, fmt.Sprintf(GetDetailPeopleRedisKey, id), &Person, func() (interface{}, error) {
+		var PersonData entity.Person
+		err := pr.masterStmts[GetByID].GetContext(ctx, &PersonData, id)
+		return PersonData, err
+	})
+
+	if err != nil {
+		log.Println(err)
+		return Person, err
+	}
+
+	return Person, nil
+}
+
+// GetCredits return every credit of the person on movie that is not deleted
+func (pr *PeopleRepository) GetCredits(ctx context.Context, id int64) ([]*entity.MovieCredit, error) {
+	var Credit []*entity.MovieCredit
+	err := pr.redis.WithCache(ctx, fmt.Sprintf(GetPersonCreditsRedisKey, id), &Credit, func() (interface{}, error) {
+		var CreditData []*entity.MovieCredit
+		err := pr.masterStmts[GetPersonCredits].SelectContext(ctx, &CreditData, id)
+		return CreditData, err
+	})
+
+	if err != nil {
+		log.Println("GetPersonCredits err: ", err)
+		return nil, err
+	}
+
+	return Credit, nil
+}
+
+func (pr *PeopleRepository) Create(ctx context.Context, data *entity.Person) (entity.Person, error) {
+	var res entity.Person
+
+	namedStmt, err := pr.getNamedStatement(ctx, InsertPerson)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return res, err
+	}
+
+	if err = namedStmt.GetContext(ctx, &res, data); err != nil {
+		log.Println("insert person err: ", err)
+		return res, err
+	}
+
+	pr.invalidateCache(ctx)
+
+	return res, nil
+}
+
+// Update overwrite the person and increase version of its movies in one statement,
+// sql.ErrNoRows is returned when the person does not exist
+func (pr *PeopleRepository) Update(ctx context.Context, data *entity.Person) error {
+	namedStmt, err := pr.getNamedStatement(ctx, UpdatePerson)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	var id int64
+	if err = namedStmt.GetContext(ctx, &id, data); err != nil {
+		log.Println("update person err: ", err)
+		return err
+	}
+
+	pr.invalidateCache(ctx)
+
+	return nil
+}
+
+// Delete soft delete the person and increase version of its movies in one statement
+func (pr *PeopleRepository) Delete(ctx context.Context, id int64) error {
+	stmt, err := pr.getStatement(ctx, Delete)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	pr.invalidateCache(ctx)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package pgerr
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	codeForeignKeyViolation = "23503"
+	codeUniqueViolation     = "23505"
+	codeExclusionViolation  = "23P01"
+)
+
+// IsUniqueViolation report whether err is caused by unique constraint or unique index
+func IsUniqueViolation(err error) bool {
+	return hasCode(err, codeUniqueViolation)
+}
+
+// IsForeignKeyViolation report whether err is caused by foreign key constraint
+func IsForeignKeyViolation(err error) bool {
+	return hasCode(err, codeForeignKeyViolation)
+}
+
+// IsExclusionViolation report whether err is caused by exclusion constraint
+func IsExclusionViolation(err error) bool {
+	return hasCode(err, codeExclusionViolation)
+}
+
+func hasCode(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == code
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package review
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+type ctxKeyCacheInvalidation struct{}
+
+// cacheInvalidation remember that a write happened while invalidation is deferred
+type cacheInvalidation struct {
+	mu      sync.Mutex
+	pending bool
+}
+
+// DeferCacheInvalidation return context under which write only mark the movie cache as stale,
+// the returned func delete the cache once when any write happened. Caller start the transaction
+// from the returned context and call the func after it end, so a read before the commit can not
+// cache the old audience rating again
+func (r *ReviewsRepository) DeferCacheInvalidation(ctx context.Context) (context.Context, func()) {
+	deferred := &cacheInvalidation{}
+
+	return context.WithValue(ctx, ctxKeyCacheInvalidation{}, deferred), func() {
+		deferred.mu.Lock()
+		pending := deferred.pending
+		deferred.mu.Unlock()
+
+		if pending {
+			r.deleteCache(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+// invalidateCache delete every cached movie key because movie response embed audience rating
+func (r *ReviewsRepository) invalidateCache(ctx context.Context) {
+	if deferred, ok := ctx.Value(ctxKeyCacheInvalidation{}).(*cacheInvalidation); ok {
+		deferred.mu.Lock()
+		deferred.pending = true
+		deferred.mu.Unlock()
+		return
+	}
+
+	r.deleteCache(ctx)
+}
+
+func (r *ReviewsRepository) deleteCache(ctx context.Context) {
+	if err := r.redis.DelWithPattern(ctx, DeleteMovieRedisKey); err != nil {
+		log.Println("delete redis err: ", err)
+	}
+}
@@ -0,0 +1,114 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	AllFields = `id, movie_id, user_id, score, content, created_at, updated_at`
+
+	GetByID = iota + 100
+	GetList
+	GetCountList
+	Delete
+	LockMovie
+	RefreshMovieRating
+
+	InsertReview = iota + 200
+	UpdateReview
+
+	// Redis Key
+
+	// review key live under movie key so every movie mutation invalidate it
+	GetListReviewsRedisKey  = "movie:movies:getreviews:%d:%d:%d"
+	GetReviewsCountRedisKey = "movie:movies:getreviewscount:%d"
+	DeleteMovieRedisKey     = "movie:movies:*"
+)
+
+var (
+	masterQueries = []string{
+		GetByID:      fmt.Sprintf("SELECT %s FROM reviews WHERE id = $1 AND movie_id = $2 AND deleted_at IS NULL", AllFields),
+		GetList:      fmt.Sprintf("SELECT %s FROM reviews WHERE movie_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3", AllFields),
+		GetCountList: `SELECT COUNT(*) FROM reviews WHERE movie_id = $1 AND deleted_at IS NULL`,
+		Delete:       `UPDATE reviews SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
+		// LockMovie serialize review mutation of the same movie so the aggregate
+		// computed afterward see every committed review
+		LockMovie: `SELECT id FROM movies WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`,
+		// version is increased because audience rating is part of the movie ETag
+		RefreshMovieRating: `UPDATE movies SET (average_rating, rating_count, version) = (
+			SELECT COALESCE(ROUND(AVG(score), 2), 0), COUNT(*), movies.version + 1 FROM reviews WHERE movie_id = $1 AND deleted_at IS NULL
+		) WHERE id = $1 RETURNING average_rating, rating_count`,
+	}
+
+	masterNamedQueries = []string{
+		InsertReview: fmt.Sprintf(`INSERT INTO reviews (movie_id, user_id, score, content, created_at) VALUES (:movie_id, :user_id, :score, :content, now()) RETURNING %s`, AllFields),
+		UpdateReview: `UPDATE reviews SET (score, content, updated_at) = (:score, :content, now()) WHERE id = :id AND deleted_at IS NULL`,
+	}
+)
+
+type ReviewsRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+	redis             frsRedis.Redis
+}
+
+func InitReviewsRepository(ctx context.Context, db *sqlx.DB, redis frsRedis.Redis) (*ReviewsRepository, error) {
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &ReviewsRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+		redis:             redis,
+	}, nil
+}
+
+func (r *ReviewsRepository) getStatement(ctx context.Context, queryId int) (*sqlx.Stmt, error) {
+	var err error
+	var statement *sqlx.Stmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			statement, err = atomicSession.Tx().PreparexContext(ctx, masterQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		statement = r.masterStmts[queryId]
+	}
+	return statement, err
+}
+
+func (r *ReviewsRepository) getNamedStatement(ctx context.Context, queryId int) (*sqlx.NamedStmt, error) {
+	var err error
+	var namedStmt *sqlx.NamedStmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			namedStmt, err = atomicSession.Tx().PrepareNamedContext(ctx, masterNamedQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		namedStmt = r.masterNamedStmpts[queryId]
+	}
+	return namedStmt, err
+}
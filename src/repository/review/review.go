@@ -0,0 +1,173 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func (rr *ReviewsRepository) GetList(ctx context.Context, movieID int64, params contract.GetListParam) ([]*entity.Review, error) {
+	var Review []*entity.Review
+
+	err := rr.redis.WithCache(ctx, fmt.Sprintf(GetListReviewsRedisKey, movieID, params.Limit, params.Offset), &Review, func() (interface{}, error) {
+		var ReviewData []*entity.Review
+		err := rr.masterStmts[GetList].SelectContext(ctx, &ReviewData, movieID, params.Limit, params.Offset)
+		return ReviewData, err
+	})
+
+	if err != nil {
+		log.Println("GetReviewList err: ", err)
+		return nil, err
+	}
+
+	return Review, nil
+}
+
+func (rr *ReviewsRepository) GetReviewCount(ctx context.Context, movieID int64) (int64, error) {
+	var count int64
+
+	err := rr.redis.WithCache(ctx, fmt.Sprintf(GetReviewsCountRedisKey, movieID), &count, func() (interface{}, error) {
+		var countData int64
+		err := rr.masterStmts[GetCountList].GetContext(ctx, &countData, movieID)
+		return countData, err
+	})
+
+	if err != nil {
+		log.Println("GetReviewCount err: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Get is not cached because it is used to check review owner before mutation
+func (rr *ReviewsRepository) Get(ctx context.Context, movieID, id int64) (entity.Review, error) {
+	var Review entity.Review
+
+	stmt, err := rr.getStatement(ctx, GetByID)
+	if err != nil {
+		log.Println("getStatement err: ", err)
+		return Review, err
+	}
+
+	if err = stmt.GetContext(ctx, &Review, id, movieID); err != nil {
+		log.Println("get review err: ", err)
+		return Review, err
+	}
+
+	return Review, nil
+}
+
+// LockMovie lock the movie row until the transaction end,
+// it return sql.ErrNoRows when the movie does not exist
+func (rr *ReviewsRepository) LockMovie(ctx context.Context, movieID int64) error {
+	stmt, err := rr.getStatement(ctx, LockMovie)
+	if err != nil {
+		log.Println("getStatement err: ", err)
+		return err
+	}
+
+	var id int64
+	if err = stmt.GetContext(ctx, &id, movieID); err != nil {
+		log.Println("lock movie err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// RefreshMovieRating recompute audience rating of the movie from its reviews
+func (rr *ReviewsRepository) RefreshMovieRating(ctx context.Context, movieID int64) (entity.MovieRating, error) {
+	var rating entity.MovieRating
+
+	stmt, err := rr.getStatement(ctx, RefreshMovieRating)
+	if err != nil {
+		log.Println("getStatement err: ", err)
+		return rating, err
+	}
+
+	if err = stmt.GetContext(ctx, &rating, movieID); err != nil {
+		log.Println("refresh movie rating err: ", err)
+		return rating, err
+	}
+
+	rr.invalidateCache(ctx)
+
+	return rating, nil
+}
+
+func (rr *ReviewsRepository) Create(ctx context.Context, data *entity.Review) (entity.Review, error) {
+	var res entity.Review
+
+	namedStmt, err := rr.getNamedStatement(ctx, InsertReview)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return res, err
+	}
+
+	if err = namedStmt.GetContext(ctx, &res, data); err != nil {
+		log.Println("insert review err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrDuplicateReview
+		}
+		return res, err
+	}
+
+	rr.invalidateCache(ctx)
+
+	return res, nil
+}
+
+func (rr *ReviewsRepository) Update(ctx context.Context, data *entity.Review) error {
+	namedStmt, err := rr.getNamedStatement(ctx, UpdateReview)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	res, err := namedStmt.ExecContext(ctx, data)
+	if err != nil {
+		log.Println("exec err: ", err)
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("ID not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	rr.invalidateCache(ctx)
+
+	return nil
+}
+
+func (rr *ReviewsRepository) Delete(ctx context.Context, id int64) error {
+	stmt, err := rr.getStatement(ctx, Delete)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	rr.invalidateCache(ctx)
+
+	return nil
+}
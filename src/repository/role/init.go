@@ -0,0 +1,119 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	frsRedis "github.com/Risuii/frs-lib/redis"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	AllFields = `r.name, r.description, r.built_in, r.created_at, r.updated_at,
+		ARRAY(SELECT p.permission FROM role_permissions p WHERE p.role = r.name ORDER BY p.permission) AS permissions`
+
+	GetByName = iota + 100
+	GetList
+	GetPermissions
+	Delete
+	DeletePermissions
+	InsertPermissions
+
+	InsertRole = iota + 200
+	UpdateRole
+
+	// Redis Key
+
+	GetListRolesRedisKey   = "movie:roles:getlist"
+	GetDetailRolesRedisKey = "movie:roles:getdetail:%s"
+	GetPermissionsRedisKey = "movie:roles:permissions:%s"
+	DeleteRoleRedisKey     = "movie:roles:*"
+)
+
+var (
+	masterQueries = []string{
+		GetByName:         fmt.Sprintf("SELECT %s FROM roles r WHERE r.name = $1", AllFields),
+		GetList:           fmt.Sprintf("SELECT %s FROM roles r ORDER BY r.name", AllFields),
+		GetPermissions:    `SELECT DISTINCT permission FROM role_permissions WHERE role = ANY($1) ORDER BY permission`,
+		Delete:            `DELETE FROM roles WHERE name = $1 AND NOT built_in`,
+		DeletePermissions: `DELETE FROM role_permissions WHERE role = $1`,
+		InsertPermissions: `INSERT INTO role_permissions (role, permission) SELECT $1, unnest(CAST($2 AS text[]))`,
+	}
+
+	masterNamedQueries = []string{
+		InsertRole: `INSERT INTO roles (name, description, created_at) VALUES (:name, :description, now())
+			RETURNING name, description, built_in, created_at, updated_at`,
+		UpdateRole: `UPDATE roles SET (description, updated_at) = (:description, now()) WHERE name = :name`,
+	}
+)
+
+type RolesRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+	redis             frsRedis.Redis
+}
+
+func InitRolesRepository(ctx context.Context, db *sqlx.DB, redis frsRedis.Redis) (*RolesRepository, error) {
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &RolesRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+		redis:             redis,
+	}, nil
+}
+
+func (r *RolesRepository) getStatement(ctx context.Context, queryId int) (*sqlx.Stmt, error) {
+	var err error
+	var statement *sqlx.Stmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			statement, err = atomicSession.Tx().PreparexContext(ctx, masterQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		statement = r.masterStmts[queryId]
+	}
+	return statement, err
+}
+
+func (r *RolesRepository) getNamedStatement(ctx context.Context, queryId int) (*sqlx.NamedStmt, error) {
+	var err error
+	var namedStmt *sqlx.NamedStmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			namedStmt, err = atomicSession.Tx().PrepareNamedContext(ctx, masterNamedQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		namedStmt = r.masterNamedStmpts[queryId]
+	}
+	return namedStmt, err
+}
+
+// invalidateCache drop every cached role and permission, the Authorize
+// middleware pick up the change on the next request
+func (r *RolesRepository) invalidateCache(ctx context.Context) {
+	if err := r.redis.DelWithPattern(ctx, DeleteRoleRedisKey); err != nil {
+		log.Println("delete redis err: ", err)
+	}
+}
@@ -0,0 +1,183 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
+	"github.com/lib/pq"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func (rr *RolesRepository) GetList(ctx context.Context) ([]*entity.Role, error) {
+	var roles []*entity.Role
+
+	err := rr.redis.WithCache(ctx, GetListRolesRedisKey, &roles, func() (interface{}, error) {
+		var rolesData []*entity.Role
+		err := rr.masterStmts[GetList].SelectContext(ctx, &rolesData)
+		return rolesData, err
+	})
+
+	if err != nil {
+		log.Println("GetRoleList err: ", err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (rr *RolesRepository) Get(ctx context.Context, name string) (entity.Role, error) {
+	var role entity.Role
+	err := rr.redis.WithCache(ctx, fmt.Sprintf(GetDetailRolesRedisKey, name), &role, func() (interface{}, error) {
+		var roleData entity.Role
+		err := rr.masterStmts[GetByName].GetContext(ctx, &roleData, name)
+		return roleData, err
+	})
+
+	if err != nil {
+		log.Println(err)
+		return role, err
+	}
+
+	return role, nil
+}
+
+// GetPermissions return permission granted to any of the roles, unknown role grant nothing.
+// It is called on every authorized request so the result is cached by the sorted roles
+func (rr *RolesRepository) GetPermissions(ctx context.Context, roles []string) ([]string, error) {
+	sorted := append([]string(nil), roles...)
+	sort.Strings(sorted)
+
+	var permissions []string
+	err := rr.redis.WithCache(ctx, fmt.Sprintf(GetPermissionsRedisKey, strings.Join(sorted, ",")), &permissions, func() (interface{}, error) {
+		permissionsData := []string{}
+		err := rr.masterStmts[GetPermissions].SelectContext(ctx, &permissionsData, pq.Array(sorted))
+		return permissionsData, err
+	})
+
+	if err != nil {
+		log.Println("GetPermissions err: ", err)
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (rr *RolesRepository) Create(ctx context.Context, data *entity.Role) (entity.Role, error) {
+	var res entity.Role
+
+	namedStmt, err := rr.getNamedStatement(ctx, InsertRole)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return res, err
+	}
+
+	if err = namedStmt.GetContext(ctx, &res, data); err != nil {
+		log.Println("insert role err: ", err)
+		if pgerr.IsUniqueViolation(err) {
+			err = appErr.ErrDuplicateRole
+		}
+		return res, err
+	}
+
+	rr.invalidateCache(ctx)
+
+	return res, nil
+}
+
+func (rr *RolesRepository) Update(ctx context.Context, data *entity.Role) error {
+	namedStmt, err := rr.getNamedStatement(ctx, UpdateRole)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	res, err := namedStmt.ExecContext(ctx, data)
+	if err != nil {
+		log.Println("exec err: ", err)
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("role not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	rr.invalidateCache(ctx)
+
+	return nil
+}
+
+// ReplacePermissions set the permissions of the role, it must run in a transaction
+// so the role is never left without its permission
+func (rr *RolesRepository) ReplacePermissions(ctx context.Context, name string, permissions []string) error {
+	stmt, err := rr.getStatement(ctx, DeletePermissions)
+	if err != nil {
+		log.Println("get statement err: ", err)
+		return err
+	}
+
+	if _, err = stmt.ExecContext(ctx, name); err != nil {
+		log.Println("delete role permissions err: ", err)
+		return err
+	}
+
+	if len(permissions) > 0 {
+		stmt, err = rr.getStatement(ctx, InsertPermissions)
+		if err != nil {
+			log.Println("get statement err: ", err)
+			return err
+		}
+
+		if _, err = stmt.ExecContext(ctx, name, pq.Array(permissions)); err != nil {
+			log.Println("insert role permissions err: ", err)
+			return err
+		}
+	}
+
+	rr.invalidateCache(ctx)
+
+	return nil
+}
+
+// Delete remove a role that is not built in, its permission is removed by cascade
+func (rr *RolesRepository) Delete(ctx context.Context, name string) error {
+	stmt, err := rr.getStatement(ctx, Delete)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, name)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("role not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	rr.invalidateCache(ctx)
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package showtime
+
+import (
+	"context"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	sqlxUtils "github.com/Risuii/frs-lib/sqlx"
+)
+
+const (
+	// SelectShowtime select showtime joined with its movie, screen and cinema,
+	// showtime of deleted movie, screen or cinema is hidden
+	SelectShowtime = `SELECT s.id, s.movie_id, s.screen_id, s.start_time, s.end_time, s.price, s.created_at, s.updated_at,
+		m.title AS movie_title, sc.name AS screen_name, c.id AS cinema_id, c.name AS cinema_name, c.city
+		FROM showtimes s
+		JOIN movies m ON m.id = s.movie_id AND m.deleted_at IS NULL
+		JOIN screens sc ON sc.id = s.screen_id AND sc.deleted_at IS NULL
+		JOIN cinemas c ON c.id = sc.cinema_id AND c.deleted_at IS NULL
+		WHERE s.deleted_at IS NULL`
+
+	GetByID = iota + 100
+	GetByMovieID
+	GetByCinemaID
+	Delete
+
+	InsertShowtime = iota + 200
+	UpdateShowtime
+)
+
+var (
+	masterQueries = []string{
+		GetByID: SelectShowtime + ` AND s.id = $1`,
+		GetByMovieID: SelectShowtime + ` AND s.movie_id = $1 AND s.start_time >= $2 AND s.start_time < $3
+			AND ($4 = '' OR lower(c.city) = lower($4))
+			ORDER BY c.city, c.name, s.start_time, s.id`,
+		GetByCinemaID: SelectShowtime + ` AND c.id = $1 AND s.start_time >= $2 AND s.start_time < $3
+			ORDER BY sc.name, s.start_time, s.id`,
+		Delete: `UPDATE showtimes SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
+	}
+
+	masterNamedQueries = []string{
+		InsertShowtime: `INSERT INTO showtimes (movie_id, screen_id, start_time, end_time, price, created_at)
+			VALUES (:movie_id, :screen_id, :start_time, :end_time, :price, now()) RETURNING id`,
+		UpdateShowtime: `UPDATE showtimes SET (movie_id, screen_id, start_time, end_time, price, updated_at)
+			= (:movie_id, :screen_id, :start_time, :end_time, :price, now()) WHERE id = :id AND deleted_at IS NULL`,
+	}
+)
+
+type ShowtimesRepository struct {
+	db                *sqlx.DB
+	masterStmts       []*sqlx.Stmt
+	masterNamedStmpts []*sqlx.NamedStmt
+}
+
+// InitShowtimesRepository does not take redis, showtime is not cached so
+// schedule change is visible at once to the ticketing product
+func InitShowtimesRepository(ctx context.Context, db *sqlx.DB) (*ShowtimesRepository, error) {
+	stmpts, err := sqlxUtils.PrepareQueries(db, masterQueries)
+	if err != nil {
+		log.Println("PrepareQueries err:", err)
+		return nil, err
+	}
+
+	namedStmpts, err := sqlxUtils.PrepareNamedQueries(db, masterNamedQueries)
+	if err != nil {
+		log.Println("PrepareNamedQueries err:", err)
+		return nil, err
+	}
+
+	return &ShowtimesRepository{
+		db:                db,
+		masterStmts:       stmpts,
+		masterNamedStmpts: namedStmpts,
+	}, nil
+}
+
+func (r *ShowtimesRepository) getStatement(ctx context.Context, queryId int) (*sqlx.Stmt, error) {
+	var err error
+	var statement *sqlx.Stmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			statement, err = atomicSession.Tx().PreparexContext(ctx, masterQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		statement = r.masterStmts[queryId]
+	}
+	return statement, err
+}
+
+func (r *ShowtimesRepository) getNamedStatement(ctx context.Context, queryId int) (*sqlx.NamedStmt, error) {
+	var err error
+	var namedStmt *sqlx.NamedStmt
+	if atomicSessionCtx, ok := ctx.(*frsAtomic.AtomicSessionContext); ok {
+		if atomicSession, ok := atomicSessionCtx.AtomicSession.(*atomicSqlx.SqlxAtomicSession); ok {
+			namedStmt, err = atomicSession.Tx().PrepareNamedContext(ctx, masterNamedQueries[queryId])
+		} else {
+			err = frsAtomic.InvalidAtomicSessionProvider
+		}
+	} else {
+		namedStmt = r.masterNamedStmpts[queryId]
+	}
+	return namedStmt, err
+}
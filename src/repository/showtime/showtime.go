@@ -0,0 +1,124 @@
+package showtime
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/repository/pgerr"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func (sr *ShowtimesRepository) Get(ctx context.Context, id int64) (entity.Showtime, error) {
+	var Showtime entity.Showtime
+
+	stmt, err := sr.getStatement(ctx, GetByID)
+	if err != nil {
+		log.Println("getStatement err: ", err)
+		return Showtime, err
+	}
+
+	if err = stmt.GetContext(ctx, &Showtime, id); err != nil {
+		log.Println("get showtime err: ", err)
+		return Showtime, err
+	}
+
+	return Showtime, nil
+}
+
+// GetByMovie return showtime of the movie starting in [from, to), city is optional
+func (sr *ShowtimesRepository) GetByMovie(ctx context.Context, movieID int64, from, to time.Time, city string) ([]*entity.Showtime, error) {
+	var Showtime []*entity.Showtime
+
+	err := sr.masterStmts[GetByMovieID].SelectContext(ctx, &Showtime, movieID, from, to, city)
+	if err != nil {
+		log.Println("GetShowtimeByMovie err: ", err)
+		return nil, err
+	}
+
+	return Showtime, nil
+}
+
+// GetByCinema return showtime on every screen of the cinema starting in [from, to)
+func (sr *ShowtimesRepository) GetByCinema(ctx context.Context, cinemaID int64, from, to time.Time) ([]*entity.Showtime, error) {
+	var Showtime []*entity.Showtime
+
+	err := sr.masterStmts[GetByCinemaID].SelectContext(ctx, &Showtime, cinemaID, from, to)
+	if err != nil {
+		log.Println("GetShowtimeByCinema err: ", err)
+		return nil, err
+	}
+
+	return Showtime, nil
+}
+
+// Create return ErrShowtimeOverlap when the screen already has a showtime in the same time range
+func (sr *ShowtimesRepository) Create(ctx context.Context, data *entity.Showtime) (int64, error) {
+	var id int64
+
+	namedStmt, err := sr.getNamedStatement(ctx, InsertShowtime)
+	if err != nil {
+		log.Println("getNamedStatement err: ", err)
+		return id, err
+	}
+
+	if err = namedStmt.GetContext(ctx, &id, data); err != nil {
+		log.Println("insert showtime err: ", err)
+		if pgerr.IsExclusionViolation(err) {
+			err = appErr.ErrShowtimeOverlap
+		}
+		return id, err
+	}
+
+	return id, nil
+}
+
+// Update return ErrShowtimeOverlap when the screen already has a showtime in the same time range
+func (sr *ShowtimesRepository) Update(ctx context.Context, data *entity.Showtime) error {
+	namedStmt, err := sr.getNamedStatement(ctx, UpdateShowtime)
+	if err != nil {
+		log.Println("get named statement err: ", err)
+		return err
+	}
+
+	res, err := namedStmt.ExecContext(ctx, data)
+	if err != nil {
+		log.Println("exec err: ", err)
+		if pgerr.IsExclusionViolation(err) {
+			err = appErr.ErrShowtimeOverlap
+		}
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("Get rows affected err: ", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		log.Println("ID not exist err: ", sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (sr *ShowtimesRepository) Delete(ctx context.Context, id int64) error {
+	stmt, err := sr.getStatement(ctx, Delete)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	return nil
+}
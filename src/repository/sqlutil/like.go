@@ -0,0 +1,11 @@
+package sqlutil
+
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escape LIKE wildcard so keyword is always matched as plain text,
+// the query must use ESCAPE '\'
+func EscapeLike(keyword string) string {
+	return likeEscaper.Replace(keyword)
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DefaultLocalDir = "uploads"
+	DefaultBaseURL  = "/uploads"
+)
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// LocalStorage keep object as file under dir, object is served by
+// the router under baseURL
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+// InitLocalStorage use DefaultLocalDir and DefaultBaseURL when they are not set
+func InitLocalStorage(ctx context.Context, dir, baseURL string) (*LocalStorage, error) {
+	if dir == "" {
+		dir = DefaultLocalDir
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Println("create storage dir err: ", err)
+		return nil, err
+	}
+
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// FileSystem serve object of dir, directory and hidden file like the temporary file
+// of Put is not found so the object can not be listed
+func (s *LocalStorage) FileSystem() http.FileSystem {
+	return objectFileSystem{http.Dir(s.dir)}
+}
+
+type objectFileSystem struct {
+	fs http.FileSystem
+}
+
+func (ofs objectFileSystem) Open(name string) (http.File, error) {
+	if strings.HasPrefix(path.Base(name), ".") {
+		return nil, os.ErrNotExist
+	}
+
+	f, err := ofs.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if info.IsDir() {
+		f.Close()
+		return nil, os.ErrNotExist
+	}
+
+	return f, nil
+}
+
+// filePath reject key escaping dir, key is always slash separated
+func (s *LocalStorage) filePath(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key {
+		log.Println(ErrInvalidKey, key)
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+// Put write data to a temporary file then rename it so reader never see partial object
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	name, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		log.Println("create object dir err: ", err)
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		log.Println("create temp file err: ", err)
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		log.Println("write object err: ", err)
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		log.Println("close object err: ", err)
+		return err
+	}
+
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		log.Println("chmod object err: ", err)
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), name); err != nil {
+		log.Println("rename object err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// Delete does not fail when the object does not exist
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Println("delete object err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// MountPath is the path the router serve object under, it is empty
+// when baseURL point to another host
+func (s *LocalStorage) MountPath() string {
+	if !strings.HasPrefix(s.baseURL, "/") || strings.HasPrefix(s.baseURL, "//") {
+		return ""
+	}
+	return s.baseURL
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
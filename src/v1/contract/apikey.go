@@ -0,0 +1,75 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrAPIKeyExpiresInPast = errors.New("expires_at must be in the future")
+
+// APIKeyRequest issue a key, scopes is permission granted to the key and
+// the key never expire when expires_at is empty
+type APIKeyRequest struct {
+	Name       string     `json:"name" validate:"required,max=100"`
+	Owner      string     `json:"owner" validate:"required,max=255"`
+	Scopes     []string   `json:"scopes" validate:"required,min=1,dive,oneof=movie:create movie:update movie:delete movie:purge import:run movie:audit catalog:manage cinema:manage"`
+	DailyQuota int64      `json:"daily_quota" validate:"required,gt=0,lte=10000000"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Owner      string   `json:"owner"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	DailyQuota int64    `json:"daily_quota"`
+	ExpiresAt  string   `json:"expires_at,omitempty"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+	RevokedAt  string   `json:"revoked_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+// IssuedAPIKeyResponse has the key in plain text, it is returned only once when the key is issued or rotated
+type IssuedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func BuildAndValidateAPIKeyRequest(r *http.Request) (APIKeyRequest, error) {
+	var payload APIKeyRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.Owner = strings.TrimSpace(payload.Owner)
+
+	if err := validator.New().Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		log.Println("validate request body err: ", ErrAPIKeyExpiresInPast)
+		return payload, ErrAPIKeyExpiresInPast
+	}
+
+	return payload, nil
+}
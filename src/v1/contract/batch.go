@@ -0,0 +1,45 @@
+package contract
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// BatchGetMovieRequest is at most 100 movie id, id can be repeated
+// and the response has one item per requested id in the same order
+type BatchGetMovieRequest struct {
+	IDs []int64 `json:"ids" validate:"required,min=1,max=100,dive,gt=0"`
+}
+
+// BatchGetMovieResponse Found is false and Movie is nil when the movie does not exist or is deleted
+type BatchGetMovieResponse struct {
+	ID    int64          `json:"id"`
+	Found bool           `json:"found"`
+	Movie *MovieResponse `json:"movie"`
+}
+
+func BuildAndValidateBatchGetMovieRequest(r *http.Request) (BatchGetMovieRequest, error) {
+	var payload BatchGetMovieRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	if err := validator.New().Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,141 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrDuplicateSeat = errors.New("duplicate seat")
+
+const (
+	SeatAvailable = "available"
+	SeatHeld      = "held"
+	SeatBooked    = "booked"
+)
+
+type SeatResponse struct {
+	Seat   string `json:"seat"`
+	Status string `json:"status"`
+}
+
+type SeatAvailabilityResponse struct {
+	ShowtimeID int64           `json:"showtime_id"`
+	ScreenID   int64           `json:"screen_id"`
+	Available  int             `json:"available"`
+	Seats      []*SeatResponse `json:"seats"`
+}
+
+type HoldResponse struct {
+	ID         string   `json:"id"`
+	ShowtimeID int64    `json:"showtime_id"`
+	UserID     string   `json:"user_id"`
+	Seats      []string `json:"seats"`
+	ExpiresAt  string   `json:"expires_at"`
+}
+
+// HoldRequest seat is written as row and number, e.g. A12
+type HoldRequest struct {
+	UserID     string   `json:"-" validate:"required"`
+	ShowtimeID int64    `json:"showtime_id" validate:"required,gt=0"`
+	Seats      []string `json:"seats" validate:"required,min=1,max=10,dive,required,max=10"`
+}
+
+type BookingResponse struct {
+	ID         int      `json:"id"`
+	ShowtimeID int64    `json:"showtime_id"`
+	UserID     string   `json:"user_id"`
+	HoldID     string   `json:"hold_id"`
+	Seats      []string `json:"seats"`
+	TotalPrice int64    `json:"total_price"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// BookingRequest confirm a hold of the same user into a booking
+type BookingRequest struct {
+	UserID string `json:"-" validate:"required"`
+	HoldID string `json:"hold_id" validate:"required,uuid"`
+}
+
+func BuildAndValidateHoldRequest(r *http.Request) (HoldRequest, error) {
+	var payload HoldRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	payload.UserID = auth.GetSubject(r.Context())
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	seen := make(map[string]bool)
+	for i, seat := range payload.Seats {
+		seat = strings.ToUpper(strings.TrimSpace(seat))
+		if seen[seat] {
+			log.Println("validate request body err: ", ErrDuplicateSeat)
+			return payload, ErrDuplicateSeat
+		}
+		seen[seat] = true
+		payload.Seats[i] = seat
+	}
+
+	return payload, nil
+}
+
+func BuildAndValidateBookingRequest(r *http.Request) (BookingRequest, error) {
+	var payload BookingRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	payload.UserID = auth.GetSubject(r.Context())
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+func ValidateHoldIDParamRequest(r *http.Request) (string, error) {
+	id := chi.URLParam(r, "id")
+
+	validator := validator.New()
+
+	if err := validator.Var(id, "required,uuid"); err != nil {
+		log.Println(err)
+		return id, err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,115 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	BulkActionUpdate  = "update"
+	BulkActionDelete  = "delete"
+	BulkActionRestore = "restore"
+
+	// BulkModeAllOrNothing run every operation in one transaction, one failing item roll back the batch.
+	// BulkModeBestEffort run every item in its own transaction and keep the succeeded one
+	BulkModeAllOrNothing = "all_or_nothing"
+	BulkModeBestEffort   = "best_effort"
+
+	BulkStatusSucceeded  = "succeeded"
+	BulkStatusFailed     = "failed"
+	BulkStatusRolledBack = "rolled_back"
+
+	// MaxBulkItems is the largest number of id of all operation in one request
+	MaxBulkItems = 500
+)
+
+var (
+	ErrTooManyBulkItems  = errors.New("bulk request has more than 500 id")
+	ErrInvalidBulkFields = errors.New("update operation need fields and delete or restore operation must not have fields")
+)
+
+// BulkOperationRequest apply one action to every id, Fields is merge patch
+// of update action and is decoded into Patch
+type BulkOperationRequest struct {
+	Action string                     `json:"action" validate:"required,oneof=update delete restore"`
+	IDs    []int64                    `json:"ids" validate:"required,min=1,dive,gt=0"`
+	Fields map[string]json.RawMessage `json:"fields"`
+
+	Patch MoviePatchRequest `json:"-"`
+}
+
+type BulkRequest struct {
+	Mode       string                 `json:"mode" validate:"omitempty,oneof=all_or_nothing best_effort"`
+	Operations []BulkOperationRequest `json:"operations" validate:"required,min=1,dive"`
+}
+
+// BulkItemResponse is result of one id of an operation, Reason is set when the item is not succeeded
+type BulkItemResponse struct {
+	Operation int    `json:"operation"`
+	Action    string `json:"action"`
+	ID        int64  `json:"id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type BulkResponse struct {
+	Mode      string              `json:"mode"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []*BulkItemResponse `json:"results"`
+}
+
+// BuildAndValidateBulkRequest mode default to all_or_nothing, the total
+// number of id of every operation must not exceed MaxBulkItems
+func BuildAndValidateBulkRequest(r *http.Request) (BulkRequest, error) {
+	var payload BulkRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	if err := validator.New().Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	if payload.Mode == "" {
+		payload.Mode = BulkModeAllOrNothing
+	}
+
+	items := 0
+	for i := range payload.Operations {
+		operation := &payload.Operations[i]
+
+		items += len(operation.IDs)
+		if items > MaxBulkItems {
+			log.Println("validate request body err: ", ErrTooManyBulkItems)
+			return payload, ErrTooManyBulkItems
+		}
+
+		if (operation.Action == BulkActionUpdate) != (len(operation.Fields) > 0) {
+			log.Println("validate request body err: ", ErrInvalidBulkFields, i)
+			return payload, ErrInvalidBulkFields
+		}
+
+		if operation.Action == BulkActionUpdate {
+			if operation.Patch, err = buildMoviePatchRequest(operation.Fields); err != nil {
+				return payload, err
+			}
+		}
+	}
+
+	return payload, nil
+}
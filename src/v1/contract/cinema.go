@@ -0,0 +1,121 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrDuplicateSeatRow = errors.New("duplicate seat row")
+
+type CinemaResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	City      string `json:"city"`
+	Address   string `json:"address"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type GetListCinemaResponse struct {
+	Data       []*CinemaResponse
+	Pagination *frsUtils.Pagination
+}
+
+type CinemaRequest struct {
+	Name    string `json:"name" validate:"required,max=255"`
+	City    string `json:"city" validate:"required,max=100"`
+	Address string `json:"address"`
+}
+
+type SeatRow struct {
+	Row   string `json:"row" validate:"required,max=3,alpha"`
+	Seats int    `json:"seats" validate:"required,gt=0,lte=100"`
+}
+
+type ScreenResponse struct {
+	ID        int       `json:"id"`
+	CinemaID  int64     `json:"cinema_id"`
+	Name      string    `json:"name"`
+	SeatMap   []SeatRow `json:"seat_map"`
+	Capacity  int       `json:"capacity"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+}
+
+// ScreenRequest describe seat map as list of row, row name is unique in a screen
+type ScreenRequest struct {
+	Name    string    `json:"name" validate:"required,max=100"`
+	SeatMap []SeatRow `json:"seat_map" validate:"required,min=1,dive"`
+}
+
+func BuildAndValidateCinemaRequest(r *http.Request) (CinemaRequest, error) {
+	var payload CinemaRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.City = strings.TrimSpace(payload.City)
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+func BuildAndValidateScreenRequest(r *http.Request) (ScreenRequest, error) {
+	var payload ScreenRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+	for i := range payload.SeatMap {
+		payload.SeatMap[i].Row = strings.ToUpper(strings.TrimSpace(payload.SeatMap[i].Row))
+	}
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	rows := make(map[string]bool, len(payload.SeatMap))
+	for _, s := range payload.SeatMap {
+		if rows[s.Row] {
+			log.Println("validate request body err: ", ErrDuplicateSeatRow)
+			return payload, ErrDuplicateSeatRow
+		}
+		rows[s.Row] = true
+	}
+
+	return payload, nil
+}
@@ -4,19 +4,45 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	DefaultPage  = 1
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+var ErrInvalidPagination = errors.New("page and limit must be positive and limit must not exceed max limit")
+
 type GetListParam struct {
 	Page    int    `json:"page"`
 	Limit   int    `json:"limit"`
 	Offset  int    `json:"offset"`
 	Keyword string `json:"keyword"`
+
+	Sort        []SortField `json:"sort,omitempty"`
+	RatingMin   *string     `json:"rating_min,omitempty"`
+	RatingMax   *string     `json:"rating_max,omitempty"`
+	CreatedFrom *time.Time  `json:"created_from,omitempty"`
+	CreatedTo   *time.Time  `json:"created_to,omitempty"`
+	UpdatedFrom *time.Time  `json:"updated_from,omitempty"`
+	UpdatedTo   *time.Time  `json:"updated_to,omitempty"`
+	HasImage    *bool       `json:"has_image,omitempty"`
+	GenreIDs    []int64     `json:"genre_ids,omitempty"`
+
+	// CursorMode use keyset pagination instead of page and offset,
+	// Cursor is nil on the first page
+	CursorMode bool    `json:"cursor_mode,omitempty"`
+	Cursor     *Cursor `json:"cursor,omitempty"`
 }
 
 // ValidateQuery return common converted parameter from query parameter for get list data
@@ -25,15 +51,19 @@ type GetListParam struct {
 // limit is limit data loaded per page, offset is number data skiped when loaded data
 // data page and limit from query parameter is always number in string
 // its need to converted to int, it will return error if page and limit is not a number
+// or when page and limit is out of range
+// sort and filter query parameter is parsed by buildSortAndFilter
+// cursor mode is enabled by pagination=cursor or by passing cursor token,
+// page and offset is ignored in cursor mode
 func ValidateAndBuildRequest(r *http.Request) (getListParam *GetListParam, err error) {
 	// default value for page and limit
-	page, limit := 1, 10
+	page, limit := DefaultPage, DefaultLimit
 
 	// get data from query parameter
 	queryParams := r.URL.Query()
 	limitQuery := queryParams.Get("limit")
 	pageQuery := queryParams.Get("page")
-	keyword := queryParams.Get("keyword")
+	keyword := strings.TrimSpace(queryParams.Get("keyword"))
 
 	// query param validation
 	if pageQuery != "" {
@@ -50,6 +80,11 @@ func ValidateAndBuildRequest(r *http.Request) (getListParam *GetListParam, err e
 		}
 	}
 
+	if page < 1 || limit < 1 || limit > MaxLimit {
+		err = ErrInvalidPagination
+		return
+	}
+
 	// offset for OFFSET in get list query
 	offset := (page - 1) * limit
 	getListParam = &GetListParam{
@@ -59,6 +94,25 @@ func ValidateAndBuildRequest(r *http.Request) (getListParam *GetListParam, err e
 		Keyword: keyword,
 	}
 
+	if err = buildSortAndFilter(queryParams, getListParam); err != nil {
+		getListParam = nil
+		return
+	}
+
+	cursorToken := queryParams.Get("cursor")
+	if cursorToken != "" || queryParams.Get("pagination") == PaginationModeCursor {
+		getListParam.CursorMode = true
+		getListParam.Page = DefaultPage
+		getListParam.Offset = 0
+	}
+
+	if cursorToken != "" {
+		if getListParam.Cursor, err = DecodeCursor(cursorToken, getListParam.Sort); err != nil {
+			getListParam = nil
+			return
+		}
+	}
+
 	return
 }
 
@@ -0,0 +1,95 @@
+package contract
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	DefaultCastLimit = 5
+	MaxCastLimit     = 50
+)
+
+type CreditResponse struct {
+	PersonID      int64  `json:"person_id"`
+	PersonName    string `json:"person_name"`
+	Role          string `json:"role"`
+	CharacterName string `json:"character_name"`
+	BillingOrder  int    `json:"billing_order"`
+}
+
+type CreditRequest struct {
+	PersonID      int64  `json:"person_id" validate:"required,gt=0"`
+	Role          string `json:"role" validate:"required,oneof=director writer actor"`
+	CharacterName string `json:"character_name" validate:"max=255"`
+	BillingOrder  int    `json:"billing_order" validate:"gte=0"`
+}
+
+type MovieCreditsRequest struct {
+	Credits []CreditRequest `json:"credits" validate:"dive"`
+}
+
+// GetMovieParam is option of movie detail,
+// include=cast embed top billed cast limited by cast_limit
+type GetMovieParam struct {
+	IncludeCast bool `json:"include_cast"`
+	CastLimit   int  `json:"cast_limit"`
+}
+
+func ValidateAndBuildGetMovieRequest(r *http.Request) (param GetMovieParam, err error) {
+	queryParams := r.URL.Query()
+
+	for _, include := range queryParams["include"] {
+		if include == "cast" {
+			param.IncludeCast = true
+		}
+	}
+
+	if !param.IncludeCast {
+		return
+	}
+
+	param.CastLimit = DefaultCastLimit
+	if castLimit := queryParams.Get("cast_limit"); castLimit != "" {
+		param.CastLimit, err = strconv.Atoi(castLimit)
+		if err != nil {
+			return
+		}
+	}
+
+	if param.CastLimit < 1 || param.CastLimit > MaxCastLimit {
+		err = ErrInvalidFilter
+		return
+	}
+
+	return
+}
+
+func BuildAndValidateMovieCreditsRequest(r *http.Request) (MovieCreditsRequest, error) {
+	var payload MovieCreditsRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,67 @@
+package contract
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	PaginationModeCursor = "cursor"
+)
+
+var ErrInvalidCursor = errors.New("cursor is invalid or does not match the requested sort")
+
+// Cursor is decoded form of next_cursor and prev_cursor token,
+// it hold sort key value and id of the boundary row of a page
+type Cursor struct {
+	Sort     string   `json:"s"`
+	Values   []string `json:"v"`
+	ID       int64    `json:"id"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// SortSignature return canonical representation of sort, used to make sure
+// a cursor is only used with the same sort it was created from
+func SortSignature(sort []SortField) string {
+	fields := make([]string, len(sort))
+	for i, s := range sort {
+		direction := sortDirectionAsc
+		if s.Desc {
+			direction = sortDirectionDesc
+		}
+		fields[i] = s.Field + ":" + direction
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// EncodeCursor return opaque token of cursor
+func EncodeCursor(cursor Cursor) string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parse opaque token and validate it against current sort
+func DecodeCursor(token string, sort []SortField) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if cursor.Sort != SortSignature(sort) || len(cursor.Values) != len(sort) {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,73 @@
+package contract
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+)
+
+const (
+	DefaultDuplicateThreshold = 0.5
+	// MinDuplicateThreshold is default pg_trgm.similarity_threshold, lower
+	// threshold is not reported because pair is matched with the % operator
+	MinDuplicateThreshold = 0.3
+)
+
+var ErrInvalidDuplicateThreshold = errors.New("threshold must be between 0.3 and 1")
+
+type GetDuplicateParam struct {
+	Page      int     `json:"page"`
+	Limit     int     `json:"limit"`
+	Offset    int     `json:"offset"`
+	Threshold float64 `json:"threshold"`
+}
+
+type DuplicateMovieResponse struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type DuplicateResponse struct {
+	Movie      DuplicateMovieResponse `json:"movie"`
+	Duplicate  DuplicateMovieResponse `json:"duplicate"`
+	Similarity float64                `json:"similarity"`
+}
+
+type GetListDuplicateResponse struct {
+	Data       []*DuplicateResponse
+	Pagination *frsUtils.Pagination
+}
+
+// ValidateAndBuildDuplicateRequest read page, limit and threshold, threshold
+// is similarity of the title from 0.3 to 1 and default to 0.5
+func ValidateAndBuildDuplicateRequest(r *http.Request) (param GetDuplicateParam, err error) {
+	listParam, err := ValidateAndBuildRequest(r)
+	if err != nil {
+		return param, err
+	}
+
+	param = GetDuplicateParam{
+		Page:      listParam.Page,
+		Limit:     listParam.Limit,
+		Offset:    listParam.Offset,
+		Threshold: DefaultDuplicateThreshold,
+	}
+
+	if thresholdQuery := r.URL.Query().Get("threshold"); thresholdQuery != "" {
+		param.Threshold, err = strconv.ParseFloat(thresholdQuery, 64)
+		if err != nil {
+			log.Println(err)
+			return param, err
+		}
+	}
+
+	if param.Threshold < MinDuplicateThreshold || param.Threshold > 1 {
+		log.Println(ErrInvalidDuplicateThreshold)
+		return param, ErrInvalidDuplicateThreshold
+	}
+
+	return param, nil
+}
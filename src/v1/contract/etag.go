@@ -0,0 +1,115 @@
+package contract
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ETagHeader        = "ETag"
+	IfMatchHeader     = "If-Match"
+	IfNoneMatchHeader = "If-None-Match"
+)
+
+var ErrInvalidETag = errors.New("invalid etag")
+
+// ETagMatch is parsed If-Match or If-None-Match header, Any is set by *.
+// Zero value means the header is not sent
+type ETagMatch struct {
+	Any      bool
+	Versions []int
+}
+
+func (m ETagMatch) IsEmpty() bool {
+	return !m.Any && len(m.Versions) == 0
+}
+
+// Match report whether the ETag of version is listed in the header
+func (m ETagMatch) Match(version int) bool {
+	if m.Any {
+		return true
+	}
+
+	for _, v := range m.Versions {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatETag return strong ETag of a resource version
+func FormatETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// parseETagMatch parse comma separated entity tag, weak tag is only accepted
+// when weak comparison is allowed, otherwise it never match
+func parseETagMatch(header string, weak bool) (ETagMatch, error) {
+	var match ETagMatch
+
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return match, nil
+	}
+
+	if header == "*" {
+		match.Any = true
+		return match, nil
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		isWeak := strings.HasPrefix(tag, "W/")
+		tag = strings.TrimPrefix(tag, "W/")
+
+		value, err := strconv.Unquote(tag)
+		if err != nil || !strings.HasPrefix(tag, `"`) {
+			log.Println(ErrInvalidETag, tag)
+			return match, ErrInvalidETag
+		}
+
+		version, err := strconv.Atoi(value)
+		if err != nil {
+			// tag of another format can not match any version
+			continue
+		}
+
+		if isWeak && !weak {
+			continue
+		}
+
+		match.Versions = append(match.Versions, version)
+	}
+
+	if len(match.Versions) == 0 {
+		// every listed tag is unknown, version start from 1 so 0 keep
+		// the header non empty while matching nothing
+		match.Versions = []int{0}
+	}
+
+	return match, nil
+}
+
+// BuildIfMatchRequest parse If-Match header with strong comparison
+func BuildIfMatchRequest(r *http.Request) (ETagMatch, error) {
+	return parseETagMatch(r.Header.Get(IfMatchHeader), false)
+}
+
+// BuildIfNoneMatchRequest parse If-None-Match header with weak comparison
+func BuildIfNoneMatchRequest(r *http.Request) (ETagMatch, error) {
+	return parseETagMatch(r.Header.Get(IfNoneMatchHeader), true)
+}
+
+// SetETag write ETag header of the version, it is skipped for unknown version
+func SetETag(w http.ResponseWriter, version int) {
+	if version <= 0 {
+		return
+	}
+	w.Header().Set(ETagHeader, FormatETag(version))
+}
@@ -0,0 +1,315 @@
+package contract
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+
+	// ExportFlushRows is number of row written between flush to the client
+	ExportFlushRows = 500
+
+	// ExportEndMarker is the first field of the last csv record, the second field is the number of row.
+	// Client can tell a complete file from one truncated by an error in the middle of the stream
+	ExportEndMarker = "#end"
+)
+
+var (
+	ErrInvalidExportFormat = errors.New("format must be one of csv, ndjson, xlsx")
+
+	// ExportContentTypes map export format to response content type
+	ExportContentTypes = map[string]string{
+		ExportFormatCSV:    "text/csv; charset=utf-8",
+		ExportFormatNDJSON: "application/x-ndjson",
+		ExportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	}
+)
+
+type ExportRequest struct {
+	Format string
+	Params GetListParam
+}
+
+// ValidateAndBuildExportRequest read format and the same keyword, sort and filter as the list endpoint,
+// page, limit and cursor is ignored because the whole filtered catalog is exported, format default to csv
+func ValidateAndBuildExportRequest(r *http.Request) (request ExportRequest, err error) {
+	queryParams := r.URL.Query()
+
+	request = ExportRequest{
+		Format: strings.ToLower(strings.TrimSpace(queryParams.Get("format"))),
+		Params: GetListParam{
+			Keyword: strings.TrimSpace(queryParams.Get("keyword")),
+		},
+	}
+
+	if request.Format == "" {
+		request.Format = ExportFormatCSV
+	}
+
+	if _, ok := ExportContentTypes[request.Format]; !ok {
+		return request, ErrInvalidExportFormat
+	}
+
+	if err = buildSortAndFilter(queryParams, &request.Params); err != nil {
+		return request, err
+	}
+
+	return request, nil
+}
+
+type exportColumn struct {
+	name    string
+	numeric bool
+	value   func(m *MovieResponse) string
+}
+
+// exportColumns is column of csv and xlsx export, genres is joined with ImportGenreSeparator
+var exportColumns = []exportColumn{
+	{name: "id", numeric: true, value: func(m *MovieResponse) string { return strconv.Itoa(m.ID) }},
+	{name: "title", value: func(m *MovieResponse) string { return m.Title }},
+	{name: "description", value: func(m *MovieResponse) string { return m.Description }},
+	{name: "rating", numeric: true, value: func(m *MovieResponse) string { return formatFloat(m.Rating) }},
+	{name: "audience_rating", numeric: true, value: func(m *MovieResponse) string { return formatFloat(m.AudienceRating) }},
+	{name: "audience_rating_count", numeric: true, value: func(m *MovieResponse) string { return strconv.FormatInt(m.AudienceRatingCount, 10) }},
+	{name: "image", value: func(m *MovieResponse) string { return m.Image }},
+	{name: "runtime", numeric: true, value: func(m *MovieResponse) string { return strconv.Itoa(m.Runtime) }},
+	{name: "genres", value: func(m *MovieResponse) string { return strings.Join(m.Genres, ImportGenreSeparator) }},
+	{name: "created_at", value: func(m *MovieResponse) string { return m.CreatedAt }},
+	{name: "updated_at", value: func(m *MovieResponse) string { return m.UpdatedAt }},
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// exportRecord is the csv and xlsx value of the movie, text starting with a formula character
+// is prefixed with a quote so a spreadsheet does not run it as a formula (CSV injection)
+func exportRecord(m *MovieResponse) []string {
+	record := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		record[i] = column.value(m)
+		if !column.numeric && record[i] != "" && strings.ContainsRune("=+-@\t\r", rune(record[i][0])) {
+			record[i] = "'" + record[i]
+		}
+	}
+
+	return record
+}
+
+// exportEnd is the last ndjson line, see ExportEndMarker
+type exportEnd struct {
+	End  bool `json:"end"`
+	Rows int  `json:"rows"`
+}
+
+// ExportWriter encode movie one by one in the export format,
+// Flush push buffered row to the underlying writer and Close write the trailer of the file,
+// csv and ndjson end with a marker holding the number of row and xlsx with the end of the zip
+type ExportWriter interface {
+	Write(m *MovieResponse) error
+	Flush() error
+	Close() error
+}
+
+// NewExportWriter return writer of a format accepted by ValidateAndBuildExportRequest
+func NewExportWriter(format string, w io.Writer) (ExportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVExportWriter(w)
+	case ExportFormatNDJSON:
+		return newNDJSONExportWriter(w), nil
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(w)
+	default:
+		return nil, ErrInvalidExportFormat
+	}
+}
+
+type csvExportWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVExportWriter(w io.Writer) (*csvExportWriter, error) {
+	header := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column.name
+	}
+
+	cw := &csvExportWriter{w: csv.NewWriter(w)}
+	return cw, cw.w.Write(header)
+}
+
+func (cw *csvExportWriter) Write(m *MovieResponse) error {
+	cw.rows++
+	return cw.w.Write(exportRecord(m))
+}
+
+func (cw *csvExportWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvExportWriter) Close() error {
+	if err := cw.w.Write([]string{ExportEndMarker, strconv.Itoa(cw.rows)}); err != nil {
+		return err
+	}
+
+	return cw.Flush()
+}
+
+type ndjsonExportWriter struct {
+	buf  *bufio.Writer
+	enc  *json.Encoder
+	rows int
+}
+
+func newNDJSONExportWriter(w io.Writer) *ndjsonExportWriter {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	// the file is not rendered as html so <, > and & is kept as is
+	enc.SetEscapeHTML(false)
+
+	return &ndjsonExportWriter{buf: buf, enc: enc}
+}
+
+// Write encode the movie like an item of the list endpoint, json.Encoder end every value with a newline
+func (nw *ndjsonExportWriter) Write(m *MovieResponse) error {
+	nw.rows++
+	return nw.enc.Encode(m)
+}
+
+func (nw *ndjsonExportWriter) Flush() error {
+	return nw.buf.Flush()
+}
+
+func (nw *ndjsonExportWriter) Close() error {
+	if err := nw.enc.Encode(exportEnd{End: true, Rows: nw.rows}); err != nil {
+		return err
+	}
+
+	return nw.Flush()
+}
+
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Movies" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetHeader = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// xlsxExportWriter write a single sheet workbook, the static part is written first
+// so the sheet is the last zip entry and its row can be streamed without buffering the file,
+// string is written as inline string so no shared string table is needed
+type xlsxExportWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	xw := &xlsxExportWriter{zw: zw, sheet: bufio.NewWriter(f)}
+	if _, err = xw.sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	header := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column.name
+	}
+
+	return xw, xw.writeRow(header, func(int) bool { return false })
+}
+
+func (xw *xlsxExportWriter) Write(m *MovieResponse) error {
+	return xw.writeRow(exportRecord(m), func(i int) bool { return exportColumns[i].numeric })
+}
+
+func (xw *xlsxExportWriter) writeRow(record []string, numeric func(i int) bool) error {
+	xw.sheet.WriteString("<row>")
+	for i, value := range record {
+		if numeric(i) {
+			xw.sheet.WriteString("<c><v>" + value + "</v></c>")
+			continue
+		}
+
+		xw.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		// EscapeText replace character that is invalid in xml so the sheet stay readable
+		if err := xml.EscapeText(xw.sheet, []byte(value)); err != nil {
+			return err
+		}
+		xw.sheet.WriteString("</t></is></c>")
+	}
+	_, err := xw.sheet.WriteString("</row>")
+
+	return err
+}
+
+func (xw *xlsxExportWriter) Flush() error {
+	if err := xw.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return xw.zw.Flush()
+}
+
+func (xw *xlsxExportWriter) Close() error {
+	if _, err := xw.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+
+	if err := xw.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return xw.zw.Close()
+}
@@ -0,0 +1,210 @@
+package contract
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SortFieldTitle     = "title"
+	SortFieldRating    = "rating"
+	SortFieldCreatedAt = "created_at"
+	SortFieldUpdatedAt = "updated_at"
+
+	sortDirectionAsc  = "asc"
+	sortDirectionDesc = "desc"
+
+	dateLayout = "2006-01-02"
+)
+
+var (
+	ErrInvalidSort   = errors.New("sort must be one of title, rating, created_at, updated_at with optional asc or desc direction")
+	ErrInvalidFilter = errors.New("invalid filter value")
+	ErrInvalidRange  = errors.New("filter range minimum must not be greater than maximum")
+
+	// decimalPattern is decimal number accepted by postgres numeric, hex and special value of ParseFloat is rejected
+	decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+	// SortableFields is whitelist of field that can be used in sort query parameter
+	SortableFields = map[string]bool{
+		SortFieldTitle:     true,
+		SortFieldRating:    true,
+		SortFieldCreatedAt: true,
+		SortFieldUpdatedAt: true,
+	}
+)
+
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// buildSortAndFilter fill sort and filter of getListParam from query parameter
+// sort is comma separated field with optional direction, e.g. sort=rating:desc,title
+// rating_min and rating_max is inclusive rating range
+// created_from, created_to, updated_from and updated_to accept date (2006-01-02) or RFC3339 time,
+// date only value on *_to parameter is inclusive for the whole day
+// has_image filter movie with or without image
+// genre_ids is comma separated genre id, movie having any of the genre is returned
+func buildSortAndFilter(queryParams url.Values, getListParam *GetListParam) (err error) {
+	if getListParam.Sort, err = parseSort(queryParams["sort"]); err != nil {
+		return
+	}
+
+	if getListParam.RatingMin, err = parseDecimalParam(queryParams.Get("rating_min")); err != nil {
+		return
+	}
+
+	if getListParam.RatingMax, err = parseDecimalParam(queryParams.Get("rating_max")); err != nil {
+		return
+	}
+
+	if getListParam.CreatedFrom, err = parseTimeParam(queryParams.Get("created_from"), false); err != nil {
+		return
+	}
+
+	if getListParam.CreatedTo, err = parseTimeParam(queryParams.Get("created_to"), true); err != nil {
+		return
+	}
+
+	if getListParam.UpdatedFrom, err = parseTimeParam(queryParams.Get("updated_from"), false); err != nil {
+		return
+	}
+
+	if getListParam.UpdatedTo, err = parseTimeParam(queryParams.Get("updated_to"), true); err != nil {
+		return
+	}
+
+	if hasImage := queryParams.Get("has_image"); hasImage != "" {
+		value, parseErr := strconv.ParseBool(hasImage)
+		if parseErr != nil {
+			return ErrInvalidFilter
+		}
+		getListParam.HasImage = &value
+	}
+
+	if getListParam.GenreIDs, err = parseIDList(queryParams["genre_ids"]); err != nil {
+		return
+	}
+
+	if getListParam.RatingMin != nil && getListParam.RatingMax != nil {
+		ratingMin, _ := strconv.ParseFloat(*getListParam.RatingMin, 64)
+		ratingMax, _ := strconv.ParseFloat(*getListParam.RatingMax, 64)
+		if ratingMin > ratingMax {
+			return ErrInvalidRange
+		}
+	}
+
+	if getListParam.CreatedFrom != nil && getListParam.CreatedTo != nil && getListParam.CreatedFrom.After(*getListParam.CreatedTo) {
+		return ErrInvalidRange
+	}
+
+	if getListParam.UpdatedFrom != nil && getListParam.UpdatedTo != nil && getListParam.UpdatedFrom.After(*getListParam.UpdatedTo) {
+		return ErrInvalidRange
+	}
+
+	return nil
+}
+
+func parseSort(values []string) ([]SortField, error) {
+	var sort []SortField
+	seen := map[string]bool{}
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			field, direction, _ := strings.Cut(item, ":")
+			field = strings.ToLower(strings.TrimSpace(field))
+			direction = strings.ToLower(strings.TrimSpace(direction))
+
+			if !SortableFields[field] || seen[field] {
+				return nil, ErrInvalidSort
+			}
+
+			if direction != "" && direction != sortDirectionAsc && direction != sortDirectionDesc {
+				return nil, ErrInvalidSort
+			}
+
+			seen[field] = true
+			sort = append(sort, SortField{
+				Field: field,
+				Desc:  direction == sortDirectionDesc,
+			})
+		}
+	}
+
+	return sort, nil
+}
+
+func parseIDList(values []string) ([]int64, error) {
+	var ids []int64
+	seen := map[int64]bool{}
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(item, 10, 64)
+			if err != nil || id < 1 {
+				return nil, ErrInvalidFilter
+			}
+
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// parseDecimalParam validate decimal number and keep it as sent, so it is compared
+// with numeric column without being rounded to float
+func parseDecimalParam(value string) (*string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if !decimalPattern.MatchString(value) {
+		return nil, ErrInvalidFilter
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return nil, ErrInvalidFilter
+	}
+
+	return &value, nil
+}
+
+func parseTimeParam(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, ErrInvalidFilter
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return &t, nil
+}
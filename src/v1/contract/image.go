@@ -0,0 +1,74 @@
+package contract
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+)
+
+const (
+	// MaxImageSize is the largest accepted poster in bytes
+	MaxImageSize = 10 << 20
+
+	ImageFormField = "image"
+)
+
+var (
+	ErrImageTooLarge        = errors.New("image too large")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+
+	// ImageContentTypes is accepted poster type, it is sniffed from the
+	// content so client provided content type is not trusted
+	ImageContentTypes = map[string]string{
+		"image/jpeg": "jpg",
+		"image/png":  "png",
+		"image/gif":  "gif",
+	}
+)
+
+// ImageRequest is a poster uploaded as multipart form file in ImageFormField
+type ImageRequest struct {
+	Data        []byte
+	ContentType string
+}
+
+func BuildAndValidateImageRequest(w http.ResponseWriter, r *http.Request) (ImageRequest, error) {
+	var payload ImageRequest
+
+	// allow room for multipart boundary and header around the file
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImageSize+1<<20)
+
+	file, _, err := r.FormFile(ImageFormField)
+	if err != nil {
+		log.Println("read form file err: ", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return payload, ErrImageTooLarge
+		}
+		return payload, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxImageSize+1))
+	if err != nil {
+		log.Println("read image err: ", err)
+		return payload, err
+	}
+
+	if len(data) > MaxImageSize {
+		log.Println("validate image err: ", ErrImageTooLarge)
+		return payload, ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := ImageContentTypes[contentType]; !ok {
+		log.Println("validate image err: ", ErrUnsupportedImageType, contentType)
+		return payload, ErrUnsupportedImageType
+	}
+
+	payload.Data = data
+	payload.ContentType = contentType
+
+	return payload, nil
+}
@@ -0,0 +1,338 @@
+package contract
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// MaxImportSize is the largest accepted import file in bytes
+	MaxImportSize = 20 << 20
+
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	// ImportGenreSeparator separate genre id in genre_ids column of csv
+	ImportGenreSeparator = "|"
+)
+
+var (
+	ErrImportTooLarge      = errors.New("import file too large")
+	ErrInvalidImportHeader = errors.New("csv header must have title column and only known column")
+	ErrEmptyImport         = errors.New("import file has no row")
+
+	// ImportContentTypes map accepted content type to import format
+	ImportContentTypes = map[string]string{
+		"text/csv":                ImportFormatCSV,
+		"application/x-ndjson":    ImportFormatNDJSON,
+		"application/jsonl":       ImportFormatNDJSON,
+		"application/x-jsonlines": ImportFormatNDJSON,
+	}
+
+	importColumns = map[string]bool{
+		"title":       true,
+		"description": true,
+		"rating":      true,
+		"image":       true,
+		"runtime":     true,
+		"genre_ids":   true,
+	}
+
+	// importValidator report field by its json name so the reason match the file column
+	importValidator = func() *validator.Validate {
+		v := validator.New()
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		})
+		return v
+	}()
+)
+
+// ImportRow is a parsed row, Reason is set when the row is invalid and is not imported.
+// Fields is the csv column or ndjson key of the row, existing movie only get these field overwritten
+type ImportRow struct {
+	Line    int
+	Request MovieRequest
+	Fields  map[string]bool
+	Reason  string
+}
+
+// ImportRequest is rows of the file, every row is validated with MovieRequest rule
+type ImportRequest struct {
+	DryRun bool
+	// Async run the import in background even when the file is small
+	Async bool
+	Rows  []ImportRow
+}
+
+type ImportRowResponse struct {
+	Line    int    `json:"line"`
+	Title   string `json:"title,omitempty"`
+	Status  string `json:"status"`
+	MovieID int64  `json:"movie_id,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ImportSummaryResponse struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Error   int `json:"error"`
+}
+
+type ImportJobResponse struct {
+	ID         string                `json:"id"`
+	Status     string                `json:"status"`
+	DryRun     bool                  `json:"dry_run"`
+	Total      int                   `json:"total"`
+	Processed  int                   `json:"processed"`
+	Summary    ImportSummaryResponse `json:"summary"`
+	Rows       []*ImportRowResponse  `json:"rows"`
+	Error      string                `json:"error,omitempty"`
+	CreatedAt  string                `json:"created_at"`
+	FinishedAt string                `json:"finished_at,omitempty"`
+}
+
+// Finished report whether every row of the import is processed
+func (res ImportJobResponse) Finished() bool {
+	return res.FinishedAt != ""
+}
+
+// BuildAndValidateImportRequest read csv or ndjson body by its content type.
+// Csv need a header row with title column, genre_ids is separated by
+// ImportGenreSeparator. Invalid row is reported instead of failing the request
+func BuildAndValidateImportRequest(w http.ResponseWriter, r *http.Request) (ImportRequest, error) {
+	var payload ImportRequest
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		log.Println("parse content type err: ", err)
+		return payload, ErrUnsupportedContentType
+	}
+
+	format, ok := ImportContentTypes[mediaType]
+	if !ok {
+		log.Println("validate import err: ", ErrUnsupportedContentType, mediaType)
+		return payload, ErrUnsupportedContentType
+	}
+
+	queryParams := r.URL.Query()
+	for name, dest := range map[string]*bool{"dry_run": &payload.DryRun, "async": &payload.Async} {
+		if value := queryParams.Get(name); value != "" {
+			if *dest, err = strconv.ParseBool(value); err != nil {
+				log.Println(err)
+				return payload, err
+			}
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportSize)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return payload, ErrImportTooLarge
+		}
+		return payload, err
+	}
+
+	switch format {
+	case ImportFormatCSV:
+		payload.Rows, err = parseImportCSV(body)
+	default:
+		payload.Rows, err = parseImportNDJSON(body)
+	}
+	if err != nil {
+		return payload, err
+	}
+
+	if len(payload.Rows) == 0 {
+		log.Println("validate import err: ", ErrEmptyImport)
+		return payload, ErrEmptyImport
+	}
+
+	return payload, nil
+}
+
+func parseImportCSV(body []byte) ([]ImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		log.Println("read csv header err: ", err)
+		if err == io.EOF {
+			return nil, ErrEmptyImport
+		}
+		return nil, ErrInvalidImportHeader
+	}
+
+	columns := make(map[string]int, len(header))
+	fields := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !importColumns[name] {
+			log.Println("validate csv header err: ", ErrInvalidImportHeader, name)
+			return nil, ErrInvalidImportHeader
+		}
+		columns[name] = i
+		fields[name] = true
+	}
+
+	if _, ok := columns["title"]; !ok {
+		log.Println("validate csv header err: ", ErrInvalidImportHeader)
+		return nil, ErrInvalidImportHeader
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, ImportRow{Line: parseErr.StartLine, Reason: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			log.Println("read csv err: ", err)
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := ImportRow{Line: line, Fields: fields}
+
+		if err = csvMovieRequest(record, columns, &row.Request); err == nil {
+			err = validateMovieRequest(importValidator, &row.Request)
+		}
+		row.Reason = importRowReason(err)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func csvMovieRequest(record []string, columns map[string]int, request *MovieRequest) (err error) {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	request.Title = value("title")
+	request.Description = value("description")
+	request.Image = value("image")
+
+	if rating := value("rating"); rating != "" {
+		parsed, err := strconv.ParseFloat(rating, 32)
+		if err != nil {
+			return fmt.Errorf("rating is not a number")
+		}
+		rating := float32(parsed)
+		request.Rating = &rating
+	}
+
+	if runtime := value("runtime"); runtime != "" {
+		if request.Runtime, err = strconv.Atoi(runtime); err != nil {
+			return fmt.Errorf("runtime is not a number")
+		}
+	}
+
+	// empty genre_ids keep linked genre of existing movie
+	if genreIDs := value("genre_ids"); genreIDs != "" {
+		for _, id := range strings.Split(genreIDs, ImportGenreSeparator) {
+			genreID, err := strconv.ParseInt(strings.TrimSpace(id), 10, 64)
+			if err != nil {
+				return fmt.Errorf("genre_ids is not a %s separated list of number", ImportGenreSeparator)
+			}
+			request.GenreIDs = append(request.GenreIDs, genreID)
+		}
+	}
+
+	return nil
+}
+
+func parseImportNDJSON(body []byte) ([]ImportRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 64<<10), MaxImportSize)
+
+	var rows []ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := ImportRow{Line: line}
+		var keys map[string]json.RawMessage
+		err := json.Unmarshal(data, &keys)
+		if err == nil {
+			row.Fields = make(map[string]bool, len(keys))
+			for key := range keys {
+				if key = strings.ToLower(key); importColumns[key] {
+					row.Fields[key] = true
+				}
+			}
+			err = json.Unmarshal(data, &row.Request)
+		}
+		if err == nil {
+			err = validateMovieRequest(importValidator, &row.Request)
+		}
+		row.Reason = importRowReason(err)
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Println("read ndjson err: ", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func ValidateImportJobIDParamRequest(r *http.Request) (string, error) {
+	id := chi.URLParam(r, "job_id")
+
+	if err := validator.New().Var(id, "required,uuid"); err != nil {
+		log.Println(err)
+		return id, err
+	}
+
+	return id, nil
+}
+
+// importRowReason describe why the row is invalid, it is empty for valid row
+func importRowReason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		reasons := make([]string, len(validationErrs))
+		for i, fe := range validationErrs {
+			reasons[i] = fmt.Sprintf("%s failed on %s", fe.Field(), fe.Tag())
+		}
+		return strings.Join(reasons, ", ")
+	}
+
+	return err.Error()
+}
@@ -2,9 +2,12 @@ package contract
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,14 +15,36 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+const MergePatchContentType = "application/merge-patch+json"
+
+var (
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	ErrInvalidMergePatch      = errors.New("invalid merge patch")
+)
+
 type MovieResponse struct {
-	ID          int     `json:"id"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Rating      float32 `json:"rating"`
-	Image       string  `json:"image"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID          int      `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Rating      float32  `json:"rating"`
+	Image       string   `json:"image"`
+	Runtime     int      `json:"runtime"`
+	Genres      []string `json:"genres"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+	DeletedAt   string   `json:"deleted_at,omitempty"`
+
+	// Images is url of uploaded poster per size, Image is then the original
+	Images map[string]string `json:"images,omitempty"`
+
+	// Rating above is editorial rating, AudienceRating is aggregated from reviews
+	AudienceRating      float32 `json:"audience_rating"`
+	AudienceRatingCount int64   `json:"audience_rating_count"`
+
+	Cast []*CreditResponse `json:"cast,omitempty"`
+
+	// Version is sent as ETag header instead of in the body
+	Version int `json:"-"`
 }
 
 type MovieResponseDB struct {
@@ -28,6 +53,8 @@ type MovieResponseDB struct {
 	Description string    `db:"description"`
 	Rating      float32   `db:"rating"`
 	Image       string    `db:"image"`
+	Runtime     int       `db:"runtime"`
+	Version     int       `db:"version"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
@@ -35,13 +62,30 @@ type MovieResponseDB struct {
 type GetListResponse struct {
 	Data       []*MovieResponse
 	Pagination *frsUtils.Pagination
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 type MovieRequest struct {
-	Title       string  `json:"title" validate:"required"`
-	Description string  `json:"description"`
-	Rating      float32 `json:"rating" validate:"required"`
-	Image       string  `json:"image"`
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description"`
+	// Rating is a pointer so required mean the rating is sent and 0 is a valid rating
+	Rating *float32 `json:"rating" validate:"required,gte=0,lte=10"`
+	Image  string   `json:"image"`
+	// Runtime is film duration in minutes
+	Runtime int `json:"runtime" validate:"gte=0,lte=1440"`
+	// GenreIDs replace linked genre when it is not null, empty list unlink every genre.
+	// Replace unlink every genre when it is null
+	GenreIDs []int64 `json:"genre_ids" validate:"omitempty,dive,gt=0"`
+
+	// IfMatch is taken from If-Match header on replace
+	IfMatch ETagMatch `json:"-"`
+}
+
+// NormalizeTitle lowercase the title and collapse its unicode whitespace, title is
+// unique after normalization, see movies_normalized_title_key index
+func NormalizeTitle(title string) string {
+	return strings.Join(strings.Fields(strings.ToLower(title)), " ")
 }
 
 func BuildAndValidateMovieRequest(r *http.Request) (MovieRequest, error) {
@@ -58,7 +102,98 @@ func BuildAndValidateMovieRequest(r *http.Request) (MovieRequest, error) {
 		return payload, err
 	}
 
-	payload.Title = strings.ToLower(payload.Title)
+	if err := validateMovieRequest(validator.New(), &payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// validateMovieRequest normalize the title then validate the request,
+// import validate every row with it too
+func validateMovieRequest(v *validator.Validate, payload *MovieRequest) error {
+	payload.Title = NormalizeTitle(payload.Title)
+	return v.Struct(payload)
+}
+
+// MoviePatchRequest is RFC 7396 merge patch of a movie, nil field is not in the
+// patch and null clear the field to its zero value, e.g. null genre_ids unlink every genre
+type MoviePatchRequest struct {
+	Title       *string  `validate:"omitnil,min=1"`
+	Description *string  `validate:"omitnil"`
+	Rating      *float32 `validate:"omitnil,gte=0,lte=10"`
+	Image       *string  `validate:"omitnil"`
+	Runtime     *int     `validate:"omitnil,gte=0,lte=1440"`
+	GenreIDs    []int64  `validate:"omitempty,dive,gt=0"`
+
+	// IfMatch is taken from If-Match header
+	IfMatch ETagMatch
+}
+
+// BuildAndValidateMoviePatchRequest accept merge patch body sent as
+// application/merge-patch+json or application/json
+func BuildAndValidateMoviePatchRequest(r *http.Request) (MoviePatchRequest, error) {
+	var payload MoviePatchRequest
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != MergePatchContentType && mediaType != "application/json") {
+			log.Println("validate content type err: ", ErrUnsupportedContentType, contentType)
+			return payload, ErrUnsupportedContentType
+		}
+	}
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	// patch other than an object would replace the whole movie
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(bodyByte, &patch); err != nil || patch == nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, ErrInvalidMergePatch
+	}
+
+	return buildMoviePatchRequest(patch)
+}
+
+// buildMoviePatchRequest decode and validate member of merge patch object,
+// bulk update build the field of every update operation with it too
+func buildMoviePatchRequest(patch map[string]json.RawMessage) (payload MoviePatchRequest, err error) {
+	if payload.Title, err = patchField[string](patch, "title"); err != nil {
+		return payload, err
+	}
+	if payload.Description, err = patchField[string](patch, "description"); err != nil {
+		return payload, err
+	}
+	if payload.Rating, err = patchField[float32](patch, "rating"); err != nil {
+		return payload, err
+	}
+	if payload.Image, err = patchField[string](patch, "image"); err != nil {
+		return payload, err
+	}
+	if payload.Runtime, err = patchField[int](patch, "runtime"); err != nil {
+		return payload, err
+	}
+
+	genreIDs, err := patchField[[]int64](patch, "genre_ids")
+	if err != nil {
+		return payload, err
+	}
+	if genreIDs != nil {
+		payload.GenreIDs = *genreIDs
+		if payload.GenreIDs == nil {
+			payload.GenreIDs = []int64{}
+		}
+	}
+
+	if payload.Title != nil {
+		title := NormalizeTitle(*payload.Title)
+		payload.Title = &title
+	}
 
 	validator := validator.New()
 
@@ -69,3 +204,41 @@ func BuildAndValidateMovieRequest(r *http.Request) (MovieRequest, error) {
 
 	return payload, nil
 }
+
+// patchField decode a member of merge patch, it return nil when the member
+// is not in the patch and zero value when it is null
+func patchField[T any](patch map[string]json.RawMessage, name string) (*T, error) {
+	raw, ok := patch[name]
+	if !ok {
+		return nil, nil
+	}
+
+	value := new(T)
+	if string(raw) == "null" {
+		return value, nil
+	}
+
+	if err := json.Unmarshal(raw, value); err != nil {
+		log.Println("unmarshal merge patch err: ", name, err)
+		return nil, ErrInvalidMergePatch
+	}
+
+	return value, nil
+}
+
+// ValidateHardDeleteParam read hard query parameter of delete request,
+// movie is soft deleted when it is empty
+func ValidateHardDeleteParam(r *http.Request) (hard bool, err error) {
+	hardQuery := r.URL.Query().Get("hard")
+	if hardQuery == "" {
+		return false, nil
+	}
+
+	hard, err = strconv.ParseBool(hardQuery)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	return hard, nil
+}
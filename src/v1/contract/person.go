@@ -0,0 +1,67 @@
+package contract
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+type PersonResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Biography string `json:"biography"`
+	Image     string `json:"image"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type GetListPersonResponse struct {
+	Data       []*PersonResponse
+	Pagination *frsUtils.Pagination
+}
+
+// PersonMovieResponse is a movie the person is credited on
+type PersonMovieResponse struct {
+	MovieID       int64  `json:"movie_id"`
+	MovieTitle    string `json:"movie_title"`
+	Role          string `json:"role"`
+	CharacterName string `json:"character_name"`
+	BillingOrder  int    `json:"billing_order"`
+}
+
+type PersonRequest struct {
+	Name      string `json:"name" validate:"required,max=255"`
+	Biography string `json:"biography"`
+	Image     string `json:"image" validate:"max=255"`
+}
+
+func BuildAndValidatePersonRequest(r *http.Request) (PersonRequest, error) {
+	var payload PersonRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
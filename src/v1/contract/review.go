@@ -0,0 +1,77 @@
+package contract
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+)
+
+type ReviewResponse struct {
+	ID        int    `json:"id"`
+	MovieID   int64  `json:"movie_id"`
+	UserID    string `json:"user_id"`
+	Score     int    `json:"score"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type GetListReviewResponse struct {
+	Data       []*ReviewResponse
+	Pagination *frsUtils.Pagination
+}
+
+// ReviewRequest is filled from request body, UserID is the subject of the token
+type ReviewRequest struct {
+	UserID  string `json:"-" validate:"required"`
+	Score   int    `json:"score" validate:"required,min=1,max=10"`
+	Content string `json:"content"`
+}
+
+func BuildAndValidateReviewRequest(r *http.Request) (ReviewRequest, error) {
+	var payload ReviewRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	payload.UserID = auth.GetSubject(r.Context())
+	payload.Content = strings.TrimSpace(payload.Content)
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+func ValidateReviewIDParamRequest(r *http.Request) (id int, err error) {
+	idParam := chi.URLParam(r, "review_id")
+
+	id, err = strconv.Atoi(idParam)
+	if err != nil {
+		log.Println(err)
+		return id, err
+	}
+
+	return id, nil
+}
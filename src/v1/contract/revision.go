@@ -0,0 +1,64 @@
+package contract
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type MovieSnapshotResponse struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Rating      float32 `json:"rating"`
+	Image       string  `json:"image"`
+	Runtime     int     `json:"runtime"`
+	GenreIDs    []int64 `json:"genre_ids"`
+	Deleted     bool    `json:"deleted"`
+	Version     int     `json:"version"`
+}
+
+// FieldChangeResponse value is raw json of the field, From is null for create
+type FieldChangeResponse struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+type RevisionResponse struct {
+	Revision  int                            `json:"revision"`
+	MovieID   int64                          `json:"movie_id"`
+	Action    string                         `json:"action"`
+	Actor     string                         `json:"actor"`
+	RequestID string                         `json:"request_id"`
+	Before    *MovieSnapshotResponse         `json:"before"`
+	After     MovieSnapshotResponse          `json:"after"`
+	Diff      map[string]FieldChangeResponse `json:"diff"`
+	RevertOf  *int                           `json:"revert_of,omitempty"`
+	CreatedAt string                         `json:"created_at"`
+}
+
+type GetListRevisionResponse struct {
+	Data       []*RevisionResponse
+	Pagination *frsUtils.Pagination
+}
+
+func ValidateRevisionParamRequest(r *http.Request) (revision int, err error) {
+	revisionParam := chi.URLParam(r, "revision")
+
+	revision, err = strconv.Atoi(revisionParam)
+	if err != nil {
+		log.Println(err)
+		return revision, err
+	}
+
+	if revision <= 0 {
+		err = strconv.ErrRange
+		log.Println(err)
+		return revision, err
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,92 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrInvalidRoleName = errors.New("role name must start with a letter and only have lowercase letter, digit, - or _")
+
+	roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	BuiltIn     bool     `json:"built_in"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// RoleRequest replace description and permissions of the role, name is read from
+// the body when the role is created and from the path when it is updated
+type RoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"dive,oneof=movie:create movie:update movie:delete movie:purge import:run role:manage movie:audit catalog:manage cinema:manage apikey:manage"`
+}
+
+func ValidateRoleNameParamRequest(r *http.Request) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(chi.URLParam(r, "name")))
+	if !roleNamePattern.MatchString(name) {
+		log.Println(ErrInvalidRoleName)
+		return name, ErrInvalidRoleName
+	}
+
+	return name, nil
+}
+
+// BuildAndValidateRoleRequest lowercase the name and remove duplicate permission
+func BuildAndValidateRoleRequest(r *http.Request) (RoleRequest, error) {
+	var payload RoleRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	if name := chi.URLParam(r, "name"); name != "" {
+		payload.Name = name
+	}
+
+	payload.Name = strings.ToLower(strings.TrimSpace(payload.Name))
+	payload.Description = strings.TrimSpace(payload.Description)
+
+	if err := validator.New().Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	if !roleNamePattern.MatchString(payload.Name) {
+		log.Println("validate request body err: ", ErrInvalidRoleName)
+		return payload, ErrInvalidRoleName
+	}
+
+	permissions := make([]string, 0, len(payload.Permissions))
+	sort.Strings(payload.Permissions)
+	for i, permission := range payload.Permissions {
+		if i == 0 || permission != payload.Permissions[i-1] {
+			permissions = append(permissions, permission)
+		}
+	}
+	payload.Permissions = permissions
+
+	return payload, nil
+}
@@ -0,0 +1,156 @@
+package contract
+
+import (
+	"errors"
+	"html"
+	"net/http"
+	"strings"
+	"unicode"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+)
+
+const (
+	DefaultSearchLanguage = "simple"
+
+	// SearchHighlightStart and SearchHighlightStop wrap matched word in the highlight from
+	// the database, they are replaced by <mark> after the text is html escaped
+	SearchHighlightStart = "\x02"
+	SearchHighlightStop  = "\x03"
+)
+
+var (
+	ErrInvalidSearchQuery    = errors.New("q must have at least one word")
+	ErrInvalidSearchLanguage = errors.New("lang must be one of en, id, simple")
+
+	// SearchConfigs map lang query parameter to postgres text search configuration,
+	// simple does not stem word so it match the exact word of any language
+	SearchConfigs = map[string]string{
+		"en":     "english",
+		"id":     "indonesian",
+		"simple": "simple",
+	}
+
+	highlightReplacer = strings.NewReplacer(SearchHighlightStart, "<mark>", SearchHighlightStop, "</mark>")
+)
+
+type SearchParam struct {
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	// Query is to_tsquery input built by BuildSearchQuery and Config is its text search configuration
+	Query  string `json:"query"`
+	Config string `json:"config"`
+}
+
+type SearchHighlightResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type SearchMovieResponse struct {
+	MovieResponse
+	Rank      float32                 `json:"rank"`
+	Highlight SearchHighlightResponse `json:"highlight"`
+}
+
+type GetListSearchResponse struct {
+	Data       []*SearchMovieResponse
+	Pagination *frsUtils.Pagination
+}
+
+// ValidateAndBuildSearchRequest read q, lang, page and limit, lang default to simple
+func ValidateAndBuildSearchRequest(r *http.Request) (param SearchParam, err error) {
+	listParam, err := ValidateAndBuildRequest(r)
+	if err != nil {
+		return param, err
+	}
+
+	queryParams := r.URL.Query()
+
+	lang := strings.ToLower(strings.TrimSpace(queryParams.Get("lang")))
+	if lang == "" {
+		lang = DefaultSearchLanguage
+	}
+
+	config, ok := SearchConfigs[lang]
+	if !ok {
+		return param, ErrInvalidSearchLanguage
+	}
+
+	query := BuildSearchQuery(queryParams.Get("q"))
+	if query == "" {
+		return param, ErrInvalidSearchQuery
+	}
+
+	param = SearchParam{
+		Page:   listParam.Page,
+		Limit:  listParam.Limit,
+		Offset: listParam.Offset,
+		Query:  query,
+		Config: config,
+	}
+
+	return param, nil
+}
+
+// BuildSearchQuery convert search text to to_tsquery input, every term must match.
+// Quoted text is a phrase, word ending with * is a prefix and word joined by punctuation,
+// e.g. spider-man, is a phrase of its part. Only letter and digit is kept so the result
+// is always a valid tsquery, it is empty when the text has no word
+func BuildSearchQuery(q string) string {
+	var terms []string
+
+	for i, segment := range strings.Split(q, `"`) {
+		// odd segment is between quote, unclosed quote is a phrase until the end
+		if i%2 == 1 {
+			if term := searchPhrase(searchTokens(segment), false); term != "" {
+				terms = append(terms, term)
+			}
+			continue
+		}
+
+		for _, word := range strings.Fields(segment) {
+			prefix := strings.HasSuffix(word, "*")
+			if term := searchPhrase(searchTokens(word), prefix); term != "" {
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+func searchTokens(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchPhrase join tokens with followed by operator, prefix match the last token
+func searchPhrase(tokens []string, prefix bool) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(tokens))
+	for i, token := range tokens {
+		quoted[i] = "'" + token + "'"
+	}
+
+	if prefix {
+		quoted[len(quoted)-1] += ":*"
+	}
+
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+
+	return "(" + strings.Join(quoted, " <-> ") + ")"
+}
+
+// FormatSearchHighlight html escape highlight from the database and mark the matched word with <mark>
+func FormatSearchHighlight(text string) string {
+	return highlightReplacer.Replace(html.EscapeString(text))
+}
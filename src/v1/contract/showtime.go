@@ -0,0 +1,92 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const ShowtimeDateLayout = "2006-01-02"
+
+var ErrInvalidShowtimeDate = errors.New("invalid showtime date")
+
+type ShowtimeResponse struct {
+	ID         int    `json:"id"`
+	MovieID    int64  `json:"movie_id"`
+	MovieTitle string `json:"movie_title"`
+	ScreenID   int64  `json:"screen_id"`
+	ScreenName string `json:"screen_name"`
+	CinemaID   int64  `json:"cinema_id"`
+	CinemaName string `json:"cinema_name"`
+	City       string `json:"city"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	Price      int64  `json:"price"`
+}
+
+// ShowtimeRequest end time is computed from start time and movie runtime
+type ShowtimeRequest struct {
+	MovieID   int64     `json:"movie_id" validate:"required,gt=0"`
+	ScreenID  int64     `json:"screen_id" validate:"required,gt=0"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	Price     int64     `json:"price" validate:"gte=0"`
+}
+
+// ShowtimeFilter select showtime starting in [From, To), City is optional
+type ShowtimeFilter struct {
+	From time.Time
+	To   time.Time
+	City string
+}
+
+func BuildAndValidateShowtimeRequest(r *http.Request) (ShowtimeRequest, error) {
+	var payload ShowtimeRequest
+
+	bodyByte, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println("read request body err: ", err)
+		return payload, err
+	}
+
+	if err := json.Unmarshal(bodyByte, &payload); err != nil {
+		log.Println("unmarshal request body err: ", err)
+		return payload, err
+	}
+
+	validator := validator.New()
+
+	if err := validator.Struct(payload); err != nil {
+		log.Println("validate request body err: ", err)
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// ValidateAndBuildShowtimeFilter read date=2006-01-02 in server time zone,
+// today when it is empty, and optional city
+func ValidateAndBuildShowtimeFilter(r *http.Request) (filter ShowtimeFilter, err error) {
+	queryParams := r.URL.Query()
+
+	now := time.Now()
+	filter.From = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	if date := queryParams.Get("date"); date != "" {
+		filter.From, err = time.ParseInLocation(ShowtimeDateLayout, date, time.Local)
+		if err != nil {
+			log.Println(err)
+			return filter, ErrInvalidShowtimeDate
+		}
+	}
+
+	filter.To = filter.From.AddDate(0, 0, 1)
+	filter.City = strings.TrimSpace(queryParams.Get("city"))
+
+	return filter, nil
+}
@@ -0,0 +1,50 @@
+package contract
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const (
+	DefaultSuggestLimit = 10
+	MaxSuggestLimit     = 20
+)
+
+var ErrInvalidSuggestRequest = errors.New("q is required and limit must be between 1 and 20")
+
+type SuggestParam struct {
+	// Query is normalized like the title, see NormalizeTitle
+	Query string `json:"query"`
+	Limit int    `json:"limit"`
+}
+
+type SuggestResponse struct {
+	ID     int     `json:"id"`
+	Title  string  `json:"title"`
+	Image  string  `json:"image"`
+	Rating float32 `json:"rating"`
+	Score  float32 `json:"score"`
+}
+
+// ValidateAndBuildSuggestRequest read q and limit, limit default to 10
+func ValidateAndBuildSuggestRequest(r *http.Request) (param SuggestParam, err error) {
+	queryParams := r.URL.Query()
+
+	param = SuggestParam{
+		Query: NormalizeTitle(queryParams.Get("q")),
+		Limit: DefaultSuggestLimit,
+	}
+
+	if limitQuery := queryParams.Get("limit"); limitQuery != "" {
+		if param.Limit, err = strconv.Atoi(limitQuery); err != nil {
+			return param, ErrInvalidSuggestRequest
+		}
+	}
+
+	if param.Query == "" || param.Limit < 1 || param.Limit > MaxSuggestLimit {
+		return param, ErrInvalidSuggestRequest
+	}
+
+	return param, nil
+}
@@ -3,51 +3,206 @@ package v1
 import (
 	"context"
 	"log"
+	"net/http"
 
 	"github.com/Risuii/movie/src/app"
 
+	atomicSqlx "github.com/Risuii/frs-lib/atomic/sqlx"
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/Risuii/movie/src/middleware/idempotency"
+	"github.com/Risuii/movie/src/middleware/ratelimit"
+	apiKeyRepo "github.com/Risuii/movie/src/repository/apikey"
+	bookingRepo "github.com/Risuii/movie/src/repository/booking"
+	cinemaRepo "github.com/Risuii/movie/src/repository/cinema"
+	genreRepo "github.com/Risuii/movie/src/repository/genre"
+	holdRepo "github.com/Risuii/movie/src/repository/hold"
 	movieRepo "github.com/Risuii/movie/src/repository/movie"
+	personRepo "github.com/Risuii/movie/src/repository/person"
+	reviewRepo "github.com/Risuii/movie/src/repository/review"
+	roleRepo "github.com/Risuii/movie/src/repository/role"
+	showtimeRepo "github.com/Risuii/movie/src/repository/showtime"
+	storageRepo "github.com/Risuii/movie/src/repository/storage"
+	apiKeySvc "github.com/Risuii/movie/src/v1/service/apikey"
+	bookingSvc "github.com/Risuii/movie/src/v1/service/booking"
+	cinemaSvc "github.com/Risuii/movie/src/v1/service/cinema"
+	genreSvc "github.com/Risuii/movie/src/v1/service/genre"
 	movieSvc "github.com/Risuii/movie/src/v1/service/movie"
+	personSvc "github.com/Risuii/movie/src/v1/service/person"
+	reviewSvc "github.com/Risuii/movie/src/v1/service/review"
+	roleSvc "github.com/Risuii/movie/src/v1/service/role"
+	showtimeSvc "github.com/Risuii/movie/src/v1/service/showtime"
 )
 
 type repositories struct {
-	mRepo *movieRepo.MoviesRepository
+	atomic *atomicSqlx.SqlxAtomicSessionProvider
+	mRepo  *movieRepo.MoviesRepository
+	gRepo  *genreRepo.GenresRepository
+	pRepo  *personRepo.PeopleRepository
+	rRepo  *reviewRepo.ReviewsRepository
+	cRepo  *cinemaRepo.CinemasRepository
+	sRepo  *showtimeRepo.ShowtimesRepository
+	bRepo  *bookingRepo.BookingsRepository
+	hRepo  *holdRepo.HoldsRepository
+	roRepo *roleRepo.RolesRepository
+	aRepo  *apiKeyRepo.APIKeysRepository
+	store  *storageRepo.LocalStorage
 }
 
 type services struct {
-	mSvc *movieSvc.MovieService
+	mSvc  *movieSvc.MovieService
+	gSvc  *genreSvc.GenreService
+	pSvc  *personSvc.PersonService
+	rSvc  *reviewSvc.ReviewService
+	cSvc  *cinemaSvc.CinemaService
+	sSvc  *showtimeSvc.ShowtimeService
+	bSvc  *bookingSvc.BookingService
+	roSvc *roleSvc.RoleService
+	aSvc  *apiKeySvc.APIKeyService
 }
 
+// middlewares is route middleware that routes opt in to
+type middlewares struct {
+	idempotency   func(http.Handler) http.Handler
+	authenticated func(http.Handler) http.Handler
+	authorize     func(permission string) func(http.Handler) http.Handler
+	apiKey        func(http.Handler) http.Handler
+	rateLimit     func(group string) func(http.Handler) http.Handler
+}
+
+// rate limit group of the routes, each group count request of an identity separately
+const (
+	rateLimitMovies  = "movies"
+	rateLimitCatalog = "catalog"
+	rateLimitCinemas = "cinemas"
+	rateLimitBooking = "booking"
+	rateLimitAdmin   = "admin"
+)
+
 type Dependency struct {
 	Repositories *repositories
 	Services     *services
+	Middlewares  *middlewares
 }
 
 func initRepositories(ctx context.Context) *repositories {
 	var r repositories
 	var err error
 
+	r.atomic = atomicSqlx.NewSqlxAtomicSessionProvider(app.DB())
+
 	r.mRepo, err = movieRepo.InitMoviesRepository(ctx, app.DB(), app.Cache())
 	if err != nil {
 		log.Fatal("init movie repo err: ", err)
 	}
 
+	r.gRepo, err = genreRepo.InitGenresRepository(ctx, app.DB(), app.Cache())
+	if err != nil {
+		log.Fatal("init genre repo err: ", err)
+	}
+
+	r.pRepo, err = personRepo.InitPeopleRepository(ctx, app.DB(), app.Cache())
+	if err != nil {
+		log.Fatal("init person repo err: ", err)
+	}
+
+	r.rRepo, err = reviewRepo.InitReviewsRepository(ctx, app.DB(), app.Cache())
+	if err != nil {
+		log.Fatal("init review repo err: ", err)
+	}
+
+	r.cRepo, err = cinemaRepo.InitCinemasRepository(ctx, app.DB(), app.Cache())
+	if err != nil {
+		log.Fatal("init cinema repo err: ", err)
+	}
+
+	r.sRepo, err = showtimeRepo.InitShowtimesRepository(ctx, app.DB())
+	if err != nil {
+		log.Fatal("init showtime repo err: ", err)
+	}
+
+	r.bRepo, err = bookingRepo.InitBookingsRepository(ctx, app.DB())
+	if err != nil {
+		log.Fatal("init booking repo err: ", err)
+	}
+
+	r.hRepo, err = holdRepo.InitHoldsRepository(ctx, app.Cache())
+	if err != nil {
+		log.Fatal("init hold repo err: ", err)
+	}
+
+	r.roRepo, err = roleRepo.InitRolesRepository(ctx, app.DB(), app.Cache())
+	if err != nil {
+		log.Fatal("init role repo err: ", err)
+	}
+
+	r.aRepo, err = apiKeyRepo.InitAPIKeysRepository(ctx, app.DB(), app.Cache())
+	if err != nil {
+		log.Fatal("init api key repo err: ", err)
+	}
+
+	r.store, err = storageRepo.InitLocalStorage(ctx, app.Config().Storage.LocalDir, app.Config().Storage.BaseURL)
+	if err != nil {
+		log.Fatal("init storage err: ", err)
+	}
+
 	return &r
 }
 
 func initServices(ctx context.Context, r *repositories) *services {
 
 	return &services{
-		mSvc: movieSvc.InitMovieService(r.mRepo),
+		mSvc:  movieSvc.InitMovieService(r.mRepo, r.store, r.atomic, app.Config().Movie.RequireIfMatch),
+		gSvc:  genreSvc.InitGenreService(r.gRepo),
+		pSvc:  personSvc.InitPersonService(r.pRepo),
+		rSvc:  reviewSvc.InitReviewService(r.rRepo, r.mRepo, r.atomic),
+		cSvc:  cinemaSvc.InitCinemaService(r.cRepo),
+		sSvc:  showtimeSvc.InitShowtimeService(r.sRepo, r.mRepo, r.cRepo),
+		bSvc:  bookingSvc.InitBookingService(r.bRepo, r.hRepo, r.sRepo, r.cRepo, r.atomic, app.Config().Booking.SeatHoldDuration),
+		roSvc: roleSvc.InitRoleService(r.roRepo, r.atomic),
+		aSvc:  apiKeySvc.InitAPIKeyService(r.aRepo),
+	}
+}
+
+func initMiddlewares(ctx context.Context, s *services) *middlewares {
+
+	idempotent, err := idempotency.Middleware(app.Cache(), app.Config().Idempotency.KeyTTL)
+	if err != nil {
+		log.Fatal("init idempotency err: ", err)
+	}
+
+	rateLimitCfg := app.Config().RateLimit
+	limiter, err := ratelimit.InitLimiter(app.Cache(), ratelimit.Options{
+		Disabled: rateLimitCfg.Disabled,
+		Default:  rateLimitCfg.Default,
+		Groups: map[string]string{
+			rateLimitMovies:  rateLimitCfg.Movies,
+			rateLimitCatalog: rateLimitCfg.Catalog,
+			rateLimitCinemas: rateLimitCfg.Cinemas,
+			rateLimitBooking: rateLimitCfg.Booking,
+			rateLimitAdmin:   rateLimitCfg.Admin,
+		},
+	})
+	if err != nil {
+		log.Fatal("init rate limiter err: ", err)
+	}
+
+	return &middlewares{
+		idempotency:   idempotent,
+		authenticated: auth.RequireAuth,
+		authorize:     auth.InitAuthorizer(s.roSvc).Authorize,
+		apiKey:        auth.APIKeyContext(s.aSvc),
+		rateLimit:     limiter.Limit,
 	}
 }
 
 func Dependencies(ctx context.Context) *Dependency {
 	repositories := initRepositories(ctx)
 	services := initServices(ctx, repositories)
+	middlewares := initMiddlewares(ctx, services)
 
 	return &Dependency{
 		Repositories: repositories,
 		Services:     services,
+		Middlewares:  middlewares,
 	}
 }
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/errors"
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+func GetAPIKeyHandler(svc APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.Get(r.Context(), id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrAPIKeyNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func GetListAPIKeyHandler(svc APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := svc.GetList(r.Context())
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+// IssueAPIKeyHandler respond the plain key once, only its hash is stored
+func IssueAPIKeyHandler(svc APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request, err := contract.BuildAndValidateAPIKeyRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.Issue(r.Context(), request)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+func RotateAPIKeyHandler(svc APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.Rotate(r.Context(), id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrAPIKeyNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			case errors.ErrAPIKeyRevoked:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+func RevokeAPIKeyHandler(svc APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		err = svc.Revoke(r.Context(), id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrAPIKeyNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, "success revoke api key")
+	}
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestIssueAPIKeyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeySvc := mock_handler.NewMockAPIKeyService(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error unknown scope",
+			body:       `{"name":"sync","owner":"partner","scopes":["role:manage"],"daily_quota":100}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error expires in the past",
+			body:       `{"name":"sync","owner":"partner","scopes":["movie:create"],"daily_quota":100,"expires_at":"2020-01-01T00:00:00Z"}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error internal server",
+			body: `{"name":"sync","owner":"partner","scopes":["movie:create"],"daily_quota":100}`,
+			mockFunc: func() {
+				mockAPIKeySvc.EXPECT().Issue(gomock.Any(), gomock.Any()).Return(contract.IssuedAPIKeyResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			body: `{"name":" sync ","owner":"partner","scopes":["movie:create"],"daily_quota":100}`,
+			mockFunc: func() {
+				mockAPIKeySvc.EXPECT().Issue(gomock.Any(), contract.APIKeyRequest{
+					Name:       "sync",
+					Owner:      "partner",
+					Scopes:     []string{"movie:create"},
+					DailyQuota: 100,
+				}).Return(contract.IssuedAPIKeyResponse{Key: "mk_0000000000000000_secret"}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(IssueAPIKeyHandler(mockAPIKeySvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestRotateAPIKeyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeySvc := mock_handler.NewMockAPIKeyService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  map[string]string{"id": "abc"},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error api key not found",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockAPIKeySvc.EXPECT().Rotate(gomock.Any(), 1).Return(contract.IssuedAPIKeyResponse{}, appErr.ErrAPIKeyNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "error api key revoked",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockAPIKeySvc.EXPECT().Rotate(gomock.Any(), 1).Return(contract.IssuedAPIKeyResponse{}, appErr.ErrAPIKeyRevoked).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockAPIKeySvc.EXPECT().Rotate(gomock.Any(), 1).Return(contract.IssuedAPIKeyResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(RotateAPIKeyHandler(mockAPIKeySvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestRevokeAPIKeyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeySvc := mock_handler.NewMockAPIKeyService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  nil,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error api key not found",
+			parameter: map[string]string{"id": "2"},
+			mockFunc: func() {
+				mockAPIKeySvc.EXPECT().Revoke(gomock.Any(), 2).Return(appErr.ErrAPIKeyNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": "2"},
+			mockFunc: func() {
+				mockAPIKeySvc.EXPECT().Revoke(gomock.Any(), 2).Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodDelete, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(RevokeAPIKeyHandler(mockAPIKeySvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/errors"
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+func CreateBookingHandler(svc BookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bookingRequest, err := contract.BuildAndValidateBookingRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.Confirm(r.Context(), bookingRequest)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrHoldIdNotFound, errors.ErrShowtimeIdNotFound, errors.ErrShowtimeStarted:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			case errors.ErrSeatTaken:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+func GetBookingHandler(svc BookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := auth.GetSubject(r.Context())
+		if userID == "" {
+			response.JSONUnauthorizedResponse(r.Context(), w)
+			return
+		}
+
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.GetBooking(r.Context(), id, userID)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrBookingIdNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func GetListBookingHandler(svc BookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := auth.GetSubject(r.Context())
+		if userID == "" {
+			response.JSONUnauthorizedResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.GetBookings(r.Context(), userID)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestCreateBookingHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingSvc := mock_handler.NewMockBookingService(ctrl)
+
+	mockRequest := contract.BookingRequest{UserID: "user-1", HoldID: "6a1f8c2e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"}
+
+	tests := []struct {
+		name       string
+		params     contract.BookingRequest
+		mockFunc   func(params contract.BookingRequest)
+		statusCode int
+	}{
+		{
+			name:       "error invalid hold id",
+			params:     contract.BookingRequest{HoldID: "abc"},
+			mockFunc:   func(params contract.BookingRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "error hold id not found",
+			params: mockRequest,
+			mockFunc: func(params contract.BookingRequest) {
+				mockBookingSvc.EXPECT().Confirm(gomock.Any(), params).Return(contract.BookingResponse{}, appErr.ErrHoldIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "error showtime started",
+			params: mockRequest,
+			mockFunc: func(params contract.BookingRequest) {
+				mockBookingSvc.EXPECT().Confirm(gomock.Any(), params).Return(contract.BookingResponse{}, appErr.ErrShowtimeStarted).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "error seat taken",
+			params: mockRequest,
+			mockFunc: func(params contract.BookingRequest) {
+				mockBookingSvc.EXPECT().Confirm(gomock.Any(), params).Return(contract.BookingResponse{}, appErr.ErrSeatTaken).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:   "error internal server",
+			params: mockRequest,
+			mockFunc: func(params contract.BookingRequest) {
+				mockBookingSvc.EXPECT().Confirm(gomock.Any(), params).Return(contract.BookingResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			mockFunc: func(params contract.BookingRequest) {
+				mockBookingSvc.EXPECT().Confirm(gomock.Any(), params).Return(contract.BookingResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = withSubject(req, "user-1")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreateBookingHandler(mockBookingSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGetBookingHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingSvc := mock_handler.NewMockBookingService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		userID     string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error anonymous",
+			parameter:  map[string]string{"id": "5"},
+			mockFunc:   func() {},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "error bad request",
+			parameter:  map[string]string{"id": "abc"},
+			userID:     "user-1",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error booking id not found",
+			parameter: map[string]string{"id": "5"},
+			userID:    "user-1",
+			mockFunc: func() {
+				mockBookingSvc.EXPECT().GetBooking(gomock.Any(), 5, "user-1").Return(contract.BookingResponse{}, appErr.ErrBookingIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": "5"},
+			userID:    "user-1",
+			mockFunc: func() {
+				mockBookingSvc.EXPECT().GetBooking(gomock.Any(), 5, "user-1").Return(contract.BookingResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = withSubject(req, tt.userID)
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetBookingHandler(mockBookingSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// BulkMovieHandler respond 200 with result of every id, failed or rolled back
+// item is reported in the result instead of the status code
+func BulkMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request, err := contract.BuildAndValidateBulkRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.Bulk(r.Context(), request)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
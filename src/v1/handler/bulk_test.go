@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestBulkMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error invalid mode",
+			body:       `{"mode": "sometimes", "operations": [{"action": "delete", "ids": [1]}]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error unknown action",
+			body:       `{"operations": [{"action": "purge", "ids": [1]}]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error update without fields",
+			body:       `{"operations": [{"action": "update", "ids": [1]}]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error delete with fields",
+			body:       `{"operations": [{"action": "delete", "ids": [1], "fields": {"rating": 1}}]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error invalid fields",
+			body:       `{"operations": [{"action": "update", "ids": [1], "fields": {"rating": -1}}]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error too many ids",
+			body: `{"operations": [{"action": "delete", "ids": [` + strings.Repeat("1,", 250) + `1]}, ` +
+				`{"action": "restore", "ids": [` + strings.Repeat("1,", 249) + `1]}]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error internal server",
+			body: `{"operations": [{"action": "delete", "ids": [1]}]}`,
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Bulk(gomock.Any(), gomock.Any()).Return(contract.BulkResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			body: `{"mode": "best_effort", "operations": [{"action": "update", "ids": [1, 2], "fields": {"rating": 8, "description": null}}, {"action": "restore", "ids": [3]}]}`,
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Bulk(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, request contract.BulkRequest) (contract.BulkResponse, error) {
+					assert.Equal(t, contract.BulkModeBestEffort, request.Mode)
+					assert.Len(t, request.Operations, 2)
+
+					patch := request.Operations[0].Patch
+					if assert.NotNil(t, patch.Rating) && assert.NotNil(t, patch.Description) {
+						assert.Equal(t, float32(8), *patch.Rating)
+						assert.Equal(t, "", *patch.Description)
+					}
+					assert.Nil(t, patch.Title)
+
+					return contract.BulkResponse{Mode: request.Mode, Succeeded: 3}, nil
+				}).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(BulkMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// exportResponseWriter remember whether any byte reached the client,
+// the error can only be answered as json before that
+type exportResponseWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (ew *exportResponseWriter) Write(p []byte) (int, error) {
+	ew.written = true
+	return ew.w.Write(p)
+}
+
+// ExportMovieHandler stream the filtered catalog as csv, ndjson or xlsx, row is flushed
+// to the client every contract.ExportFlushRows row, an error after the first flush
+// can not change the status anymore so the client receive a truncated file, it is told
+// by the missing end marker of csv and ndjson or the missing end of the xlsx zip.
+// The route has its own deadline instead of the request timeout, see Router
+func ExportMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request, err := contract.ValidateAndBuildExportRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		out := &exportResponseWriter{w: w}
+		rc := http.NewResponseController(w)
+
+		w.Header().Set("Content-Type", contract.ExportContentTypes[request.Format])
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="movies.%s"`, request.Format))
+
+		writer, err := contract.NewExportWriter(request.Format, out)
+		if err == nil {
+			rows := 0
+			err = svc.Export(r.Context(), request.Params, func(m *contract.MovieResponse) error {
+				if err := writer.Write(m); err != nil {
+					return err
+				}
+
+				if rows++; rows%contract.ExportFlushRows == 0 {
+					if err := writer.Flush(); err != nil {
+						return err
+					}
+					rc.Flush()
+				}
+
+				return nil
+			})
+		}
+
+		if err == nil {
+			err = writer.Close()
+		}
+
+		if err != nil {
+			log.Println("export movie err: ", err)
+			if !out.written {
+				w.Header().Del("Content-Disposition")
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		rc.Flush()
+	}
+}
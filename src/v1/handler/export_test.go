@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestExportMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	exportMovies := func(_ interface{}, _ contract.GetListParam, fn func(m *contract.MovieResponse) error) error {
+		movies := []*contract.MovieResponse{
+			{ID: 1, Title: "Avengers, The", Rating: 9, Genres: []string{"action", "sci-fi"}},
+			{ID: 2, Title: "Tom & Jerry <3", Rating: 8.5, Runtime: 101},
+			{ID: 3, Title: "=HYPERLINK(1)", Description: "@SUM(1)", Rating: -1},
+		}
+		for _, m := range movies {
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		mockFunc    func()
+		statusCode  int
+		contentType string
+		check       func(t *testing.T, body []byte)
+	}{
+		{
+			name:       "error invalid format",
+			query:      "?format=pdf",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error invalid filter",
+			query:      "?rating_min=high",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "error internal server",
+			query: "?format=ndjson",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
+			},
+			statusCode:  http.StatusInternalServerError,
+			contentType: "application/json",
+		},
+		{
+			name:  "success csv with list filter",
+			query: "?keyword=avengers&rating_min=8&sort=title:desc&page=3&limit=1",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Export(gomock.Any(), contract.GetListParam{
+					Keyword:   "avengers",
+					RatingMin: stringPtr("8"),
+					Sort:      []contract.SortField{{Field: contract.SortFieldTitle, Desc: true}},
+				}, gomock.Any()).DoAndReturn(exportMovies).Times(1)
+			},
+			statusCode:  http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+				assert.Len(t, lines, 5)
+				assert.Equal(t, "id,title,description,rating,audience_rating,audience_rating_count,image,runtime,genres,created_at,updated_at", lines[0])
+				assert.Equal(t, `1,"Avengers, The",,9,0,0,,0,action|sci-fi,,`, lines[1])
+				assert.Equal(t, `3,'=HYPERLINK(1),'@SUM(1),-1,0,0,,0,,,`, lines[3])
+				assert.Equal(t, "#end,3", lines[4])
+			},
+		},
+		{
+			name:  "success ndjson",
+			query: "?format=ndjson",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(exportMovies).Times(1)
+			},
+			statusCode:  http.StatusOK,
+			contentType: "application/x-ndjson",
+			check: func(t *testing.T, body []byte) {
+				lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+				assert.Len(t, lines, 4)
+				assert.Contains(t, lines[1], `"title":"Tom & Jerry <3"`)
+				assert.Contains(t, lines[2], `"title":"=HYPERLINK(1)"`)
+				assert.Equal(t, `{"end":true,"rows":3}`, lines[3])
+			},
+		},
+		{
+			name:  "success xlsx",
+			query: "?format=XLSX",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(exportMovies).Times(1)
+			},
+			statusCode:  http.StatusOK,
+			contentType: contract.ExportContentTypes[contract.ExportFormatXLSX],
+			check: func(t *testing.T, body []byte) {
+				zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				var sheet []byte
+				for _, f := range zr.File {
+					if f.Name != "xl/worksheets/sheet1.xml" {
+						continue
+					}
+					rc, err := f.Open()
+					if !assert.NoError(t, err) {
+						return
+					}
+					sheet, _ = io.ReadAll(rc)
+					rc.Close()
+				}
+
+				assert.Len(t, zr.File, 5)
+				assert.Equal(t, 4, strings.Count(string(sheet), "<row>"))
+				assert.Contains(t, string(sheet), "&#39;=HYPERLINK(1)")
+				assert.Contains(t, string(sheet), "Tom &amp; Jerry &lt;3")
+				assert.Contains(t, string(sheet), "<c><v>8.5</v></c>")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(ExportMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, rr.Header().Get("Content-Type"))
+			}
+
+			if tt.check != nil {
+				tt.check(t, rr.Body.Bytes())
+			}
+		})
+	}
+}
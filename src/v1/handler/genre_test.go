@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestGetGenreHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreSvc := mock_handler.NewMockGenreService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request",
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+			mockFunc:   func() {},
+		},
+		{
+			name:       "error id not found",
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockGenreSvc.EXPECT().Get(gomock.Any(), 1).Return(contract.GenreResponse{}, appErr.ErrGenreIdNotFound).Times(1)
+			},
+		},
+		{
+			name:       "error internal server",
+			statusCode: http.StatusInternalServerError,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockGenreSvc.EXPECT().Get(gomock.Any(), 1).Return(contract.GenreResponse{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockGenreSvc.EXPECT().Get(gomock.Any(), 1).Return(contract.GenreResponse{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetGenreHandler(mockGenreSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGetListGenreHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreSvc := mock_handler.NewMockGenreService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error",
+			statusCode: http.StatusInternalServerError,
+			mockFunc: func() {
+				mockGenreSvc.EXPECT().GetList(gomock.Any()).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			mockFunc: func() {
+				mockGenreSvc.EXPECT().GetList(gomock.Any()).Return([]contract.GenreResponse{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetListGenreHandler(mockGenreSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestCreateGenreHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreSvc := mock_handler.NewMockGenreService(ctrl)
+
+	mockRequest := contract.GenreRequest{
+		Name: "horror",
+	}
+
+	tests := []struct {
+		name       string
+		params     contract.GenreRequest
+		mockFunc   func(params contract.GenreRequest)
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			params:     contract.GenreRequest{},
+			mockFunc:   func(params contract.GenreRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "error duplicate",
+			params: mockRequest,
+			mockFunc: func(params contract.GenreRequest) {
+				mockGenreSvc.EXPECT().Create(gomock.Any(), params).Return(contract.GenreResponse{}, appErr.ErrDuplicateGenre).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:   "error internal server",
+			params: mockRequest,
+			mockFunc: func(params contract.GenreRequest) {
+				mockGenreSvc.EXPECT().Create(gomock.Any(), params).Return(contract.GenreResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			mockFunc: func(params contract.GenreRequest) {
+				mockGenreSvc.EXPECT().Create(gomock.Any(), params).Return(contract.GenreResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreateGenreHandler(mockGenreSvc))
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestUpdateGenreHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreSvc := mock_handler.NewMockGenreService(ctrl)
+
+	mockRequest := contract.GenreRequest{
+		Name: "horror",
+	}
+
+	tests := []struct {
+		name       string
+		params     contract.GenreRequest
+		mockFunc   func(params contract.GenreRequest)
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request id",
+			params:     mockRequest,
+			mockFunc:   func(params contract.GenreRequest) {},
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+		},
+		{
+			name:       "error bad request payload",
+			params:     contract.GenreRequest{},
+			mockFunc:   func(params contract.GenreRequest) {},
+			statusCode: http.StatusBadRequest,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+		{
+			name:   "error id not found",
+			params: mockRequest,
+			mockFunc: func(params contract.GenreRequest) {
+				mockGenreSvc.EXPECT().Update(gomock.Any(), params, 1).Return(contract.GenreResponse{}, appErr.ErrGenreIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			mockFunc: func(params contract.GenreRequest) {
+				mockGenreSvc.EXPECT().Update(gomock.Any(), params, 1).Return(contract.GenreResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPatch, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(UpdateGenreHandler(mockGenreSvc))
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestDeleteGenreHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreSvc := mock_handler.NewMockGenreService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+		},
+		{
+			name: "error id not found",
+			mockFunc: func() {
+				mockGenreSvc.EXPECT().Delete(gomock.Any(), 1).Return(appErr.ErrGenreIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				mockGenreSvc.EXPECT().Delete(gomock.Any(), 1).Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodDelete, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(DeleteGenreHandler(mockGenreSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/errors"
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+func GetShowtimeSeatsHandler(svc BookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.GetSeats(r.Context(), id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrShowtimeIdNotFound, errors.ErrScreenIdNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func CreateHoldHandler(svc BookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		holdRequest, err := contract.BuildAndValidateHoldRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.CreateHold(r.Context(), holdRequest)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrShowtimeIdNotFound, errors.ErrScreenIdNotFound, errors.ErrShowtimeStarted, errors.ErrSeatNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			case errors.ErrSeatTaken:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+func GetHoldHandler(svc BookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := auth.GetSubject(r.Context())
+		if userID == "" {
+			response.JSONUnauthorizedResponse(r.Context(), w)
+			return
+		}
+
+		id, err := contract.ValidateHoldIDParamRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.GetHold(r.Context(), id, userID)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrHoldIdNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func ReleaseHoldHandler(svc BookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := auth.GetSubject(r.Context())
+		if userID == "" {
+			response.JSONUnauthorizedResponse(r.Context(), w)
+			return
+		}
+
+		id, err := contract.ValidateHoldIDParamRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		err = svc.ReleaseHold(r.Context(), id, userID)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrHoldIdNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, "success release hold")
+	}
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestCreateHoldHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingSvc := mock_handler.NewMockBookingService(ctrl)
+
+	mockRequest := contract.HoldRequest{UserID: "user-1", ShowtimeID: 1, Seats: []string{"A1", "A2"}}
+
+	tests := []struct {
+		name       string
+		userID     string
+		params     contract.HoldRequest
+		mockFunc   func(params contract.HoldRequest)
+		statusCode int
+	}{
+		{
+			name:       "error missing user id",
+			params:     mockRequest,
+			mockFunc:   func(params contract.HoldRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error too many seats",
+			userID:     "user-1",
+			params:     contract.HoldRequest{ShowtimeID: 1, Seats: make([]string, 11)},
+			mockFunc:   func(params contract.HoldRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error duplicate seat",
+			userID:     "user-1",
+			params:     contract.HoldRequest{ShowtimeID: 1, Seats: []string{"A1", "a1"}},
+			mockFunc:   func(params contract.HoldRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "error seat not found",
+			userID: "user-1",
+			params: mockRequest,
+			mockFunc: func(params contract.HoldRequest) {
+				mockBookingSvc.EXPECT().CreateHold(gomock.Any(), params).Return(contract.HoldResponse{}, appErr.ErrSeatNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "error seat taken",
+			userID: "user-1",
+			params: mockRequest,
+			mockFunc: func(params contract.HoldRequest) {
+				mockBookingSvc.EXPECT().CreateHold(gomock.Any(), params).Return(contract.HoldResponse{}, appErr.ErrSeatTaken).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:   "error internal server",
+			userID: "user-1",
+			params: mockRequest,
+			mockFunc: func(params contract.HoldRequest) {
+				mockBookingSvc.EXPECT().CreateHold(gomock.Any(), params).Return(contract.HoldResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "success",
+			userID: "user-1",
+			params: mockRequest,
+			mockFunc: func(params contract.HoldRequest) {
+				mockBookingSvc.EXPECT().CreateHold(gomock.Any(), params).Return(contract.HoldResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = withSubject(req, tt.userID)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreateHoldHandler(mockBookingSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestReleaseHoldHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingSvc := mock_handler.NewMockBookingService(ctrl)
+
+	holdID := "6a1f8c2e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error invalid hold id",
+			parameter:  map[string]string{"id": "1"},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error hold id not found",
+			parameter: map[string]string{"id": holdID},
+			mockFunc: func() {
+				mockBookingSvc.EXPECT().ReleaseHold(gomock.Any(), holdID, "user-1").Return(appErr.ErrHoldIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": holdID},
+			mockFunc: func() {
+				mockBookingSvc.EXPECT().ReleaseHold(gomock.Any(), holdID, "user-1").Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodDelete, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = withSubject(req, "user-1")
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(ReleaseHoldHandler(mockBookingSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
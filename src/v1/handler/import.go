@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/errors"
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// ImportMovieHandler respond 202 with the pending job when the import run in background
+func ImportMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request, err := contract.BuildAndValidateImportRequest(w, r)
+		if err != nil {
+			switch err {
+			case contract.ErrImportTooLarge:
+				response.JSONError(r.Context(), w, http.StatusRequestEntityTooLarge, errors.ErrImportTooLarge)
+			case contract.ErrUnsupportedContentType:
+				response.JSONError(r.Context(), w, http.StatusUnsupportedMediaType, errors.ErrUnsupportedContentType)
+			default:
+				response.JSONBadRequestResponse(r.Context(), w)
+			}
+			return
+		}
+
+		data, err := svc.Import(r.Context(), request)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrImportBusy:
+				response.JSONError(r.Context(), w, http.StatusTooManyRequests, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		if !data.Finished() {
+			response.JSONSuccess(r.Context(), w, http.StatusAccepted, data)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func GetImportJobHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateImportJobIDParamRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.GetImportJob(r.Context(), id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrImportJobNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestImportMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	finished := contract.ImportJobResponse{Status: "completed", FinishedAt: "2024-01-02 03:04:05"}
+	csvFields := map[string]bool{"title": true, "rating": true, "runtime": true, "genre_ids": true}
+
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		mockFunc    func()
+		statusCode  int
+	}{
+		{
+			name:        "error unsupported content type",
+			contentType: "application/json",
+			body:        `{"title": "avengers"}`,
+			mockFunc:    func() {},
+			statusCode:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "error unknown csv column",
+			contentType: "text/csv",
+			body:        "title,year\navengers,2012\n",
+			mockFunc:    func() {},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "error csv without row",
+			contentType: "text/csv",
+			body:        "title,rating\n",
+			mockFunc:    func() {},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "error invalid dry run",
+			query:       "?dry_run=maybe",
+			contentType: "text/csv",
+			body:        "title,rating\navengers,9\n",
+			mockFunc:    func() {},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "error internal server",
+			contentType: "text/csv",
+			body:        "title,rating\navengers,9\n",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Import(gomock.Any(), gomock.Any()).Return(contract.ImportJobResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:        "error too many running import",
+			query:       "?async=true",
+			contentType: "text/csv",
+			body:        "title,rating\navengers,9\n",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Import(gomock.Any(), gomock.Any()).Return(contract.ImportJobResponse{}, appErr.ErrImportBusy).Times(1)
+			},
+			statusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:        "success csv report invalid row",
+			query:       "?dry_run=true",
+			contentType: "text/csv; charset=utf-8",
+			body: "\xef\xbb\xbfTitle,Rating,Runtime,Genre_IDs\n" +
+				"  The   Avengers ,9.5,143,1|2\n" +
+				",8,90,\n" +
+				"spiderman,ten,,\n" +
+				"showman,10,,3\n",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Import(gomock.Any(), contract.ImportRequest{
+					DryRun: true,
+					Rows: []contract.ImportRow{
+						{Line: 2, Request: contract.MovieRequest{Title: "the avengers", Rating: float32Ptr(9.5), Runtime: 143, GenreIDs: []int64{1, 2}}, Fields: csvFields},
+						{Line: 3, Request: contract.MovieRequest{Rating: float32Ptr(8), Runtime: 90}, Fields: csvFields, Reason: "title failed on required"},
+						{Line: 4, Request: contract.MovieRequest{Title: "spiderman"}, Fields: csvFields, Reason: "rating is not a number"},
+						{Line: 5, Request: contract.MovieRequest{Title: "showman", Rating: float32Ptr(10), GenreIDs: []int64{3}}, Fields: csvFields},
+					},
+				}).Return(finished, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:        "success ndjson in background",
+			query:       "?async=true",
+			contentType: "application/x-ndjson",
+			body:        "{\"title\": \"Avengers\", \"rating\": 9}\n\n{\"title\": 1}\n",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Import(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, request contract.ImportRequest) (contract.ImportJobResponse, error) {
+						assert.True(t, request.Async)
+						assert.Len(t, request.Rows, 2)
+						assert.Equal(t, contract.MovieRequest{Title: "avengers", Rating: float32Ptr(9)}, request.Rows[0].Request)
+						assert.Equal(t, map[string]bool{"title": true, "rating": true}, request.Rows[0].Fields)
+						assert.Equal(t, 3, request.Rows[1].Line)
+						assert.NotEmpty(t, request.Rows[1].Reason)
+						return contract.ImportJobResponse{Status: "pending"}, nil
+					}).Times(1)
+			},
+			statusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing"+tt.query, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(ImportMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGetImportJobHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	jobID := "7f1c2a52-8a9e-4c53-9a51-1b7ef0c1d9a4"
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  map[string]string{"job_id": "1"},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error job not found",
+			parameter: map[string]string{"job_id": jobID},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().GetImportJob(gomock.Any(), jobID).Return(contract.ImportJobResponse{}, appErr.ErrImportJobNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"job_id": jobID},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().GetImportJob(gomock.Any(), jobID).Return(contract.ImportJobResponse{ID: jobID, Status: "running"}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetImportJobHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
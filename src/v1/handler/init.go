@@ -7,9 +7,98 @@ import (
 )
 
 type MovieService interface {
-	Get(ctx context.Context, id int) (res contract.MovieResponse, err error)
+	Get(ctx context.Context, id int, params contract.GetMovieParam) (res contract.MovieResponse, err error)
 	GetList(ctx context.Context, params contract.GetListParam) (res contract.GetListResponse, err error)
+	BatchGet(ctx context.Context, request contract.BatchGetMovieRequest) (res []*contract.BatchGetMovieResponse, err error)
 	Create(ctx context.Context, request contract.MovieRequest) (res contract.MovieResponse, err error)
-	Update(ctx context.Context, request contract.MovieRequest, id int) (res contract.MovieResponse, err error)
+	Update(ctx context.Context, request contract.MoviePatchRequest, id int) (res contract.MovieResponse, err error)
+	Replace(ctx context.Context, request contract.MovieRequest, id int) (res contract.MovieResponse, err error)
+	Delete(ctx context.Context, id int, match contract.ETagMatch) (err error)
+	Bulk(ctx context.Context, request contract.BulkRequest) (res contract.BulkResponse, err error)
+	GetCredits(ctx context.Context, id int) (res []*contract.CreditResponse, err error)
+	ReplaceCredits(ctx context.Context, request contract.MovieCreditsRequest, id int) (res []*contract.CreditResponse, err error)
+	UploadImage(ctx context.Context, request contract.ImageRequest, id int) (res contract.MovieResponse, err error)
+	GetDeletedList(ctx context.Context, params contract.GetListParam) (res contract.GetListResponse, err error)
+	Restore(ctx context.Context, id int) (res contract.MovieResponse, err error)
+	Purge(ctx context.Context, id int, match contract.ETagMatch) (err error)
+	GetHistory(ctx context.Context, id int, params contract.GetListParam) (res contract.GetListRevisionResponse, err error)
+	Revert(ctx context.Context, id, revision int) (res contract.MovieResponse, err error)
+	GetDuplicates(ctx context.Context, params contract.GetDuplicateParam) (res contract.GetListDuplicateResponse, err error)
+	Search(ctx context.Context, params contract.SearchParam) (res contract.GetListSearchResponse, err error)
+	Suggest(ctx context.Context, params contract.SuggestParam) (res []*contract.SuggestResponse, err error)
+	Import(ctx context.Context, request contract.ImportRequest) (res contract.ImportJobResponse, err error)
+	GetImportJob(ctx context.Context, id string) (res contract.ImportJobResponse, err error)
+	Export(ctx context.Context, params contract.GetListParam, fn func(m *contract.MovieResponse) error) (err error)
+}
+
+type GenreService interface {
+	Get(ctx context.Context, id int) (res contract.GenreResponse, err error)
+	GetList(ctx context.Context) (res []contract.GenreResponse, err error)
+	Create(ctx context.Context, request contract.GenreRequest) (res contract.GenreResponse, err error)
+	Update(ctx context.Context, request contract.GenreRequest, id int) (res contract.GenreResponse, err error)
+	Delete(ctx context.Context, id int) (err error)
+}
+
+type PersonService interface {
+	Get(ctx context.Context, id int) (res contract.PersonResponse, err error)
+	GetList(ctx context.Context, params contract.GetListParam) (res contract.GetListPersonResponse, err error)
+	GetMovies(ctx context.Context, id int) (res []*contract.PersonMovieResponse, err error)
+	Create(ctx context.Context, request contract.PersonRequest) (res contract.PersonResponse, err error)
+	Update(ctx context.Context, request contract.PersonRequest, id int) (res contract.PersonResponse, err error)
+	Delete(ctx context.Context, id int) (err error)
+}
+
+type ReviewService interface {
+	GetList(ctx context.Context, movieID int, params contract.GetListParam) (res contract.GetListReviewResponse, err error)
+	Create(ctx context.Context, request contract.ReviewRequest, movieID int) (res contract.ReviewResponse, err error)
+	Update(ctx context.Context, request contract.ReviewRequest, movieID, id int) (res contract.ReviewResponse, err error)
+	Delete(ctx context.Context, movieID, id int, userID string) (err error)
+}
+
+type CinemaService interface {
+	Get(ctx context.Context, id int) (res contract.CinemaResponse, err error)
+	GetList(ctx context.Context, params contract.GetListParam) (res contract.GetListCinemaResponse, err error)
+	Create(ctx context.Context, request contract.CinemaRequest) (res contract.CinemaResponse, err error)
+	Update(ctx context.Context, request contract.CinemaRequest, id int) (res contract.CinemaResponse, err error)
 	Delete(ctx context.Context, id int) (err error)
+	GetScreens(ctx context.Context, cinemaID int) (res []*contract.ScreenResponse, err error)
+	GetScreen(ctx context.Context, id int) (res contract.ScreenResponse, err error)
+	CreateScreen(ctx context.Context, request contract.ScreenRequest, cinemaID int) (res contract.ScreenResponse, err error)
+	UpdateScreen(ctx context.Context, request contract.ScreenRequest, id int) (res contract.ScreenResponse, err error)
+	DeleteScreen(ctx context.Context, id int) (err error)
+}
+
+type ShowtimeService interface {
+	Get(ctx context.Context, id int) (res contract.ShowtimeResponse, err error)
+	GetByMovie(ctx context.Context, movieID int, filter contract.ShowtimeFilter) (res []*contract.ShowtimeResponse, err error)
+	GetSchedule(ctx context.Context, cinemaID int, filter contract.ShowtimeFilter) (res []*contract.ShowtimeResponse, err error)
+	Create(ctx context.Context, request contract.ShowtimeRequest) (res contract.ShowtimeResponse, err error)
+	Update(ctx context.Context, request contract.ShowtimeRequest, id int) (res contract.ShowtimeResponse, err error)
+	Delete(ctx context.Context, id int) (err error)
+}
+
+type BookingService interface {
+	GetSeats(ctx context.Context, showtimeID int) (res contract.SeatAvailabilityResponse, err error)
+	CreateHold(ctx context.Context, request contract.HoldRequest) (res contract.HoldResponse, err error)
+	GetHold(ctx context.Context, id, userID string) (res contract.HoldResponse, err error)
+	ReleaseHold(ctx context.Context, id, userID string) (err error)
+	Confirm(ctx context.Context, request contract.BookingRequest) (res contract.BookingResponse, err error)
+	GetBooking(ctx context.Context, id int, userID string) (res contract.BookingResponse, err error)
+	GetBookings(ctx context.Context, userID string) (res []*contract.BookingResponse, err error)
+}
+
+type RoleService interface {
+	Get(ctx context.Context, name string) (res contract.RoleResponse, err error)
+	GetList(ctx context.Context) (res []contract.RoleResponse, err error)
+	Create(ctx context.Context, request contract.RoleRequest) (res contract.RoleResponse, err error)
+	Update(ctx context.Context, request contract.RoleRequest) (res contract.RoleResponse, err error)
+	Delete(ctx context.Context, name string) (err error)
+}
+
+type APIKeyService interface {
+	Get(ctx context.Context, id int) (res contract.APIKeyResponse, err error)
+	GetList(ctx context.Context) (res []contract.APIKeyResponse, err error)
+	Issue(ctx context.Context, request contract.APIKeyRequest) (res contract.IssuedAPIKeyResponse, err error)
+	Rotate(ctx context.Context, id int) (res contract.IssuedAPIKeyResponse, err error)
+	Revoke(ctx context.Context, id int) (err error)
 }
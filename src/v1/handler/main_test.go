@@ -3,10 +3,12 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"os"
 	"testing"
 
 	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/middleware/auth"
 	"github.com/Risuii/movie/src/middleware/response"
 	"github.com/nsf/jsondiff"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +24,22 @@ func TestMain(m *testing.M) {
 	os.Exit(exitVal)
 }
 
+// withSubject authenticate the request as the user, request without user is anonymous
+func withSubject(r *http.Request, userID string) *http.Request {
+	if userID == "" {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), auth.CtxKeyClaims, &auth.Claims{Subject: userID}))
+}
+
+func float32Ptr(f float32) *float32 {
+	return &f
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
 func CheckBodyResponse(t *testing.T, actualResponse []byte, expected interface{}) response.Response {
 	var body response.Response
 	err := json.Unmarshal(actualResponse, &body)
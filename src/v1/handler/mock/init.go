@@ -39,6 +39,36 @@ func (m *MockMovieService) EXPECT() *MockMovieServiceMockRecorder {
 	return m.recorder
 }
 
+// BatchGet mocks base method.
+func (m *MockMovieService) BatchGet(ctx context.Context, request contract.BatchGetMovieRequest) ([]*contract.BatchGetMovieResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGet", ctx, request)
+	ret0, _ := ret[0].([]*contract.BatchGetMovieResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockMovieServiceMockRecorder) BatchGet(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockMovieService)(nil).BatchGet), ctx, request)
+}
+
+// Bulk mocks base method.
+func (m *MockMovieService) Bulk(ctx context.Context, request contract.BulkRequest) (contract.BulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, request)
+	ret0, _ := ret[0].(contract.BulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockMovieServiceMockRecorder) Bulk(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockMovieService)(nil).Bulk), ctx, request)
+}
+
 // Create mocks base method.
 func (m *MockMovieService) Create(ctx context.Context, request contract.MovieRequest) (contract.MovieResponse, error) {
 	m.ctrl.T.Helper()
@@ -55,32 +85,121 @@ func (mr *MockMovieServiceMockRecorder) Create(ctx, request any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockMovieService) Delete(ctx context.Context, id int) error {
+func (m *MockMovieService) Delete(ctx context.Context, id int, match contract.ETagMatch) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, match)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockMovieServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) Delete(ctx, id, match any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMovieService)(nil).Delete), ctx, id, match)
+}
+
+// Export mocks base method.
+func (m *MockMovieService) Export(ctx context.Context, params contract.GetListParam, fn func(*contract.MovieResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, params, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockMovieServiceMockRecorder) Export(ctx, params, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMovieService)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockMovieService)(nil).Export), ctx, params, fn)
 }
 
 // Get mocks base method.
-func (m *MockMovieService) Get(ctx context.Context, id int) (contract.MovieResponse, error) {
+func (m *MockMovieService) Get(ctx context.Context, id int, params contract.GetMovieParam) (contract.MovieResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret := m.ctrl.Call(m, "Get", ctx, id, params)
 	ret0, _ := ret[0].(contract.MovieResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockMovieServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) Get(ctx, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMovieService)(nil).Get), ctx, id, params)
+}
+
+// GetCredits mocks base method.
+func (m *MockMovieService) GetCredits(ctx context.Context, id int) ([]*contract.CreditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredits", ctx, id)
+	ret0, _ := ret[0].([]*contract.CreditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredits indicates an expected call of GetCredits.
+func (mr *MockMovieServiceMockRecorder) GetCredits(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredits", reflect.TypeOf((*MockMovieService)(nil).GetCredits), ctx, id)
+}
+
+// GetDeletedList mocks base method.
+func (m *MockMovieService) GetDeletedList(ctx context.Context, params contract.GetListParam) (contract.GetListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedList", ctx, params)
+	ret0, _ := ret[0].(contract.GetListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedList indicates an expected call of GetDeletedList.
+func (mr *MockMovieServiceMockRecorder) GetDeletedList(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedList", reflect.TypeOf((*MockMovieService)(nil).GetDeletedList), ctx, params)
+}
+
+// GetDuplicates mocks base method.
+func (m *MockMovieService) GetDuplicates(ctx context.Context, params contract.GetDuplicateParam) (contract.GetListDuplicateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicates", ctx, params)
+	ret0, _ := ret[0].(contract.GetListDuplicateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockMovieServiceMockRecorder) GetDuplicates(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockMovieService)(nil).GetDuplicates), ctx, params)
+}
+
+// GetHistory mocks base method.
+func (m *MockMovieService) GetHistory(ctx context.Context, id int, params contract.GetListParam) (contract.GetListRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id, params)
+	ret0, _ := ret[0].(contract.GetListRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockMovieServiceMockRecorder) GetHistory(ctx, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockMovieService)(nil).GetHistory), ctx, id, params)
+}
+
+// GetImportJob mocks base method.
+func (m *MockMovieService) GetImportJob(ctx context.Context, id string) (contract.ImportJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id)
+	ret0, _ := ret[0].(contract.ImportJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockMovieServiceMockRecorder) GetImportJob(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMovieService)(nil).Get), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockMovieService)(nil).GetImportJob), ctx, id)
 }
 
 // GetList mocks base method.
@@ -98,8 +217,127 @@ func (mr *MockMovieServiceMockRecorder) GetList(ctx, params any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockMovieService)(nil).GetList), ctx, params)
 }
 
+// Import mocks base method.
+func (m *MockMovieService) Import(ctx context.Context, request contract.ImportRequest) (contract.ImportJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, request)
+	ret0, _ := ret[0].(contract.ImportJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockMovieServiceMockRecorder) Import(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockMovieService)(nil).Import), ctx, request)
+}
+
+// Purge mocks base method.
+func (m *MockMovieService) Purge(ctx context.Context, id int, match contract.ETagMatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id, match)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockMovieServiceMockRecorder) Purge(ctx, id, match any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockMovieService)(nil).Purge), ctx, id, match)
+}
+
+// Replace mocks base method.
+func (m *MockMovieService) Replace(ctx context.Context, request contract.MovieRequest, id int) (contract.MovieResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, request, id)
+	ret0, _ := ret[0].(contract.MovieResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockMovieServiceMockRecorder) Replace(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockMovieService)(nil).Replace), ctx, request, id)
+}
+
+// ReplaceCredits mocks base method.
+func (m *MockMovieService) ReplaceCredits(ctx context.Context, request contract.MovieCreditsRequest, id int) ([]*contract.CreditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCredits", ctx, request, id)
+	ret0, _ := ret[0].([]*contract.CreditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceCredits indicates an expected call of ReplaceCredits.
+func (mr *MockMovieServiceMockRecorder) ReplaceCredits(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCredits", reflect.TypeOf((*MockMovieService)(nil).ReplaceCredits), ctx, request, id)
+}
+
+// Restore mocks base method.
+func (m *MockMovieService) Restore(ctx context.Context, id int) (contract.MovieResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(contract.MovieResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockMovieServiceMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockMovieService)(nil).Restore), ctx, id)
+}
+
+// Revert mocks base method.
+func (m *MockMovieService) Revert(ctx context.Context, id, revision int) (contract.MovieResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, id, revision)
+	ret0, _ := ret[0].(contract.MovieResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockMovieServiceMockRecorder) Revert(ctx, id, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockMovieService)(nil).Revert), ctx, id, revision)
+}
+
+// Search mocks base method.
+func (m *MockMovieService) Search(ctx context.Context, params contract.SearchParam) (contract.GetListSearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, params)
+	ret0, _ := ret[0].(contract.GetListSearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockMovieServiceMockRecorder) Search(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockMovieService)(nil).Search), ctx, params)
+}
+
+// Suggest mocks base method.
+func (m *MockMovieService) Suggest(ctx context.Context, params contract.SuggestParam) ([]*contract.SuggestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, params)
+	ret0, _ := ret[0].([]*contract.SuggestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockMovieServiceMockRecorder) Suggest(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockMovieService)(nil).Suggest), ctx, params)
+}
+
 // Update mocks base method.
-func (m *MockMovieService) Update(ctx context.Context, request contract.MovieRequest, id int) (contract.MovieResponse, error) {
+func (m *MockMovieService) Update(ctx context.Context, request contract.MoviePatchRequest, id int) (contract.MovieResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, request, id)
 	ret0, _ := ret[0].(contract.MovieResponse)
@@ -112,3 +350,913 @@ func (mr *MockMovieServiceMockRecorder) Update(ctx, request, id any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMovieService)(nil).Update), ctx, request, id)
 }
+
+// UploadImage mocks base method.
+func (m *MockMovieService) UploadImage(ctx context.Context, request contract.ImageRequest, id int) (contract.MovieResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, request, id)
+	ret0, _ := ret[0].(contract.MovieResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockMovieServiceMockRecorder) UploadImage(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockMovieService)(nil).UploadImage), ctx, request, id)
+}
+
+// MockGenreService is a mock of GenreService interface.
+type MockGenreService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreServiceMockRecorder
+}
+
+// MockGenreServiceMockRecorder is the mock recorder for MockGenreService.
+type MockGenreServiceMockRecorder struct {
+	mock *MockGenreService
+}
+
+// NewMockGenreService creates a new mock instance.
+func NewMockGenreService(ctrl *gomock.Controller) *MockGenreService {
+	mock := &MockGenreService{ctrl: ctrl}
+	mock.recorder = &MockGenreServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreService) EXPECT() *MockGenreServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGenreService) Create(ctx context.Context, request contract.GenreRequest) (contract.GenreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(contract.GenreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGenreServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGenreService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockGenreService) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGenreServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGenreService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockGenreService) Get(ctx context.Context, id int) (contract.GenreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(contract.GenreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGenreServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGenreService)(nil).Get), ctx, id)
+}
+
+// GetList mocks base method.
+func (m *MockGenreService) GetList(ctx context.Context) ([]contract.GenreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx)
+	ret0, _ := ret[0].([]contract.GenreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockGenreServiceMockRecorder) GetList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockGenreService)(nil).GetList), ctx)
+}
+
+// Update mocks base method.
+func (m *MockGenreService) Update(ctx context.Context, request contract.GenreRequest, id int) (contract.GenreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request, id)
+	ret0, _ := ret[0].(contract.GenreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGenreServiceMockRecorder) Update(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreService)(nil).Update), ctx, request, id)
+}
+
+// MockPersonService is a mock of PersonService interface.
+type MockPersonService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonServiceMockRecorder
+}
+
+// MockPersonServiceMockRecorder is the mock recorder for MockPersonService.
+type MockPersonServiceMockRecorder struct {
+	mock *MockPersonService
+}
+
+// NewMockPersonService creates a new mock instance.
+func NewMockPersonService(ctrl *gomock.Controller) *MockPersonService {
+	mock := &MockPersonService{ctrl: ctrl}
+	mock.recorder = &MockPersonServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonService) EXPECT() *MockPersonServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPersonService) Create(ctx context.Context, request contract.PersonRequest) (contract.PersonResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(contract.PersonResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPersonServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockPersonService) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPersonServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPersonService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockPersonService) Get(ctx context.Context, id int) (contract.PersonResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(contract.PersonResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPersonServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPersonService)(nil).Get), ctx, id)
+}
+
+// GetList mocks base method.
+func (m *MockPersonService) GetList(ctx context.Context, params contract.GetListParam) (contract.GetListPersonResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, params)
+	ret0, _ := ret[0].(contract.GetListPersonResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockPersonServiceMockRecorder) GetList(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockPersonService)(nil).GetList), ctx, params)
+}
+
+// GetMovies mocks base method.
+func (m *MockPersonService) GetMovies(ctx context.Context, id int) ([]*contract.PersonMovieResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovies", ctx, id)
+	ret0, _ := ret[0].([]*contract.PersonMovieResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovies indicates an expected call of GetMovies.
+func (mr *MockPersonServiceMockRecorder) GetMovies(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockPersonService)(nil).GetMovies), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPersonService) Update(ctx context.Context, request contract.PersonRequest, id int) (contract.PersonResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request, id)
+	ret0, _ := ret[0].(contract.PersonResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPersonServiceMockRecorder) Update(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPersonService)(nil).Update), ctx, request, id)
+}
+
+// MockReviewService is a mock of ReviewService interface.
+type MockReviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewServiceMockRecorder
+}
+
+// MockReviewServiceMockRecorder is the mock recorder for MockReviewService.
+type MockReviewServiceMockRecorder struct {
+	mock *MockReviewService
+}
+
+// NewMockReviewService creates a new mock instance.
+func NewMockReviewService(ctrl *gomock.Controller) *MockReviewService {
+	mock := &MockReviewService{ctrl: ctrl}
+	mock.recorder = &MockReviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewService) EXPECT() *MockReviewServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewService) Create(ctx context.Context, request contract.ReviewRequest, movieID int) (contract.ReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request, movieID)
+	ret0, _ := ret[0].(contract.ReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewServiceMockRecorder) Create(ctx, request, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewService)(nil).Create), ctx, request, movieID)
+}
+
+// Delete mocks base method.
+func (m *MockReviewService) Delete(ctx context.Context, movieID, id int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, movieID, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReviewServiceMockRecorder) Delete(ctx, movieID, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReviewService)(nil).Delete), ctx, movieID, id, userID)
+}
+
+// GetList mocks base method.
+func (m *MockReviewService) GetList(ctx context.Context, movieID int, params contract.GetListParam) (contract.GetListReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, movieID, params)
+	ret0, _ := ret[0].(contract.GetListReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockReviewServiceMockRecorder) GetList(ctx, movieID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockReviewService)(nil).GetList), ctx, movieID, params)
+}
+
+// Update mocks base method.
+func (m *MockReviewService) Update(ctx context.Context, request contract.ReviewRequest, movieID, id int) (contract.ReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request, movieID, id)
+	ret0, _ := ret[0].(contract.ReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReviewServiceMockRecorder) Update(ctx, request, movieID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReviewService)(nil).Update), ctx, request, movieID, id)
+}
+
+// MockCinemaService is a mock of CinemaService interface.
+type MockCinemaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCinemaServiceMockRecorder
+}
+
+// MockCinemaServiceMockRecorder is the mock recorder for MockCinemaService.
+type MockCinemaServiceMockRecorder struct {
+	mock *MockCinemaService
+}
+
+// NewMockCinemaService creates a new mock instance.
+func NewMockCinemaService(ctrl *gomock.Controller) *MockCinemaService {
+	mock := &MockCinemaService{ctrl: ctrl}
+	mock.recorder = &MockCinemaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCinemaService) EXPECT() *MockCinemaServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCinemaService) Create(ctx context.Context, request contract.CinemaRequest) (contract.CinemaResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(contract.CinemaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCinemaServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCinemaService)(nil).Create), ctx, request)
+}
+
+// CreateScreen mocks base method.
+func (m *MockCinemaService) CreateScreen(ctx context.Context, request contract.ScreenRequest, cinemaID int) (contract.ScreenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScreen", ctx, request, cinemaID)
+	ret0, _ := ret[0].(contract.ScreenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScreen indicates an expected call of CreateScreen.
+func (mr *MockCinemaServiceMockRecorder) CreateScreen(ctx, request, cinemaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScreen", reflect.TypeOf((*MockCinemaService)(nil).CreateScreen), ctx, request, cinemaID)
+}
+
+// Delete mocks base method.
+func (m *MockCinemaService) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCinemaServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCinemaService)(nil).Delete), ctx, id)
+}
+
+// DeleteScreen mocks base method.
+func (m *MockCinemaService) DeleteScreen(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScreen", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScreen indicates an expected call of DeleteScreen.
+func (mr *MockCinemaServiceMockRecorder) DeleteScreen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScreen", reflect.TypeOf((*MockCinemaService)(nil).DeleteScreen), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCinemaService) Get(ctx context.Context, id int) (contract.CinemaResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(contract.CinemaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCinemaServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCinemaService)(nil).Get), ctx, id)
+}
+
+// GetList mocks base method.
+func (m *MockCinemaService) GetList(ctx context.Context, params contract.GetListParam) (contract.GetListCinemaResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, params)
+	ret0, _ := ret[0].(contract.GetListCinemaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockCinemaServiceMockRecorder) GetList(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockCinemaService)(nil).GetList), ctx, params)
+}
+
+// GetScreen mocks base method.
+func (m *MockCinemaService) GetScreen(ctx context.Context, id int) (contract.ScreenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreen", ctx, id)
+	ret0, _ := ret[0].(contract.ScreenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreen indicates an expected call of GetScreen.
+func (mr *MockCinemaServiceMockRecorder) GetScreen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreen", reflect.TypeOf((*MockCinemaService)(nil).GetScreen), ctx, id)
+}
+
+// GetScreens mocks base method.
+func (m *MockCinemaService) GetScreens(ctx context.Context, cinemaID int) ([]*contract.ScreenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreens", ctx, cinemaID)
+	ret0, _ := ret[0].([]*contract.ScreenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreens indicates an expected call of GetScreens.
+func (mr *MockCinemaServiceMockRecorder) GetScreens(ctx, cinemaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreens", reflect.TypeOf((*MockCinemaService)(nil).GetScreens), ctx, cinemaID)
+}
+
+// Update mocks base method.
+func (m *MockCinemaService) Update(ctx context.Context, request contract.CinemaRequest, id int) (contract.CinemaResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request, id)
+	ret0, _ := ret[0].(contract.CinemaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCinemaServiceMockRecorder) Update(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCinemaService)(nil).Update), ctx, request, id)
+}
+
+// UpdateScreen mocks base method.
+func (m *MockCinemaService) UpdateScreen(ctx context.Context, request contract.ScreenRequest, id int) (contract.ScreenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScreen", ctx, request, id)
+	ret0, _ := ret[0].(contract.ScreenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScreen indicates an expected call of UpdateScreen.
+func (mr *MockCinemaServiceMockRecorder) UpdateScreen(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScreen", reflect.TypeOf((*MockCinemaService)(nil).UpdateScreen), ctx, request, id)
+}
+
+// MockShowtimeService is a mock of ShowtimeService interface.
+type MockShowtimeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShowtimeServiceMockRecorder
+}
+
+// MockShowtimeServiceMockRecorder is the mock recorder for MockShowtimeService.
+type MockShowtimeServiceMockRecorder struct {
+	mock *MockShowtimeService
+}
+
+// NewMockShowtimeService creates a new mock instance.
+func NewMockShowtimeService(ctrl *gomock.Controller) *MockShowtimeService {
+	mock := &MockShowtimeService{ctrl: ctrl}
+	mock.recorder = &MockShowtimeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShowtimeService) EXPECT() *MockShowtimeServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShowtimeService) Create(ctx context.Context, request contract.ShowtimeRequest) (contract.ShowtimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(contract.ShowtimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShowtimeServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShowtimeService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockShowtimeService) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShowtimeServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShowtimeService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockShowtimeService) Get(ctx context.Context, id int) (contract.ShowtimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(contract.ShowtimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockShowtimeServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockShowtimeService)(nil).Get), ctx, id)
+}
+
+// GetByMovie mocks base method.
+func (m *MockShowtimeService) GetByMovie(ctx context.Context, movieID int, filter contract.ShowtimeFilter) ([]*contract.ShowtimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMovie", ctx, movieID, filter)
+	ret0, _ := ret[0].([]*contract.ShowtimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMovie indicates an expected call of GetByMovie.
+func (mr *MockShowtimeServiceMockRecorder) GetByMovie(ctx, movieID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMovie", reflect.TypeOf((*MockShowtimeService)(nil).GetByMovie), ctx, movieID, filter)
+}
+
+// GetSchedule mocks base method.
+func (m *MockShowtimeService) GetSchedule(ctx context.Context, cinemaID int, filter contract.ShowtimeFilter) ([]*contract.ShowtimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, cinemaID, filter)
+	ret0, _ := ret[0].([]*contract.ShowtimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockShowtimeServiceMockRecorder) GetSchedule(ctx, cinemaID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockShowtimeService)(nil).GetSchedule), ctx, cinemaID, filter)
+}
+
+// Update mocks base method.
+func (m *MockShowtimeService) Update(ctx context.Context, request contract.ShowtimeRequest, id int) (contract.ShowtimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request, id)
+	ret0, _ := ret[0].(contract.ShowtimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShowtimeServiceMockRecorder) Update(ctx, request, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShowtimeService)(nil).Update), ctx, request, id)
+}
+
+// MockBookingService is a mock of BookingService interface.
+type MockBookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingServiceMockRecorder
+}
+
+// MockBookingServiceMockRecorder is the mock recorder for MockBookingService.
+type MockBookingServiceMockRecorder struct {
+	mock *MockBookingService
+}
+
+// NewMockBookingService creates a new mock instance.
+func NewMockBookingService(ctrl *gomock.Controller) *MockBookingService {
+	mock := &MockBookingService{ctrl: ctrl}
+	mock.recorder = &MockBookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingService) EXPECT() *MockBookingServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockBookingService) Confirm(ctx context.Context, request contract.BookingRequest) (contract.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, request)
+	ret0, _ := ret[0].(contract.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockBookingServiceMockRecorder) Confirm(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockBookingService)(nil).Confirm), ctx, request)
+}
+
+// CreateHold mocks base method.
+func (m *MockBookingService) CreateHold(ctx context.Context, request contract.HoldRequest) (contract.HoldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, request)
+	ret0, _ := ret[0].(contract.HoldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockBookingServiceMockRecorder) CreateHold(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockBookingService)(nil).CreateHold), ctx, request)
+}
+
+// GetBooking mocks base method.
+func (m *MockBookingService) GetBooking(ctx context.Context, id int, userID string) (contract.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBooking", ctx, id, userID)
+	ret0, _ := ret[0].(contract.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBooking indicates an expected call of GetBooking.
+func (mr *MockBookingServiceMockRecorder) GetBooking(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooking", reflect.TypeOf((*MockBookingService)(nil).GetBooking), ctx, id, userID)
+}
+
+// GetBookings mocks base method.
+func (m *MockBookingService) GetBookings(ctx context.Context, userID string) ([]*contract.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookings", ctx, userID)
+	ret0, _ := ret[0].([]*contract.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookings indicates an expected call of GetBookings.
+func (mr *MockBookingServiceMockRecorder) GetBookings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookings", reflect.TypeOf((*MockBookingService)(nil).GetBookings), ctx, userID)
+}
+
+// GetHold mocks base method.
+func (m *MockBookingService) GetHold(ctx context.Context, id, userID string) (contract.HoldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id, userID)
+	ret0, _ := ret[0].(contract.HoldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockBookingServiceMockRecorder) GetHold(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockBookingService)(nil).GetHold), ctx, id, userID)
+}
+
+// GetSeats mocks base method.
+func (m *MockBookingService) GetSeats(ctx context.Context, showtimeID int) (contract.SeatAvailabilityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeats", ctx, showtimeID)
+	ret0, _ := ret[0].(contract.SeatAvailabilityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeats indicates an expected call of GetSeats.
+func (mr *MockBookingServiceMockRecorder) GetSeats(ctx, showtimeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeats", reflect.TypeOf((*MockBookingService)(nil).GetSeats), ctx, showtimeID)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockBookingService) ReleaseHold(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockBookingServiceMockRecorder) ReleaseHold(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockBookingService)(nil).ReleaseHold), ctx, id, userID)
+}
+
+// MockRoleService is a mock of RoleService interface.
+type MockRoleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleServiceMockRecorder
+}
+
+// MockRoleServiceMockRecorder is the mock recorder for MockRoleService.
+type MockRoleServiceMockRecorder struct {
+	mock *MockRoleService
+}
+
+// NewMockRoleService creates a new mock instance.
+func NewMockRoleService(ctrl *gomock.Controller) *MockRoleService {
+	mock := &MockRoleService{ctrl: ctrl}
+	mock.recorder = &MockRoleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleService) EXPECT() *MockRoleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRoleService) Create(ctx context.Context, request contract.RoleRequest) (contract.RoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(contract.RoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRoleServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRoleService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockRoleService) Delete(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRoleServiceMockRecorder) Delete(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRoleService)(nil).Delete), ctx, name)
+}
+
+// Get mocks base method.
+func (m *MockRoleService) Get(ctx context.Context, name string) (contract.RoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret0, _ := ret[0].(contract.RoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRoleServiceMockRecorder) Get(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRoleService)(nil).Get), ctx, name)
+}
+
+// GetList mocks base method.
+func (m *MockRoleService) GetList(ctx context.Context) ([]contract.RoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx)
+	ret0, _ := ret[0].([]contract.RoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockRoleServiceMockRecorder) GetList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockRoleService)(nil).GetList), ctx)
+}
+
+// Update mocks base method.
+func (m *MockRoleService) Update(ctx context.Context, request contract.RoleRequest) (contract.RoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request)
+	ret0, _ := ret[0].(contract.RoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRoleServiceMockRecorder) Update(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRoleService)(nil).Update), ctx, request)
+}
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAPIKeyService) Get(ctx context.Context, id int) (contract.APIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(contract.APIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAPIKeyServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIKeyService)(nil).Get), ctx, id)
+}
+
+// GetList mocks base method.
+func (m *MockAPIKeyService) GetList(ctx context.Context) ([]contract.APIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx)
+	ret0, _ := ret[0].([]contract.APIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockAPIKeyServiceMockRecorder) GetList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockAPIKeyService)(nil).GetList), ctx)
+}
+
+// Issue mocks base method.
+func (m *MockAPIKeyService) Issue(ctx context.Context, request contract.APIKeyRequest) (contract.IssuedAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, request)
+	ret0, _ := ret[0].(contract.IssuedAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockAPIKeyServiceMockRecorder) Issue(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockAPIKeyService)(nil).Issue), ctx, request)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyService) Revoke(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyServiceMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyService)(nil).Revoke), ctx, id)
+}
+
+// Rotate mocks base method.
+func (m *MockAPIKeyService) Rotate(ctx context.Context, id int) (contract.IssuedAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, id)
+	ret0, _ := ret[0].(contract.IssuedAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockAPIKeyServiceMockRecorder) Rotate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockAPIKeyService)(nil).Rotate), ctx, id)
+}
@@ -4,7 +4,9 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/Risuii/movie/src/entity"
 	"github.com/Risuii/movie/src/errors"
+	"github.com/Risuii/movie/src/middleware/auth"
 	"github.com/Risuii/movie/src/middleware/response"
 	"github.com/Risuii/movie/src/v1/contract"
 )
@@ -18,7 +20,20 @@ func GetMovieHandler(svc MovieService) http.HandlerFunc {
 			return
 		}
 
-		data, err := svc.Get(r.Context(), id)
+		params, err := contract.ValidateAndBuildGetMovieRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		ifNoneMatch, err := contract.BuildIfNoneMatchRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.Get(r.Context(), id, params)
 		if err != nil {
 			log.Println(err)
 			switch err {
@@ -30,6 +45,12 @@ func GetMovieHandler(svc MovieService) http.HandlerFunc {
 			return
 		}
 
+		contract.SetETag(w, data.Version)
+		if ifNoneMatch.Match(data.Version) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
 		response.JSONSuccessResponse(r.Context(), w, data)
 	}
 }
@@ -54,6 +75,134 @@ func GetListMovieHandler(svc MovieService) http.HandlerFunc {
 	}
 }
 
+// BatchGetMovieHandler always respond 200, movie that is not found is marked in its item
+func BatchGetMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request, err := contract.BuildAndValidateBatchGetMovieRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.BatchGet(r.Context(), request)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func GetDeletedListMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := contract.ValidateAndBuildRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.GetDeletedList(r.Context(), *params)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func GetDuplicateMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := contract.ValidateAndBuildDuplicateRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.GetDuplicates(r.Context(), params)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func SearchMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := contract.ValidateAndBuildSearchRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.Search(r.Context(), params)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func SuggestMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := contract.ValidateAndBuildSuggestRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.Suggest(r.Context(), params)
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func RestoreMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.Restore(r.Context(), id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrMovieIdNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			case errors.ErrDuplicatemovie:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		contract.SetETag(w, data.Version)
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
 func CreateMovieHandler(svc MovieService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		movieRequest, err := contract.BuildAndValidateMovieRequest(r)
@@ -65,15 +214,60 @@ func CreateMovieHandler(svc MovieService) http.HandlerFunc {
 		res, err := svc.Create(r.Context(), movieRequest)
 		if err != nil {
 			log.Println(err)
-			response.JSONInternalErrorResponse(r.Context(), w)
+			switch err {
+			case errors.ErrGenreIdNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			case errors.ErrDuplicatemovie:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
 			return
 		}
 
+		contract.SetETag(w, res.Version)
 		response.JSONSuccessResponse(r.Context(), w, res)
 	}
 }
 
 func UpdateMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		patchRequest, err := contract.BuildAndValidateMoviePatchRequest(r)
+		if err != nil {
+			if err == contract.ErrUnsupportedContentType {
+				response.JSONError(r.Context(), w, http.StatusUnsupportedMediaType, errors.ErrUnsupportedContentType)
+				return
+			}
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		patchRequest.IfMatch, err = contract.BuildIfMatchRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.Update(r.Context(), patchRequest, id)
+		if err != nil {
+			log.Println(err)
+			writeMovieUpdateError(w, r, err)
+			return
+		}
+
+		contract.SetETag(w, res.Version)
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+func ReplaceMovieHandler(svc MovieService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id, err := contract.ValidateIDParamRequest(r)
 		if err != nil {
@@ -88,23 +282,94 @@ func UpdateMovieHandler(svc MovieService) http.HandlerFunc {
 			return
 		}
 
-		res, err := svc.Update(r.Context(), movieRequest, id)
+		movieRequest.IfMatch, err = contract.BuildIfMatchRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.Replace(r.Context(), movieRequest, id)
+		if err != nil {
+			log.Println(err)
+			writeMovieUpdateError(w, r, err)
+			return
+		}
+
+		contract.SetETag(w, res.Version)
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+// writeMovieUpdateError map error of patch and replace to its response
+func writeMovieUpdateError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case errors.ErrMovieIdNotFound, errors.ErrGenreIdNotFound:
+		response.JSONUnprocessableEntity(r.Context(), w, err)
+	case errors.ErrDuplicatemovie:
+		response.JSONError(r.Context(), w, http.StatusConflict, err)
+	case errors.ErrPreconditionFailed:
+		response.JSONError(r.Context(), w, http.StatusPreconditionFailed, err)
+	case errors.ErrPreconditionRequired:
+		response.JSONError(r.Context(), w, http.StatusPreconditionRequired, err)
+	default:
+		response.JSONInternalErrorResponse(r.Context(), w)
+	}
+}
+
+func DeleteMovieHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		hard, err := contract.ValidateHardDeleteParam(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		// route authorize movie:delete, hard delete need movie:purge too
+		if hard && !auth.HasPermission(r.Context(), entity.PermissionMoviePurge) {
+			response.JSONForbiddenResponse(r.Context(), w)
+			return
+		}
+
+		ifMatch, err := contract.BuildIfMatchRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		if hard {
+			err = svc.Purge(r.Context(), id, ifMatch)
+		} else {
+			err = svc.Delete(r.Context(), id, ifMatch)
+		}
 		if err != nil {
 			log.Println(err)
 			switch err {
 			case errors.ErrMovieIdNotFound:
 				response.JSONUnprocessableEntity(r.Context(), w, err)
+			case errors.ErrMovieHasBookings:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			case errors.ErrPreconditionFailed:
+				response.JSONError(r.Context(), w, http.StatusPreconditionFailed, err)
+			case errors.ErrPreconditionRequired:
+				response.JSONError(r.Context(), w, http.StatusPreconditionRequired, err)
 			default:
 				response.JSONInternalErrorResponse(r.Context(), w)
 			}
 			return
 		}
 
-		response.JSONSuccessResponse(r.Context(), w, res)
+		response.JSONSuccessResponse(r.Context(), w, "success delete movie")
 	}
 }
 
-func DeleteMovieHandler(svc MovieService) http.HandlerFunc {
+func GetMovieCreditsHandler(svc MovieService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id, err := contract.ValidateIDParamRequest(r)
 		if err != nil {
@@ -113,7 +378,7 @@ func DeleteMovieHandler(svc MovieService) http.HandlerFunc {
 			return
 		}
 
-		err = svc.Delete(r.Context(), id)
+		data, err := svc.GetCredits(r.Context(), id)
 		if err != nil {
 			log.Println(err)
 			switch err {
@@ -125,6 +390,76 @@ func DeleteMovieHandler(svc MovieService) http.HandlerFunc {
 			return
 		}
 
-		response.JSONSuccessResponse(r.Context(), w, "success delete movie")
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func ReplaceMovieCreditsHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		creditsRequest, err := contract.BuildAndValidateMovieCreditsRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.ReplaceCredits(r.Context(), creditsRequest, id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrMovieIdNotFound, errors.ErrPersonIdNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func UploadMovieImageHandler(svc MovieService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := contract.ValidateIDParamRequest(r)
+		if err != nil {
+			log.Println(err)
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		imageRequest, err := contract.BuildAndValidateImageRequest(w, r)
+		if err != nil {
+			switch err {
+			case contract.ErrImageTooLarge:
+				response.JSONError(r.Context(), w, http.StatusRequestEntityTooLarge, errors.ErrImageTooLarge)
+			case contract.ErrUnsupportedImageType:
+				response.JSONError(r.Context(), w, http.StatusUnsupportedMediaType, errors.ErrUnsupportedImageType)
+			default:
+				response.JSONBadRequestResponse(r.Context(), w)
+			}
+			return
+		}
+
+		data, err := svc.UploadImage(r.Context(), imageRequest, id)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrMovieIdNotFound, errors.ErrInvalidImage:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		contract.SetETag(w, data.Version)
+		response.JSONSuccessResponse(r.Context(), w, data)
 	}
 }
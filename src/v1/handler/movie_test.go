@@ -1,11 +1,17 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/middleware/auth"
 	"github.com/Risuii/movie/src/v1/contract"
 
 	"github.com/stretchr/testify/assert"
@@ -60,7 +66,7 @@ func TestGetMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Get(gomock.Any(), arg.id).Return(contract.MovieResponse{}, appErr.ErrMovieIdNotFound).Times(1)
+				mockMovieSvc.EXPECT().Get(gomock.Any(), arg.id, contract.GetMovieParam{}).Return(contract.MovieResponse{}, appErr.ErrMovieIdNotFound).Times(1)
 			},
 		},
 		{
@@ -76,7 +82,7 @@ func TestGetMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Get(gomock.Any(), arg.id).Return(contract.MovieResponse{}, assert.AnError).Times(1)
+				mockMovieSvc.EXPECT().Get(gomock.Any(), arg.id, contract.GetMovieParam{}).Return(contract.MovieResponse{}, assert.AnError).Times(1)
 			},
 		},
 		{
@@ -92,7 +98,7 @@ func TestGetMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Get(gomock.Any(), arg.id).Return(contract.MovieResponse{}, nil).Times(1)
+				mockMovieSvc.EXPECT().Get(gomock.Any(), arg.id, contract.GetMovieParam{}).Return(contract.MovieResponse{}, nil).Times(1)
 			},
 		},
 	}
@@ -143,7 +149,75 @@ func TestGetListMovieHandler(t *testing.T) {
 		want       contract.GetListResponse
 		wantErr    bool
 		statusCode int
+		query      string
 	}{
+		{
+			name: "error bad request limit",
+			args: args{
+				ctx:    context.Background(),
+				params: mockParams,
+			},
+			want:       contract.GetListResponse{},
+			wantErr:    true,
+			statusCode: http.StatusBadRequest,
+			query:      "?limit=1000",
+			mockFunc:   func(arg args) {},
+		},
+		{
+			name: "error bad request sort",
+			args: args{
+				ctx:    context.Background(),
+				params: mockParams,
+			},
+			want:       contract.GetListResponse{},
+			wantErr:    true,
+			statusCode: http.StatusBadRequest,
+			query:      "?sort=description:desc",
+			mockFunc:   func(arg args) {},
+		},
+		{
+			name: "error bad request rating range",
+			args: args{
+				ctx:    context.Background(),
+				params: mockParams,
+			},
+			want:       contract.GetListResponse{},
+			wantErr:    true,
+			statusCode: http.StatusBadRequest,
+			query:      "?rating_min=8&rating_max=5",
+			mockFunc:   func(arg args) {},
+		},
+		{
+			name: "error bad request rating not decimal",
+			args: args{
+				ctx:    context.Background(),
+				params: mockParams,
+			},
+			want:       contract.GetListResponse{},
+			wantErr:    true,
+			statusCode: http.StatusBadRequest,
+			query:      "?rating_min=0x1p3",
+			mockFunc:   func(arg args) {},
+		},
+		{
+			name: "success rating range keep decimal",
+			args: args{
+				ctx: context.Background(),
+				params: contract.GetListParam{
+					Page:      1,
+					Limit:     10,
+					RatingMin: stringPtr("7.3"),
+					RatingMax: stringPtr("7.30"),
+				},
+			},
+			want:       contract.GetListResponse{},
+			wantErr:    false,
+			statusCode: http.StatusOK,
+			query:      "?rating_min=7.3&rating_max=7.30",
+			mockFunc: func(arg args) {
+				mockMovieSvc.EXPECT().GetList(gomock.Any(), arg.params).Return(contract.GetListResponse{}, nil).Times(1)
+			},
+		},
 		{
 			name: "error",
 			args: args{
@@ -176,7 +250,7 @@ func TestGetListMovieHandler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc(tt.args)
 
-			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing"+tt.query, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -192,6 +266,77 @@ func TestGetListMovieHandler(t *testing.T) {
 	}
 }
 
+func TestBatchGetMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error empty ids",
+			body:       `{"ids": []}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error invalid id",
+			body:       `{"ids": [1, 0]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error too many ids",
+			body:       `{"ids": [` + strings.Repeat("1, ", 100) + `1]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error internal server",
+			body: `{"ids": [3, 1]}`,
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().BatchGet(gomock.Any(), contract.BatchGetMovieRequest{IDs: []int64{3, 1}}).Return(nil, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			body: `{"ids": [3, 1]}`,
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().BatchGet(gomock.Any(), contract.BatchGetMovieRequest{IDs: []int64{3, 1}}).Return([]*contract.BatchGetMovieResponse{
+					{ID: 3, Found: true, Movie: &contract.MovieResponse{ID: 3}},
+					{ID: 1},
+				}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(BatchGetMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
 func TestCreateMovieHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -201,7 +346,7 @@ func TestCreateMovieHandler(t *testing.T) {
 	mockRequest := contract.MovieRequest{
 		Title:       "test-name",
 		Description: "test-description",
-		Rating:      1,
+		Rating:      float32Ptr(1),
 		Image:       "test-image",
 	}
 
@@ -225,7 +370,7 @@ func TestCreateMovieHandler(t *testing.T) {
 				params: contract.MovieRequest{
 					Title:       "",
 					Description: "",
-					Rating:      0,
+					Rating:      nil,
 					Image:       "",
 				},
 			},
@@ -247,6 +392,19 @@ func TestCreateMovieHandler(t *testing.T) {
 			wantErr:    true,
 			statusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "error duplicate title",
+			args: args{
+				ctx:    context.Background(),
+				params: mockRequest,
+			},
+			mockFunc: func(arg args) {
+				mockMovieSvc.EXPECT().Create(gomock.Any(), arg.params).Return(contract.MovieResponse{}, appErr.ErrDuplicatemovie).Times(1)
+			},
+			want:       contract.MovieResponse{},
+			wantErr:    true,
+			statusCode: http.StatusConflict,
+		},
 		{
 			name: "success",
 			args: args{
@@ -294,10 +452,162 @@ func TestUpdateMovieHandler(t *testing.T) {
 
 	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
 
+	title := "test-name"
+	var clearRating float32
+
+	type args struct {
+		ctx         context.Context
+		id          int
+		body        string
+		contentType string
+		params      contract.MoviePatchRequest
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		mockFunc   func(arg args)
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name: "error bad request id",
+			args: args{
+				ctx:  context.Background(),
+				body: `{}`,
+			},
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+			mockFunc:   func(arg args) {},
+		},
+		{
+			name: "error unsupported content type",
+			args: args{
+				ctx:         context.Background(),
+				body:        `{"title": "test-name"}`,
+				contentType: "text/plain",
+			},
+			statusCode: http.StatusUnsupportedMediaType,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {},
+		},
+		{
+			name: "error patch is not an object",
+			args: args{
+				ctx:         context.Background(),
+				body:        `["title"]`,
+				contentType: contract.MergePatchContentType,
+			},
+			statusCode: http.StatusBadRequest,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {},
+		},
+		{
+			name: "error clear title",
+			args: args{
+				ctx:         context.Background(),
+				body:        `{"title": null}`,
+				contentType: contract.MergePatchContentType,
+			},
+			statusCode: http.StatusBadRequest,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {},
+		},
+		{
+			name: "error internal server",
+			args: args{
+				ctx:         context.Background(),
+				id:          1,
+				body:        `{"title": "Test-Name"}`,
+				contentType: contract.MergePatchContentType,
+				params:      contract.MoviePatchRequest{Title: &title},
+			},
+			statusCode: http.StatusInternalServerError,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {
+				mockMovieSvc.EXPECT().Update(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "error id not found",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				body:   `{"title": "test-name"}`,
+				params: contract.MoviePatchRequest{Title: &title},
+			},
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {
+				mockMovieSvc.EXPECT().Update(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, appErr.ErrMovieIdNotFound).Times(1)
+			},
+		},
+		{
+			name: "success null clear field",
+			args: args{
+				ctx:         context.Background(),
+				id:          1,
+				body:        `{"rating": null, "genre_ids": null}`,
+				contentType: contract.MergePatchContentType,
+				params:      contract.MoviePatchRequest{Rating: &clearRating, GenreIDs: []int64{}},
+			},
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {
+				mockMovieSvc.EXPECT().Update(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.args)
+
+			req, err := http.NewRequest(http.MethodPatch, "/just/for/testing", strings.NewReader(tt.args.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.args.contentType != "" {
+				req.Header.Set("Content-Type", tt.args.contentType)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(UpdateMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestReplaceMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
 	mockRequest := contract.MovieRequest{
 		Title:       "test-name",
 		Description: "test-description",
-		Rating:      1,
+		Rating:      float32Ptr(1),
 		Image:       "test-image",
 	}
 
@@ -336,7 +646,7 @@ func TestUpdateMovieHandler(t *testing.T) {
 				params: contract.MovieRequest{
 					Title:       "",
 					Description: "",
-					Rating:      0,
+					Rating:      nil,
 					Image:       "",
 				},
 			},
@@ -348,6 +658,21 @@ func TestUpdateMovieHandler(t *testing.T) {
 			},
 			mockFunc: func(arg args) {},
 		},
+		{
+			name: "error rating out of range",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				params: contract.MovieRequest{Title: "test-name", Rating: float32Ptr(11)},
+			},
+			want:       contract.MovieResponse{},
+			wantErr:    true,
+			statusCode: http.StatusBadRequest,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {},
+		},
 		{
 			name: "error internal server",
 			args: args{
@@ -362,7 +687,7 @@ func TestUpdateMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Update(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, assert.AnError).Times(1)
+				mockMovieSvc.EXPECT().Replace(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, assert.AnError).Times(1)
 			},
 		},
 		{
@@ -379,7 +704,7 @@ func TestUpdateMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Update(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, appErr.ErrMovieIdNotFound).Times(1)
+				mockMovieSvc.EXPECT().Replace(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, appErr.ErrMovieIdNotFound).Times(1)
 			},
 		},
 		{
@@ -396,7 +721,24 @@ func TestUpdateMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Update(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, nil).Times(1)
+				mockMovieSvc.EXPECT().Replace(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name: "success zero rating",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				params: contract.MovieRequest{Title: "test-name", Rating: float32Ptr(0)},
+			},
+			want:       contract.MovieResponse{},
+			wantErr:    false,
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func(arg args) {
+				mockMovieSvc.EXPECT().Replace(gomock.Any(), arg.params, arg.id).Return(contract.MovieResponse{}, nil).Times(1)
 			},
 		},
 	}
@@ -410,7 +752,7 @@ func TestUpdateMovieHandler(t *testing.T) {
 				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.args.params)
 				return
 			}
-			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			req, err := http.NewRequest(http.MethodPut, "/just/for/testing", reader)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -418,7 +760,7 @@ func TestUpdateMovieHandler(t *testing.T) {
 			req = contract.AddParameters(req, tt.parameter)
 
 			rr := httptest.NewRecorder()
-			handler := http.HandlerFunc(UpdateMovieHandler(mockMovieSvc))
+			handler := http.HandlerFunc(ReplaceMovieHandler(mockMovieSvc))
 			handler.ServeHTTP(rr, req)
 
 			if status := rr.Code; status != tt.statusCode {
@@ -474,7 +816,7 @@ func TestDeleteMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Delete(gomock.Any(), arg.id).Return(appErr.ErrMovieIdNotFound).Times(1)
+				mockMovieSvc.EXPECT().Delete(gomock.Any(), arg.id, contract.ETagMatch{}).Return(appErr.ErrMovieIdNotFound).Times(1)
 			},
 		},
 		{
@@ -490,7 +832,7 @@ func TestDeleteMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Delete(gomock.Any(), arg.id).Return(assert.AnError).Times(1)
+				mockMovieSvc.EXPECT().Delete(gomock.Any(), arg.id, contract.ETagMatch{}).Return(assert.AnError).Times(1)
 			},
 		},
 		{
@@ -506,7 +848,7 @@ func TestDeleteMovieHandler(t *testing.T) {
 				"id": "1",
 			},
 			mockFunc: func(arg args) {
-				mockMovieSvc.EXPECT().Delete(gomock.Any(), arg.id).Return(nil).Times(1)
+				mockMovieSvc.EXPECT().Delete(gomock.Any(), arg.id, contract.ETagMatch{}).Return(nil).Times(1)
 			},
 		},
 	}
@@ -532,3 +874,556 @@ func TestDeleteMovieHandler(t *testing.T) {
 		})
 	}
 }
+
+func newImageRequest(t *testing.T, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile(contract.ImageFormField, "poster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	req, err := http.NewRequest(http.MethodPost, "/just/for/testing", &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestUploadMovieImageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	mockPNG := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error unsupported image type",
+			data:       []byte("<svg></svg>"),
+			mockFunc:   func() {},
+			statusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "error image too large",
+			data:       append(mockPNG, make([]byte, contract.MaxImageSize)...),
+			mockFunc:   func() {},
+			statusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "error invalid image",
+			data: mockPNG,
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().UploadImage(gomock.Any(), contract.ImageRequest{Data: mockPNG, ContentType: "image/png"}, 1).
+					Return(contract.MovieResponse{}, appErr.ErrInvalidImage).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "success",
+			data: mockPNG,
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().UploadImage(gomock.Any(), contract.ImageRequest{Data: mockPNG, ContentType: "image/png"}, 1).
+					Return(contract.MovieResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := newImageRequest(t, tt.data)
+			req = contract.AddParameters(req, map[string]string{"id": "1"})
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(UploadMovieImageHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestHardDeleteMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name        string
+		query       string
+		permissions []string
+		mockFunc    func()
+		statusCode  int
+	}{
+		{
+			name:       "error invalid hard",
+			query:      "?hard=maybe",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "error forbidden without purge permission",
+			query:       "?hard=true",
+			permissions: []string{entity.PermissionMovieDelete},
+			mockFunc:    func() {},
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "error movie has bookings",
+			query:       "?hard=true",
+			permissions: []string{entity.PermissionMovieDelete, entity.PermissionMoviePurge},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Purge(gomock.Any(), 1, contract.ETagMatch{}).Return(appErr.ErrMovieHasBookings).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:        "success soft delete when hard is false",
+			query:       "?hard=false",
+			permissions: []string{entity.PermissionMovieDelete},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Delete(gomock.Any(), 1, contract.ETagMatch{}).Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:        "success",
+			query:       "?hard=true",
+			permissions: []string{entity.PermissionMovieDelete, entity.PermissionMoviePurge},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Purge(gomock.Any(), 1, contract.ETagMatch{}).Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodDelete, "/just/for/testing"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = req.WithContext(context.WithValue(req.Context(), auth.CtxKeyPermissions, tt.permissions))
+			req = contract.AddParameters(req, map[string]string{"id": "1"})
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(DeleteMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestRestoreMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  nil,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error movie is not deleted",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Restore(gomock.Any(), 1).Return(contract.MovieResponse{}, appErr.ErrMovieIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "error title is taken",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Restore(gomock.Any(), 1).Return(contract.MovieResponse{}, appErr.ErrDuplicatemovie).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Restore(gomock.Any(), 1).Return(contract.MovieResponse{ID: 1}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(RestoreMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGetDuplicateMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error threshold below trigram threshold",
+			query:      "?threshold=0.1",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error threshold is not a number",
+			query:      "?threshold=high",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "error internal server",
+			query: "",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().GetDuplicates(gomock.Any(), contract.GetDuplicateParam{Page: 1, Limit: 10, Threshold: contract.DefaultDuplicateThreshold}).
+					Return(contract.GetListDuplicateResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "success",
+			query: "?page=2&limit=5&threshold=0.8",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().GetDuplicates(gomock.Any(), contract.GetDuplicateParam{Page: 2, Limit: 5, Offset: 5, Threshold: 0.8}).
+					Return(contract.GetListDuplicateResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetDuplicateMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestSearchMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error missing query",
+			query:      "",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error query without word",
+			query:      "?q=%22*%27%22+%26",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error unknown language",
+			query:      "?q=avengers&lang=fr",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "error internal server",
+			query: "?q=avengers",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Search(gomock.Any(), contract.SearchParam{Page: 1, Limit: 10, Query: "'avengers'", Config: "simple"}).
+					Return(contract.GetListSearchResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "success phrase and prefix",
+			query: "?lang=ID&page=2&limit=5&q=" + url.QueryEscape(`"pengabdi setan" spider-man kuntil* 'or' 1=1`),
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Search(gomock.Any(), contract.SearchParam{
+					Page:   2,
+					Limit:  5,
+					Offset: 5,
+					Query:  "('pengabdi' <-> 'setan') & ('spider' <-> 'man') & 'kuntil':* & 'or' & ('1' <-> '1')",
+					Config: "indonesian",
+				}).Return(contract.GetListSearchResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(SearchMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestSuggestMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error blank query",
+			query:      "?q=+++",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error limit too large",
+			query:      "?q=aven&limit=50",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "error internal server",
+			query: "?q=aven",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Suggest(gomock.Any(), contract.SuggestParam{Query: "aven", Limit: contract.DefaultSuggestLimit}).
+					Return(nil, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "success normalize query",
+			query: "?q=" + url.QueryEscape("  The   AVEN") + "&limit=5",
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Suggest(gomock.Any(), contract.SuggestParam{Query: "the aven", Limit: 5}).
+					Return([]*contract.SuggestResponse{{ID: 1, Title: "the avengers"}}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(SuggestMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestMovieConditionalRequestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		method     string
+		header     map[string]string
+		mockFunc   func()
+		handler    http.HandlerFunc
+		statusCode int
+		etag       string
+	}{
+		{
+			name:   "get not modified",
+			method: http.MethodGet,
+			header: map[string]string{"If-None-Match": `"1", W/"3"`},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Get(gomock.Any(), 1, contract.GetMovieParam{}).Return(contract.MovieResponse{ID: 1, Version: 3}, nil).Times(1)
+			},
+			handler:    GetMovieHandler(mockMovieSvc),
+			statusCode: http.StatusNotModified,
+			etag:       `"3"`,
+		},
+		{
+			name:   "get modified",
+			method: http.MethodGet,
+			header: map[string]string{"If-None-Match": `"2"`},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Get(gomock.Any(), 1, contract.GetMovieParam{}).Return(contract.MovieResponse{ID: 1, Version: 3}, nil).Times(1)
+			},
+			handler:    GetMovieHandler(mockMovieSvc),
+			statusCode: http.StatusOK,
+			etag:       `"3"`,
+		},
+		{
+			name:       "update invalid if match",
+			method:     http.MethodPatch,
+			header:     map[string]string{"If-Match": `3`},
+			mockFunc:   func() {},
+			handler:    UpdateMovieHandler(mockMovieSvc),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "update precondition failed",
+			method: http.MethodPatch,
+			header: map[string]string{"If-Match": `"2"`},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Update(gomock.Any(), gomock.Any(), 1).
+					DoAndReturn(func(ctx context.Context, request contract.MoviePatchRequest, id int) (contract.MovieResponse, error) {
+						assert.Equal(t, contract.ETagMatch{Versions: []int{2}}, request.IfMatch)
+						return contract.MovieResponse{}, appErr.ErrPreconditionFailed
+					}).Times(1)
+			},
+			handler:    UpdateMovieHandler(mockMovieSvc),
+			statusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "update success",
+			method: http.MethodPatch,
+			header: map[string]string{"If-Match": `"2"`},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Update(gomock.Any(), gomock.Any(), 1).Return(contract.MovieResponse{ID: 1, Version: 3}, nil).Times(1)
+			},
+			handler:    UpdateMovieHandler(mockMovieSvc),
+			statusCode: http.StatusOK,
+			etag:       `"3"`,
+		},
+		{
+			name:   "delete precondition required",
+			method: http.MethodDelete,
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Delete(gomock.Any(), 1, contract.ETagMatch{}).Return(appErr.ErrPreconditionRequired).Times(1)
+			},
+			handler:    DeleteMovieHandler(mockMovieSvc),
+			statusCode: http.StatusPreconditionRequired,
+		},
+		{
+			name:   "delete any version",
+			method: http.MethodDelete,
+			header: map[string]string{"If-Match": `*`},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Delete(gomock.Any(), 1, contract.ETagMatch{Any: true}).Return(nil).Times(1)
+			},
+			handler:    DeleteMovieHandler(mockMovieSvc),
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			body, err := contract.MarshalToReader(contract.MovieRequest{Title: "title", Rating: float32Ptr(1)})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := http.NewRequest(tt.method, "/just/for/testing", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+
+			req = contract.AddParameters(req, map[string]string{"id": "1"})
+
+			rr := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+			assert.Equal(t, tt.etag, rr.Header().Get(contract.ETagHeader))
+		})
+	}
+}
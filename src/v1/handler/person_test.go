@@ -0,0 +1,419 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestGetPersonHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonSvc := mock_handler.NewMockPersonService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request",
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+			mockFunc:   func() {},
+		},
+		{
+			name:       "error id not found",
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().Get(gomock.Any(), 1).Return(contract.PersonResponse{}, appErr.ErrPersonIdNotFound).Times(1)
+			},
+		},
+		{
+			name:       "error internal server",
+			statusCode: http.StatusInternalServerError,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().Get(gomock.Any(), 1).Return(contract.PersonResponse{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().Get(gomock.Any(), 1).Return(contract.PersonResponse{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetPersonHandler(mockPersonSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGetListPersonHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonSvc := mock_handler.NewMockPersonService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error",
+			statusCode: http.StatusInternalServerError,
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().GetList(gomock.Any(), gomock.Any()).Return(contract.GetListPersonResponse{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().GetList(gomock.Any(), gomock.Any()).Return(contract.GetListPersonResponse{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetListPersonHandler(mockPersonSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestCreatePersonHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonSvc := mock_handler.NewMockPersonService(ctrl)
+
+	mockRequest := contract.PersonRequest{
+		Name: "Joko Anwar",
+	}
+
+	tests := []struct {
+		name       string
+		params     contract.PersonRequest
+		mockFunc   func(params contract.PersonRequest)
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			params:     contract.PersonRequest{},
+			mockFunc:   func(params contract.PersonRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "error internal server",
+			params: mockRequest,
+			mockFunc: func(params contract.PersonRequest) {
+				mockPersonSvc.EXPECT().Create(gomock.Any(), params).Return(contract.PersonResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			mockFunc: func(params contract.PersonRequest) {
+				mockPersonSvc.EXPECT().Create(gomock.Any(), params).Return(contract.PersonResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreatePersonHandler(mockPersonSvc))
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestUpdatePersonHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonSvc := mock_handler.NewMockPersonService(ctrl)
+
+	mockRequest := contract.PersonRequest{
+		Name: "Joko Anwar",
+	}
+
+	tests := []struct {
+		name       string
+		params     contract.PersonRequest
+		mockFunc   func(params contract.PersonRequest)
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request id",
+			params:     mockRequest,
+			mockFunc:   func(params contract.PersonRequest) {},
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+		},
+		{
+			name:       "error bad request payload",
+			params:     contract.PersonRequest{},
+			mockFunc:   func(params contract.PersonRequest) {},
+			statusCode: http.StatusBadRequest,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+		{
+			name:   "error id not found",
+			params: mockRequest,
+			mockFunc: func(params contract.PersonRequest) {
+				mockPersonSvc.EXPECT().Update(gomock.Any(), params, 1).Return(contract.PersonResponse{}, appErr.ErrPersonIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			mockFunc: func(params contract.PersonRequest) {
+				mockPersonSvc.EXPECT().Update(gomock.Any(), params, 1).Return(contract.PersonResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPatch, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(UpdatePersonHandler(mockPersonSvc))
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v",
+					status, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestDeletePersonHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonSvc := mock_handler.NewMockPersonService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+		},
+		{
+			name: "error id not found",
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().Delete(gomock.Any(), 1).Return(appErr.ErrPersonIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().Delete(gomock.Any(), 1).Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodDelete, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(DeletePersonHandler(mockPersonSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGetPersonMoviesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonSvc := mock_handler.NewMockPersonService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request",
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+			mockFunc:   func() {},
+		},
+		{
+			name:       "error id not found",
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().GetMovies(gomock.Any(), 1).Return(nil, appErr.ErrPersonIdNotFound).Times(1)
+			},
+		},
+		{
+			name:       "error internal server",
+			statusCode: http.StatusInternalServerError,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().GetMovies(gomock.Any(), 1).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockPersonSvc.EXPECT().GetMovies(gomock.Any(), 1).Return([]*contract.PersonMovieResponse{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetPersonMoviesHandler(mockPersonSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
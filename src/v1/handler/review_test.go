@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestGetListReviewHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewSvc := mock_handler.NewMockReviewService(ctrl)
+
+	tests := []struct {
+		name       string
+		mockFunc   func()
+		statusCode int
+		parameter  map[string]string
+	}{
+		{
+			name:       "error bad request",
+			statusCode: http.StatusBadRequest,
+			parameter:  nil,
+			mockFunc:   func() {},
+		},
+		{
+			name:       "error movie id not found",
+			statusCode: http.StatusUnprocessableEntity,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().GetList(gomock.Any(), 1, gomock.Any()).Return(contract.GetListReviewResponse{}, appErr.ErrMovieIdNotFound).Times(1)
+			},
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			parameter: map[string]string{
+				"id": "1",
+			},
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().GetList(gomock.Any(), 1, gomock.Any()).Return(contract.GetListReviewResponse{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			r := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetListReviewHandler(mockReviewSvc))
+			handler.ServeHTTP(r, req)
+
+			if r.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", r.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestCreateReviewHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewSvc := mock_handler.NewMockReviewService(ctrl)
+
+	mockRequest := contract.ReviewRequest{
+		Score:   8,
+		Content: "scary",
+	}
+
+	expectedRequest := mockRequest
+	expectedRequest.UserID = "user-1"
+
+	tests := []struct {
+		name       string
+		params     contract.ReviewRequest
+		userID     string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error missing user",
+			params:     mockRequest,
+			userID:     "",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error score out of range",
+			params:     contract.ReviewRequest{Score: 11},
+			userID:     "user-1",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "error movie id not found",
+			params: mockRequest,
+			userID: "user-1",
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().Create(gomock.Any(), expectedRequest, 1).Return(contract.ReviewResponse{}, appErr.ErrMovieIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "error duplicate",
+			params: mockRequest,
+			userID: "user-1",
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().Create(gomock.Any(), expectedRequest, 1).Return(contract.ReviewResponse{}, appErr.ErrDuplicateReview).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:   "error internal server",
+			params: mockRequest,
+			userID: "user-1",
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().Create(gomock.Any(), expectedRequest, 1).Return(contract.ReviewResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			userID: "user-1",
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().Create(gomock.Any(), expectedRequest, 1).Return(contract.ReviewResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = withSubject(req, tt.userID)
+			req = contract.AddParameters(req, map[string]string{"id": "1"})
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreateReviewHandler(mockReviewSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestDeleteReviewHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewSvc := mock_handler.NewMockReviewService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		userID     string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request review id",
+			parameter:  map[string]string{"id": "1"},
+			userID:     "user-1",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error missing user",
+			parameter:  map[string]string{"id": "1", "review_id": "2"},
+			userID:     "",
+			mockFunc:   func() {},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:      "error review id not found",
+			parameter: map[string]string{"id": "1", "review_id": "2"},
+			userID:    "user-1",
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().Delete(gomock.Any(), 1, 2, "user-1").Return(appErr.ErrReviewIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": "1", "review_id": "2"},
+			userID:    "user-1",
+			mockFunc: func() {
+				mockReviewSvc.EXPECT().Delete(gomock.Any(), 1, 2, "user-1").Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodDelete, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = withSubject(req, tt.userID)
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(DeleteReviewHandler(mockReviewSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestGetMovieHistoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  nil,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error movie id not found",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().GetHistory(gomock.Any(), 1, gomock.Any()).Return(contract.GetListRevisionResponse{}, appErr.ErrMovieIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().GetHistory(gomock.Any(), 1, gomock.Any()).Return(contract.GetListRevisionResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetMovieHistoryHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestRevertMovieHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieSvc := mock_handler.NewMockMovieService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  map[string]string{"id": "1", "revision": "0"},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error revision not found",
+			parameter: map[string]string{"id": "1", "revision": "2"},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Revert(gomock.Any(), 1, 2).Return(contract.MovieResponse{}, appErr.ErrMovieRevisionNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"id": "1", "revision": "2"},
+			mockFunc: func() {
+				mockMovieSvc.EXPECT().Revert(gomock.Any(), 1, 2).Return(contract.MovieResponse{ID: 1}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(RevertMovieHandler(mockMovieSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Risuii/movie/src/errors"
+	"github.com/Risuii/movie/src/middleware/response"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+func GetRoleHandler(svc RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, err := contract.ValidateRoleNameParamRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		data, err := svc.Get(r.Context(), name)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrRoleNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func GetListRoleHandler(svc RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := svc.GetList(r.Context())
+		if err != nil {
+			log.Println(err)
+			response.JSONInternalErrorResponse(r.Context(), w)
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, data)
+	}
+}
+
+func CreateRoleHandler(svc RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roleRequest, err := contract.BuildAndValidateRoleRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.Create(r.Context(), roleRequest)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrDuplicateRole:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+// UpdateRoleHandler replace description and permissions of the role
+func UpdateRoleHandler(svc RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := contract.ValidateRoleNameParamRequest(r); err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		roleRequest, err := contract.BuildAndValidateRoleRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		res, err := svc.Update(r.Context(), roleRequest)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrRoleNotFound, errors.ErrRoleAdminLockout:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, res)
+	}
+}
+
+func DeleteRoleHandler(svc RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, err := contract.ValidateRoleNameParamRequest(r)
+		if err != nil {
+			response.JSONBadRequestResponse(r.Context(), w)
+			return
+		}
+
+		err = svc.Delete(r.Context(), name)
+		if err != nil {
+			log.Println(err)
+			switch err {
+			case errors.ErrRoleNotFound:
+				response.JSONUnprocessableEntity(r.Context(), w, err)
+			case errors.ErrRoleBuiltIn:
+				response.JSONError(r.Context(), w, http.StatusConflict, err)
+			default:
+				response.JSONInternalErrorResponse(r.Context(), w)
+			}
+			return
+		}
+
+		response.JSONSuccessResponse(r.Context(), w, "success delete role")
+	}
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestCreateRoleHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleSvc := mock_handler.NewMockRoleService(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error invalid name",
+			body:       `{"name":"curator!","permissions":["movie:update"]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error unknown permission",
+			body:       `{"name":"curator","permissions":["movie:publish"]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error duplicate role",
+			body: `{"name":"curator","permissions":["movie:update"]}`,
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(contract.RoleResponse{}, appErr.ErrDuplicateRole).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name: "success normalize request",
+			body: `{"name":" Curator ","description":" curate ","permissions":["movie:update","movie:create","movie:update"]}`,
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Create(gomock.Any(), contract.RoleRequest{
+					Name:        "curator",
+					Description: "curate",
+					Permissions: []string{"movie:create", "movie:update"},
+				}).Return(contract.RoleResponse{Name: "curator"}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreateRoleHandler(mockRoleSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestUpdateRoleHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleSvc := mock_handler.NewMockRoleService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		body       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  nil,
+			body:       `{}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error admin lockout",
+			parameter: map[string]string{"name": "admin"},
+			body:      `{"permissions":["movie:create"]}`,
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Update(gomock.Any(), gomock.Any()).Return(contract.RoleResponse{}, appErr.ErrRoleAdminLockout).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "error internal server",
+			parameter: map[string]string{"name": "editor"},
+			body:      `{"permissions":[]}`,
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Update(gomock.Any(), gomock.Any()).Return(contract.RoleResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "success name from path",
+			parameter: map[string]string{"name": "editor"},
+			body:      `{"name":"admin","permissions":["movie:update"]}`,
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Update(gomock.Any(), contract.RoleRequest{
+					Name:        "editor",
+					Permissions: []string{"movie:update"},
+				}).Return(contract.RoleResponse{Name: "editor"}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodPut, "/just/for/testing", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(UpdateRoleHandler(mockRoleSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestDeleteRoleHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleSvc := mock_handler.NewMockRoleService(ctrl)
+
+	tests := []struct {
+		name       string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			parameter:  nil,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error role not found",
+			parameter: map[string]string{"name": "curator"},
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Delete(gomock.Any(), "curator").Return(appErr.ErrRoleNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "error built in role",
+			parameter: map[string]string{"name": "viewer"},
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Delete(gomock.Any(), "viewer").Return(appErr.ErrRoleBuiltIn).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:      "success",
+			parameter: map[string]string{"name": "curator"},
+			mockFunc: func() {
+				mockRoleSvc.EXPECT().Delete(gomock.Any(), "curator").Return(nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodDelete, "/just/for/testing", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(DeleteRoleHandler(mockRoleSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestCreateScreenHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCinemaSvc := mock_handler.NewMockCinemaService(ctrl)
+
+	mockRequest := contract.ScreenRequest{
+		Name:    "Studio 1",
+		SeatMap: []contract.SeatRow{{Row: "A", Seats: 10}},
+	}
+
+	tests := []struct {
+		name       string
+		params     contract.ScreenRequest
+		mockFunc   func(params contract.ScreenRequest)
+		statusCode int
+	}{
+		{
+			name:       "error empty seat map",
+			params:     contract.ScreenRequest{Name: "Studio 1"},
+			mockFunc:   func(params contract.ScreenRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error duplicate seat row",
+			params: contract.ScreenRequest{
+				Name:    "Studio 1",
+				SeatMap: []contract.SeatRow{{Row: "A", Seats: 10}, {Row: "a", Seats: 8}},
+			},
+			mockFunc:   func(params contract.ScreenRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "error cinema id not found",
+			params: mockRequest,
+			mockFunc: func(params contract.ScreenRequest) {
+				mockCinemaSvc.EXPECT().CreateScreen(gomock.Any(), params, 1).Return(contract.ScreenResponse{}, appErr.ErrCinemaIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "error duplicate screen",
+			params: mockRequest,
+			mockFunc: func(params contract.ScreenRequest) {
+				mockCinemaSvc.EXPECT().CreateScreen(gomock.Any(), params, 1).Return(contract.ScreenResponse{}, appErr.ErrDuplicateScreen).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			mockFunc: func(params contract.ScreenRequest) {
+				mockCinemaSvc.EXPECT().CreateScreen(gomock.Any(), params, 1).Return(contract.ScreenResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, map[string]string{"id": "1"})
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreateScreenHandler(mockCinemaSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_handler "github.com/Risuii/movie/src/v1/handler/mock"
+)
+
+func TestCreateShowtimeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShowtimeSvc := mock_handler.NewMockShowtimeService(ctrl)
+
+	mockRequest := contract.ShowtimeRequest{
+		MovieID:   1,
+		ScreenID:  2,
+		StartTime: time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+		Price:     50000,
+	}
+
+	tests := []struct {
+		name       string
+		params     contract.ShowtimeRequest
+		mockFunc   func(params contract.ShowtimeRequest)
+		statusCode int
+	}{
+		{
+			name:       "error bad request",
+			params:     contract.ShowtimeRequest{},
+			mockFunc:   func(params contract.ShowtimeRequest) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "error movie runtime not set",
+			params: mockRequest,
+			mockFunc: func(params contract.ShowtimeRequest) {
+				mockShowtimeSvc.EXPECT().Create(gomock.Any(), params).Return(contract.ShowtimeResponse{}, appErr.ErrMovieRuntimeNotSet).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "error overlap",
+			params: mockRequest,
+			mockFunc: func(params contract.ShowtimeRequest) {
+				mockShowtimeSvc.EXPECT().Create(gomock.Any(), params).Return(contract.ShowtimeResponse{}, appErr.ErrShowtimeOverlap).Times(1)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:   "error internal server",
+			params: mockRequest,
+			mockFunc: func(params contract.ShowtimeRequest) {
+				mockShowtimeSvc.EXPECT().Create(gomock.Any(), params).Return(contract.ShowtimeResponse{}, assert.AnError).Times(1)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "success",
+			params: mockRequest,
+			mockFunc: func(params contract.ShowtimeRequest) {
+				mockShowtimeSvc.EXPECT().Create(gomock.Any(), params).Return(contract.ShowtimeResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.params)
+
+			reader, err := contract.MarshalToReader(tt.params)
+			if err != nil {
+				t.Errorf("Error when try to marshal params. error = %v, data = %v", err, tt.params)
+				return
+			}
+			req, err := http.NewRequest(http.MethodPost, "/just/for/testing", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(CreateShowtimeHandler(mockShowtimeSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGetMovieShowtimesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShowtimeSvc := mock_handler.NewMockShowtimeService(ctrl)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		query      string
+		parameter  map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:       "error bad request id",
+			parameter:  nil,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "error invalid date",
+			query:      "?date=01-01-2024",
+			parameter:  map[string]string{"id": "1"},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error movie id not found",
+			query:     "?date=2024-01-01",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				mockShowtimeSvc.EXPECT().GetByMovie(gomock.Any(), 1, gomock.Any()).Return(nil, appErr.ErrMovieIdNotFound).Times(1)
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "success",
+			query:     "?date=2024-01-01&city=Jakarta",
+			parameter: map[string]string{"id": "1"},
+			mockFunc: func() {
+				filter := contract.ShowtimeFilter{From: from, To: from.AddDate(0, 0, 1), City: "Jakarta"}
+				mockShowtimeSvc.EXPECT().GetByMovie(gomock.Any(), 1, filter).Return([]*contract.ShowtimeResponse{}, nil).Times(1)
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req, err := http.NewRequest(http.MethodGet, "/just/for/testing"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = contract.AddParameters(req, tt.parameter)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(GetMovieShowtimesHandler(mockShowtimeSvc))
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.statusCode)
+			}
+		})
+	}
+}
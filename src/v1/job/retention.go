@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RetentionInterval is how often soft deleted movie is checked for purge
+const RetentionInterval = time.Hour
+
+type MoviePurger interface {
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+}
+
+// RunMovieRetention purge movie soft deleted more than retentionDays ago on
+// start and then every RetentionInterval until ctx is done. Purge is idempotent
+// so it is safe to run on every instance
+func RunMovieRetention(ctx context.Context, svc MoviePurger, retentionDays int) {
+	ticker := time.NewTicker(RetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		before := time.Now().AddDate(0, 0, -retentionDays)
+
+		count, err := svc.PurgeDeleted(ctx, before)
+		if err != nil {
+			log.Println("movie retention err: ", err)
+		} else if count > 0 {
+			log.Printf("movie retention purged %d movie deleted before %s", count, before.Format(time.RFC3339))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/v1/job"
+)
+
+// StartJobs run background job in their own goroutine, job that is
+// not configured is skipped
+func StartJobs(ctx context.Context, deps *Dependency) {
+	if days := app.Config().Movie.RetentionDays; days > 0 {
+		go job.RunMovieRetention(ctx, deps.Services.mSvc, days)
+	}
+}
@@ -2,23 +2,185 @@ package v1
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/Risuii/movie/src/entity"
 	"github.com/Risuii/movie/src/v1/handler"
 	"github.com/go-chi/chi/v5"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
+const (
+	// RequestTimeout cancel the context of a request that run longer
+	RequestTimeout = 60 * time.Second
+
+	// ExportTimeout is the deadline of the movie export, the whole catalog is streamed in one request
+	ExportTimeout = 30 * time.Minute
+)
+
+// Router register every route, reads are public except the audit of movie and the user own hold and booking.
+// Route that mutate data need a verified token, movie, catalog and cinema route also need the permission
+// of the role of the token while review, hold and booking only need the user.
+// Every route group is rate limited per identity before any other route middleware, partner authenticate
+// with api key instead of token right after the limiter
 func Router(r *chi.Mux, deps *Dependency) {
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+	// every route is bounded by RequestTimeout except the export that stream the whole catalog
+	timed := r.With(chimiddleware.Timeout(RequestTimeout))
+
+	timed.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("ok"))
 	})
 
+	// Uploaded image, only when storage url is served by this service
+
+	if path := deps.Repositories.store.MountPath(); path != "" {
+		fs := http.StripPrefix(path, http.FileServer(deps.Repositories.store.FileSystem()))
+		timed.Handle(path+"/*", fs)
+	}
+
+	// Movie export, it is matched before the movie route
+
+	r.With(deps.Middlewares.rateLimit(rateLimitMovies), deps.Middlewares.apiKey, chimiddleware.Timeout(ExportTimeout)).
+		Get("/Movies/export", handler.ExportMovieHandler(deps.Services.mSvc))
+
 	// Movie
 
-	r.Route("/Movies", func(v1 chi.Router) {
+	timed.Route("/Movies", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitMovies))
+		v1.Use(deps.Middlewares.apiKey)
 		v1.Get("/{id}", handler.GetMovieHandler(deps.Services.mSvc))
 		v1.Get("/", handler.GetListMovieHandler(deps.Services.mSvc))
-		v1.Post("/", handler.CreateMovieHandler(deps.Services.mSvc))
-		v1.Patch("/{id}", handler.UpdateMovieHandler(deps.Services.mSvc))
-		v1.Delete("/{id}", handler.DeleteMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieAudit)).Get("/deleted", handler.GetDeletedListMovieHandler(deps.Services.mSvc))
+		v1.Get("/duplicates", handler.GetDuplicateMovieHandler(deps.Services.mSvc))
+		v1.Get("/search", handler.SearchMovieHandler(deps.Services.mSvc))
+		v1.Get("/suggest", handler.SuggestMovieHandler(deps.Services.mSvc))
+		v1.Post("/batch-get", handler.BatchGetMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieUpdate), deps.Middlewares.authorize(entity.PermissionMovieDelete), deps.Middlewares.idempotency).Post("/bulk", handler.BulkMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionImportRun)).Post("/import", handler.ImportMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionImportRun)).Get("/import/{job_id}", handler.GetImportJobHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieCreate), deps.Middlewares.idempotency).Post("/", handler.CreateMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieUpdate)).Patch("/{id}", handler.UpdateMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieUpdate)).Put("/{id}", handler.ReplaceMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieDelete)).Delete("/{id}", handler.DeleteMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieDelete)).Post("/{id}/restore", handler.RestoreMovieHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieAudit)).Get("/{id}/history", handler.GetMovieHistoryHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieUpdate)).Post("/{id}/revert/{revision}", handler.RevertMovieHandler(deps.Services.mSvc))
+		v1.Get("/{id}/credits", handler.GetMovieCreditsHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieUpdate)).Put("/{id}/credits", handler.ReplaceMovieCreditsHandler(deps.Services.mSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionMovieUpdate)).Post("/{id}/image", handler.UploadMovieImageHandler(deps.Services.mSvc))
+		v1.Get("/{id}/reviews", handler.GetListReviewHandler(deps.Services.rSvc))
+		v1.With(deps.Middlewares.authenticated).Post("/{id}/reviews", handler.CreateReviewHandler(deps.Services.rSvc))
+		v1.With(deps.Middlewares.authenticated).Patch("/{id}/reviews/{review_id}", handler.UpdateReviewHandler(deps.Services.rSvc))
+		v1.With(deps.Middlewares.authenticated).Delete("/{id}/reviews/{review_id}", handler.DeleteReviewHandler(deps.Services.rSvc))
+		v1.Get("/{id}/showtimes", handler.GetMovieShowtimesHandler(deps.Services.sSvc))
+	})
+
+	// Genre
+
+	timed.Route("/Genres", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitCatalog))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.Get("/{id}", handler.GetGenreHandler(deps.Services.gSvc))
+		v1.Get("/", handler.GetListGenreHandler(deps.Services.gSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCatalogManage)).Post("/", handler.CreateGenreHandler(deps.Services.gSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCatalogManage)).Patch("/{id}", handler.UpdateGenreHandler(deps.Services.gSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCatalogManage)).Delete("/{id}", handler.DeleteGenreHandler(deps.Services.gSvc))
+	})
+
+	// People
+
+	timed.Route("/People", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitCatalog))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.Get("/{id}", handler.GetPersonHandler(deps.Services.pSvc))
+		v1.Get("/", handler.GetListPersonHandler(deps.Services.pSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCatalogManage)).Post("/", handler.CreatePersonHandler(deps.Services.pSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCatalogManage)).Patch("/{id}", handler.UpdatePersonHandler(deps.Services.pSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCatalogManage)).Delete("/{id}", handler.DeletePersonHandler(deps.Services.pSvc))
+		v1.Get("/{id}/movies", handler.GetPersonMoviesHandler(deps.Services.pSvc))
+	})
+
+	// Cinema
+
+	timed.Route("/Cinemas", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitCinemas))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.Get("/{id}", handler.GetCinemaHandler(deps.Services.cSvc))
+		v1.Get("/", handler.GetListCinemaHandler(deps.Services.cSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Post("/", handler.CreateCinemaHandler(deps.Services.cSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Patch("/{id}", handler.UpdateCinemaHandler(deps.Services.cSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Delete("/{id}", handler.DeleteCinemaHandler(deps.Services.cSvc))
+		v1.Get("/{id}/screens", handler.GetCinemaScreensHandler(deps.Services.cSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Post("/{id}/screens", handler.CreateScreenHandler(deps.Services.cSvc))
+		v1.Get("/{id}/schedule", handler.GetCinemaScheduleHandler(deps.Services.sSvc))
+	})
+
+	// Screen
+
+	timed.Route("/Screens", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitCinemas))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.Get("/{id}", handler.GetScreenHandler(deps.Services.cSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Patch("/{id}", handler.UpdateScreenHandler(deps.Services.cSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Delete("/{id}", handler.DeleteScreenHandler(deps.Services.cSvc))
+	})
+
+	// Showtime
+
+	timed.Route("/Showtimes", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitCinemas))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.Get("/{id}", handler.GetShowtimeHandler(deps.Services.sSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Post("/", handler.CreateShowtimeHandler(deps.Services.sSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Patch("/{id}", handler.UpdateShowtimeHandler(deps.Services.sSvc))
+		v1.With(deps.Middlewares.authorize(entity.PermissionCinemaManage)).Delete("/{id}", handler.DeleteShowtimeHandler(deps.Services.sSvc))
+		v1.Get("/{id}/seats", handler.GetShowtimeSeatsHandler(deps.Services.bSvc))
+	})
+
+	// Hold
+
+	timed.Route("/Holds", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitBooking))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.With(deps.Middlewares.authenticated).Post("/", handler.CreateHoldHandler(deps.Services.bSvc))
+		v1.With(deps.Middlewares.authenticated).Get("/{id}", handler.GetHoldHandler(deps.Services.bSvc))
+		v1.With(deps.Middlewares.authenticated).Delete("/{id}", handler.ReleaseHoldHandler(deps.Services.bSvc))
+	})
+
+	// Booking
+
+	timed.Route("/Bookings", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitBooking))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.With(deps.Middlewares.authenticated).Get("/{id}", handler.GetBookingHandler(deps.Services.bSvc))
+		v1.With(deps.Middlewares.authenticated).Get("/", handler.GetListBookingHandler(deps.Services.bSvc))
+		v1.With(deps.Middlewares.authenticated, deps.Middlewares.idempotency).Post("/", handler.CreateBookingHandler(deps.Services.bSvc))
+	})
+
+	// Role, admin only
+
+	timed.Route("/Roles", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitAdmin))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.Use(deps.Middlewares.authorize(entity.PermissionRoleManage))
+		v1.Get("/", handler.GetListRoleHandler(deps.Services.roSvc))
+		v1.Get("/{name}", handler.GetRoleHandler(deps.Services.roSvc))
+		v1.Post("/", handler.CreateRoleHandler(deps.Services.roSvc))
+		v1.Put("/{name}", handler.UpdateRoleHandler(deps.Services.roSvc))
+		v1.Delete("/{name}", handler.DeleteRoleHandler(deps.Services.roSvc))
+	})
+
+	// API key, admin only
+
+	timed.Route("/ApiKeys", func(v1 chi.Router) {
+		v1.Use(deps.Middlewares.rateLimit(rateLimitAdmin))
+		v1.Use(deps.Middlewares.apiKey)
+		v1.Use(deps.Middlewares.authorize(entity.PermissionAPIKeyManage))
+		v1.Get("/", handler.GetListAPIKeyHandler(deps.Services.aSvc))
+		v1.Get("/{id}", handler.GetAPIKeyHandler(deps.Services.aSvc))
+		v1.Post("/", handler.IssueAPIKeyHandler(deps.Services.aSvc))
+		v1.Post("/{id}/rotate", handler.RotateAPIKeyHandler(deps.Services.aSvc))
+		v1.Delete("/{id}", handler.RevokeAPIKeyHandler(deps.Services.aSvc))
 	})
 }
@@ -0,0 +1,268 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+const (
+	// keyPrefix start every key so a leaked key is easy to recognize by secret scanner
+	keyPrefix   = "mk_"
+	prefixBytes = 8
+	secretBytes = 32
+	timeLayout  = "2006-01-02 15:04:05"
+)
+
+type APIKeyService struct {
+	APIKeyRepo APIKeyRepository
+	now        func() time.Time
+}
+
+func InitAPIKeyService(aRepo APIKeyRepository) *APIKeyService {
+	return &APIKeyService{
+		APIKeyRepo: aRepo,
+		now:        time.Now,
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(timeLayout)
+}
+
+func mapperAPIKeyResponse(key *entity.APIKey) contract.APIKeyResponse {
+	scopes := []string(key.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return contract.APIKeyResponse{
+		ID:         int(key.Id),
+		Name:       key.Name,
+		Owner:      key.Owner,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		DailyQuota: key.DailyQuota,
+		ExpiresAt:  formatOptionalTime(key.ExpiresAt),
+		LastUsedAt: formatOptionalTime(key.LastUsedAt),
+		RevokedAt:  formatOptionalTime(key.RevokedAt),
+		CreatedAt:  key.CreatedAt.Format(timeLayout),
+		UpdatedAt:  key.UpdatedAt.Format(timeLayout),
+	}
+}
+
+// generateKey return the plain key mk_<prefix>_<secret> with its prefix and secret hash
+func generateKey() (key, prefix, secretHash string, err error) {
+	prefixRaw := make([]byte, prefixBytes)
+	if _, err = rand.Read(prefixRaw); err != nil {
+		return
+	}
+
+	secretRaw := make([]byte, secretBytes)
+	if _, err = rand.Read(secretRaw); err != nil {
+		return
+	}
+
+	prefix = hex.EncodeToString(prefixRaw)
+	secret := base64.RawURLEncoding.EncodeToString(secretRaw)
+
+	return keyPrefix + prefix + "_" + secret, prefix, hashSecret(secret), nil
+}
+
+// hashSecret use sha256 without salt, the secret is random so it can not be guessed from the hash
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseKey split the plain key, secret can have _ but prefix is hex so the first _ separate them
+func parseKey(key string) (prefix, secret string, ok bool) {
+	rest, found := strings.CutPrefix(key, keyPrefix)
+	if !found {
+		return "", "", false
+	}
+
+	prefix, secret, found = strings.Cut(rest, "_")
+	if !found || len(prefix) != prefixBytes*2 || secret == "" {
+		return "", "", false
+	}
+
+	return prefix, secret, true
+}
+
+func (as *APIKeyService) Get(ctx context.Context, id int) (res contract.APIKeyResponse, err error) {
+
+	key, err := as.APIKeyRepo.Get(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrAPIKeyNotFound
+		}
+		log.Println("get api key err: ", err)
+		return
+	}
+
+	res = mapperAPIKeyResponse(&key)
+
+	return
+}
+
+func (as *APIKeyService) GetList(ctx context.Context) (res []contract.APIKeyResponse, err error) {
+
+	keys, err := as.APIKeyRepo.GetList(ctx)
+	if err != nil {
+		log.Println("get list api key err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(keys), func(k *entity.APIKey) contract.APIKeyResponse {
+		return mapperAPIKeyResponse(k)
+	}).ToSlice()
+
+	return
+}
+
+func (as *APIKeyService) Issue(ctx context.Context, request contract.APIKeyRequest) (res contract.IssuedAPIKeyResponse, err error) {
+
+	plain, prefix, secretHash, err := generateKey()
+	if err != nil {
+		log.Println("generate api key err: ", err)
+		return
+	}
+
+	key, err := as.APIKeyRepo.Create(ctx, &entity.APIKey{
+		Name:       request.Name,
+		Owner:      request.Owner,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     request.Scopes,
+		DailyQuota: request.DailyQuota,
+		ExpiresAt:  request.ExpiresAt,
+	})
+	if err != nil {
+		log.Println("issue api key err: ", err)
+		return
+	}
+
+	res = contract.IssuedAPIKeyResponse{APIKeyResponse: mapperAPIKeyResponse(&key), Key: plain}
+
+	return
+}
+
+// Rotate replace the secret and prefix of the key, the previous key stop working at once
+func (as *APIKeyService) Rotate(ctx context.Context, id int) (res contract.IssuedAPIKeyResponse, err error) {
+
+	current, err := as.APIKeyRepo.Get(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrAPIKeyNotFound
+		}
+		log.Println("get api key err: ", err)
+		return
+	}
+
+	if current.RevokedAt != nil {
+		err = appErr.ErrAPIKeyRevoked
+		log.Println("rotate api key err: ", err)
+		return
+	}
+
+	plain, prefix, secretHash, err := generateKey()
+	if err != nil {
+		log.Println("generate api key err: ", err)
+		return
+	}
+
+	current.Prefix = prefix
+	current.SecretHash = secretHash
+
+	key, err := as.APIKeyRepo.Rotate(ctx, &current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrAPIKeyRevoked
+		}
+		log.Println("rotate api key err: ", err)
+		return
+	}
+
+	res = contract.IssuedAPIKeyResponse{APIKeyResponse: mapperAPIKeyResponse(&key), Key: plain}
+
+	return
+}
+
+func (as *APIKeyService) Revoke(ctx context.Context, id int) (err error) {
+
+	err = as.APIKeyRepo.Revoke(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrAPIKeyNotFound
+		}
+		log.Println("revoke api key err: ", err)
+		return
+	}
+
+	return
+}
+
+// Authenticate return the active key of the X-API-Key header and count the request in its daily quota.
+// Unknown, expired and revoked key is ErrInvalidAPIKey so the caller can not tell them apart
+func (as *APIKeyService) Authenticate(ctx context.Context, plain string) (key entity.APIKey, err error) {
+
+	prefix, secret, ok := parseKey(plain)
+	if !ok {
+		err = appErr.ErrInvalidAPIKey
+		log.Println("authenticate api key err: ", err)
+		return
+	}
+
+	key, err = as.APIKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrInvalidAPIKey
+		}
+		log.Println("authenticate api key err: ", err)
+		return
+	}
+
+	now := as.now()
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.SecretHash)) != 1 || !key.Active(now) {
+		err = appErr.ErrInvalidAPIKey
+		log.Println("authenticate api key err: ", err, key.Id)
+		return
+	}
+
+	used, err := as.APIKeyRepo.IncrementUsage(ctx, key.Id, now)
+	if err != nil {
+		log.Println("count api key usage err: ", err)
+		return
+	}
+
+	if used > key.DailyQuota {
+		err = appErr.ErrAPIKeyQuotaExceeded
+		log.Println("authenticate api key err: ", err, key.Id)
+		return
+	}
+
+	// last used is informational, failing to record it does not reject the request
+	if err := as.APIKeyRepo.TouchLastUsed(ctx, key.Id); err != nil {
+		log.Println("touch api key last used err: ", err)
+	}
+
+	return
+}
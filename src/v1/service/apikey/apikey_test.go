@@ -0,0 +1,243 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_apikey "github.com/Risuii/movie/src/v1/service/mock/apikey"
+)
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+}
+
+func TestAuthenticateAPIKeyService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mock_apikey.NewMockAPIKeyRepository(ctrl)
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+
+	plain, prefix, secretHash, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := entity.APIKey{ModelID: entity.ModelID{Id: 7}, Owner: "partner", Prefix: prefix, SecretHash: secretHash, Scopes: []string{entity.PermissionMovieCreate}, DailyQuota: 2}
+	revoked := key
+	revoked.RevokedAt = &past
+	expired := key
+	expired.ExpiresAt = &past
+
+	tests := []struct {
+		name     string
+		key      string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:     "error invalid format",
+			key:      "not-a-key",
+			wantErr:  appErr.ErrInvalidAPIKey,
+			mockFunc: func() {},
+		},
+		{
+			name:    "error unknown prefix",
+			key:     plain,
+			wantErr: appErr.ErrInvalidAPIKey,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(entity.APIKey{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error wrong secret",
+			key:     keyPrefix + prefix + "_wrong",
+			wantErr: appErr.ErrInvalidAPIKey,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(key, nil).Times(1)
+			},
+		},
+		{
+			name:    "error revoked key",
+			key:     plain,
+			wantErr: appErr.ErrInvalidAPIKey,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(revoked, nil).Times(1)
+			},
+		},
+		{
+			name:    "error expired key",
+			key:     plain,
+			wantErr: appErr.ErrInvalidAPIKey,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(expired, nil).Times(1)
+			},
+		},
+		{
+			name:    "error count usage",
+			key:     plain,
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(key, nil).Times(1)
+				mockAPIKeyRepo.EXPECT().IncrementUsage(gomock.Any(), int64(7), now).Return(int64(0), assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "error quota exceeded",
+			key:     plain,
+			wantErr: appErr.ErrAPIKeyQuotaExceeded,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(key, nil).Times(1)
+				mockAPIKeyRepo.EXPECT().IncrementUsage(gomock.Any(), int64(7), now).Return(int64(3), nil).Times(1)
+			},
+		},
+		{
+			name: "success ignore last used error",
+			key:  plain,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(key, nil).Times(1)
+				mockAPIKeyRepo.EXPECT().IncrementUsage(gomock.Any(), int64(7), now).Return(int64(2), nil).Times(1)
+				mockAPIKeyRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(7)).Return(assert.AnError).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			svc := InitAPIKeyService(mockAPIKeyRepo)
+			svc.now = func() time.Time { return now }
+
+			got, err := svc.Authenticate(context.Background(), tt.key)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, "partner", got.Owner)
+			}
+		})
+	}
+}
+
+func TestIssueAPIKeyService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mock_apikey.NewMockAPIKeyRepository(ctrl)
+
+	request := contract.APIKeyRequest{Name: "catalog sync", Owner: "partner", Scopes: []string{entity.PermissionMovieCreate}, DailyQuota: 100}
+
+	var stored entity.APIKey
+	mockAPIKeyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k *entity.APIKey) (entity.APIKey, error) {
+		stored = *k
+		stored.Id = 1
+		return stored, nil
+	}).Times(1)
+
+	got, err := InitAPIKeyService(mockAPIKeyRepo).Issue(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefix, secret, ok := parseKey(got.Key)
+	assert.True(t, ok)
+	assert.Equal(t, stored.Prefix, prefix)
+	assert.Equal(t, stored.Prefix, got.Prefix)
+	assert.Equal(t, hashSecret(secret), stored.SecretHash)
+	assert.False(t, strings.Contains(stored.SecretHash, secret))
+	assert.Equal(t, 1, got.ID)
+}
+
+func TestRotateAPIKeyService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mock_apikey.NewMockAPIKeyRepository(ctrl)
+
+	revokedAt := time.Now()
+	current := entity.APIKey{ModelID: entity.ModelID{Id: 3}, Prefix: "0000000000000000", SecretHash: "old"}
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error api key not found",
+			wantErr: appErr.ErrAPIKeyNotFound,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(entity.APIKey{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error api key revoked",
+			wantErr: appErr.ErrAPIKeyRevoked,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(entity.APIKey{ModelID: current.ModelID, RevokedAt: &revokedAt}, nil).Times(1)
+			},
+		},
+		{
+			name:    "error revoked while rotating",
+			wantErr: appErr.ErrAPIKeyRevoked,
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(current, nil).Times(1)
+				mockAPIKeyRepo.EXPECT().Rotate(gomock.Any(), gomock.Any()).Return(entity.APIKey{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "success replace prefix and secret",
+			mockFunc: func() {
+				mockAPIKeyRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(current, nil).Times(1)
+				mockAPIKeyRepo.EXPECT().Rotate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k *entity.APIKey) (entity.APIKey, error) {
+					assert.NotEqual(t, current.Prefix, k.Prefix)
+					assert.NotEqual(t, current.SecretHash, k.SecretHash)
+					return *k, nil
+				}).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitAPIKeyService(mockAPIKeyRepo).Rotate(context.Background(), 3)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.True(t, strings.HasPrefix(got.Key, keyPrefix+got.Prefix+"_"))
+			}
+		})
+	}
+}
+
+func TestRevokeAPIKeyService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mock_apikey.NewMockAPIKeyRepository(ctrl)
+
+	mockAPIKeyRepo.EXPECT().Revoke(gomock.Any(), int64(3)).Return(sql.ErrNoRows).Times(1)
+	assert.Equal(t, appErr.ErrAPIKeyNotFound, InitAPIKeyService(mockAPIKeyRepo).Revoke(context.Background(), 3))
+
+	mockAPIKeyRepo.EXPECT().Revoke(gomock.Any(), int64(4)).Return(nil).Times(1)
+	assert.NoError(t, InitAPIKeyService(mockAPIKeyRepo).Revoke(context.Background(), 4))
+}
@@ -0,0 +1,19 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, data *entity.APIKey) (entity.APIKey, error)
+	GetList(ctx context.Context) ([]*entity.APIKey, error)
+	Get(ctx context.Context, id int64) (entity.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (entity.APIKey, error)
+	Rotate(ctx context.Context, data *entity.APIKey) (entity.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+	IncrementUsage(ctx context.Context, id int64, now time.Time) (int64, error)
+	TouchLastUsed(ctx context.Context, id int64) error
+}
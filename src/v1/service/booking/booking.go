@@ -0,0 +1,352 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/google/uuid"
+	"github.com/mariomac/gostream/stream"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+const (
+	DefaultHoldDuration = 10 * time.Minute
+
+	// ConfirmHoldDuration keep the seats held while the booking is committed,
+	// it outlive the request timeout of the router
+	ConfirmHoldDuration = 2 * time.Minute
+)
+
+type BookingService struct {
+	BookingRepo  BookingRepository
+	HoldRepo     HoldRepository
+	ShowtimeRepo ShowtimeRepository
+	CinemaRepo   CinemaRepository
+	Atomic       frsAtomic.AtomicSessionProvider
+	HoldDuration time.Duration
+}
+
+// InitBookingService use DefaultHoldDuration when holdDuration is not set
+func InitBookingService(bRepo BookingRepository, hRepo HoldRepository, sRepo ShowtimeRepository, cRepo CinemaRepository, atomic frsAtomic.AtomicSessionProvider, holdDuration time.Duration) *BookingService {
+	if holdDuration <= 0 {
+		holdDuration = DefaultHoldDuration
+	}
+
+	return &BookingService{
+		BookingRepo:  bRepo,
+		HoldRepo:     hRepo,
+		ShowtimeRepo: sRepo,
+		CinemaRepo:   cRepo,
+		Atomic:       atomic,
+		HoldDuration: holdDuration,
+	}
+}
+
+func mapperHoldResponse(hold *entity.Hold) *contract.HoldResponse {
+	return &contract.HoldResponse{
+		ID:         hold.ID,
+		ShowtimeID: hold.ShowtimeID,
+		UserID:     hold.UserID,
+		Seats:      hold.Seats,
+		ExpiresAt:  hold.ExpiresAt.Local().Format(time.RFC3339),
+	}
+}
+
+func mapperBookingResponse(booking *entity.Booking) *contract.BookingResponse {
+	return &contract.BookingResponse{
+		ID:         int(booking.Id),
+		ShowtimeID: booking.ShowtimeID,
+		UserID:     booking.UserID,
+		HoldID:     booking.HoldID,
+		Seats:      booking.Seats,
+		TotalPrice: booking.TotalPrice,
+		CreatedAt:  booking.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (bs *BookingService) getShowtime(ctx context.Context, id int) (showtime entity.Showtime, err error) {
+	showtime, err = bs.ShowtimeRepo.Get(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrShowtimeIdNotFound
+		}
+		log.Println("get showtime err: ", err)
+	}
+	return
+}
+
+func (bs *BookingService) getScreen(ctx context.Context, id int) (screen entity.Screen, err error) {
+	screen, err = bs.CinemaRepo.GetScreen(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrScreenIdNotFound
+		}
+		log.Println("get screen err: ", err)
+	}
+	return
+}
+
+// getOwnHold return hold of userID, hold of another user is reported as not found
+func (bs *BookingService) getOwnHold(ctx context.Context, id, userID string) (hold entity.Hold, err error) {
+	hold, err = bs.HoldRepo.Get(ctx, id)
+	if err != nil {
+		log.Println("get hold err: ", err)
+		return
+	}
+
+	if hold.UserID != userID {
+		err = appErr.ErrHoldIdNotFound
+		log.Println("hold owner mismatch err: ", err)
+	}
+
+	return
+}
+
+// GetSeats return every seat of the showtime screen with its status,
+// booked seat take precedence over held seat
+func (bs *BookingService) GetSeats(ctx context.Context, showtimeID int) (res contract.SeatAvailabilityResponse, err error) {
+
+	showtime, err := bs.getShowtime(ctx, showtimeID)
+	if err != nil {
+		return
+	}
+
+	screen, err := bs.getScreen(ctx, int(showtime.ScreenID))
+	if err != nil {
+		return
+	}
+
+	booked, err := bs.BookingRepo.GetBookedSeats(ctx, showtime.Id)
+	if err != nil {
+		log.Println("get booked seats err: ", err)
+		return
+	}
+
+	seats := screen.SeatMap.SeatIDs()
+
+	held, err := bs.HoldRepo.GetHeldSeats(ctx, showtime.Id, seats)
+	if err != nil {
+		log.Println("get held seats err: ", err)
+		return
+	}
+
+	bookedSeats := make(map[string]bool, len(booked))
+	for _, seat := range booked {
+		bookedSeats[seat] = true
+	}
+
+	res = contract.SeatAvailabilityResponse{
+		ShowtimeID: showtime.Id,
+		ScreenID:   screen.Id,
+		Seats:      make([]*contract.SeatResponse, 0, len(seats)),
+	}
+
+	for _, seat := range seats {
+		status := contract.SeatAvailable
+		switch {
+		case bookedSeats[seat]:
+			status = contract.SeatBooked
+		case held[seat]:
+			status = contract.SeatHeld
+		default:
+			res.Available++
+		}
+		res.Seats = append(res.Seats, &contract.SeatResponse{Seat: seat, Status: status})
+	}
+
+	return
+}
+
+// CreateHold lock the seats for HoldDuration, ErrSeatTaken is returned when any
+// of them is already booked or held by another hold
+func (bs *BookingService) CreateHold(ctx context.Context, request contract.HoldRequest) (res contract.HoldResponse, err error) {
+
+	showtime, err := bs.getShowtime(ctx, int(request.ShowtimeID))
+	if err != nil {
+		return
+	}
+
+	if !showtime.StartTime.After(time.Now()) {
+		err = appErr.ErrShowtimeStarted
+		log.Println("create hold err: ", err)
+		return
+	}
+
+	screen, err := bs.getScreen(ctx, int(showtime.ScreenID))
+	if err != nil {
+		return
+	}
+
+	for _, seat := range request.Seats {
+		if !screen.SeatMap.Has(seat) {
+			err = appErr.ErrSeatNotFound
+			log.Println("create hold err: ", err, seat)
+			return
+		}
+	}
+
+	booked, err := bs.BookingRepo.GetBookedSeats(ctx, showtime.Id)
+	if err != nil {
+		log.Println("get booked seats err: ", err)
+		return
+	}
+
+	for _, seat := range booked {
+		if stream.OfSlice(request.Seats).AnyMatch(func(s string) bool { return s == seat }) {
+			err = appErr.ErrSeatTaken
+			log.Println("create hold err: ", err, seat)
+			return
+		}
+	}
+
+	hold := entity.Hold{
+		ID:         uuid.NewString(),
+		ShowtimeID: showtime.Id,
+		UserID:     request.UserID,
+		Seats:      request.Seats,
+		ExpiresAt:  time.Now().Add(bs.HoldDuration),
+	}
+
+	_, err = bs.HoldRepo.Create(ctx, &hold, bs.HoldDuration)
+	if err != nil {
+		log.Println("create hold err: ", err)
+		return
+	}
+
+	res = *mapperHoldResponse(&hold)
+
+	return
+}
+
+func (bs *BookingService) GetHold(ctx context.Context, id, userID string) (res contract.HoldResponse, err error) {
+
+	hold, err := bs.getOwnHold(ctx, id, userID)
+	if err != nil {
+		return
+	}
+
+	res = *mapperHoldResponse(&hold)
+
+	return
+}
+
+// ReleaseHold free the seats before the hold expire
+func (bs *BookingService) ReleaseHold(ctx context.Context, id, userID string) (err error) {
+
+	hold, err := bs.getOwnHold(ctx, id, userID)
+	if err != nil {
+		return
+	}
+
+	err = bs.HoldRepo.Release(ctx, &hold)
+	if err != nil {
+		log.Println("release hold err: ", err)
+		return
+	}
+
+	return
+}
+
+// Confirm turn a hold into a booking, the hold is released once the booking
+// is committed, double booking is rejected by booking_seats unique constraint.
+// Seats must still be held by the hold in the transaction, so a hold expiring
+// before the commit can not book seat that another hold took since
+func (bs *BookingService) Confirm(ctx context.Context, request contract.BookingRequest) (res contract.BookingResponse, err error) {
+
+	hold, err := bs.getOwnHold(ctx, request.HoldID, request.UserID)
+	if err != nil {
+		return
+	}
+
+	showtime, err := bs.getShowtime(ctx, int(hold.ShowtimeID))
+	if err != nil {
+		return
+	}
+
+	if !showtime.StartTime.After(time.Now()) {
+		err = appErr.ErrShowtimeStarted
+		log.Println("confirm booking err: ", err)
+		return
+	}
+
+	req := &entity.Booking{
+		BookingData: entity.BookingData{
+			ShowtimeID: showtime.Id,
+			UserID:     hold.UserID,
+			HoldID:     hold.ID,
+			TotalPrice: showtime.Price * int64(len(hold.Seats)),
+		},
+		Seats: hold.Seats,
+	}
+
+	var booking entity.Booking
+
+	err = frsAtomic.Atomic(ctx, bs.Atomic, func(ctx context.Context) error {
+		err := bs.HoldRepo.Extend(ctx, &hold, ConfirmHoldDuration)
+		if err != nil {
+			log.Println("extend hold err: ", err)
+			return err
+		}
+
+		booking, err = bs.BookingRepo.Create(ctx, req)
+		if err != nil {
+			log.Println("create booking err: ", err)
+		}
+		return err
+	})
+	if err != nil {
+		return
+	}
+
+	// seats is booked already, failing to release only delay the hold expiry
+	if err := bs.HoldRepo.Release(ctx, &hold); err != nil {
+		log.Println("release hold err: ", err)
+	}
+
+	res = *mapperBookingResponse(&booking)
+
+	return
+}
+
+// GetBooking return booking of userID, booking of another user is reported as not found
+func (bs *BookingService) GetBooking(ctx context.Context, id int, userID string) (res contract.BookingResponse, err error) {
+
+	booking, err := bs.BookingRepo.Get(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrBookingIdNotFound
+		}
+		log.Println("get booking err: ", err)
+		return
+	}
+
+	if booking.UserID != userID {
+		err = appErr.ErrBookingIdNotFound
+		log.Println("booking owner mismatch err: ", err)
+		return
+	}
+
+	res = *mapperBookingResponse(&booking)
+
+	return
+}
+
+func (bs *BookingService) GetBookings(ctx context.Context, userID string) (res []*contract.BookingResponse, err error) {
+
+	bookings, err := bs.BookingRepo.GetByUser(ctx, userID)
+	if err != nil {
+		log.Println("get booking by user err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(bookings), mapperBookingResponse).ToSlice()
+
+	return
+}
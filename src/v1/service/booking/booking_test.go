@@ -0,0 +1,374 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_booking "github.com/Risuii/movie/src/v1/service/mock/booking"
+)
+
+// expectAtomic expect one transaction that is committed or rolled back
+func expectAtomic(mock *mock_atomic.MockAtomicSessionProvider, session *mock_atomic.MockAtomicSession, commit bool) {
+	mock.EXPECT().BeginSession(gomock.Any()).DoAndReturn(func(ctx context.Context) (*frsAtomic.AtomicSessionContext, error) {
+		return frsAtomic.NewAtomicSessionContext(ctx, session), nil
+	}).Times(1)
+
+	if commit {
+		session.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+	} else {
+		session.EXPECT().Rollback(gomock.Any()).Return(nil).Times(1)
+	}
+}
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+
+}
+
+type mockFields struct {
+	bookingRepo  *mock_booking.MockBookingRepository
+	holdRepo     *mock_booking.MockHoldRepository
+	showtimeRepo *mock_booking.MockShowtimeRepository
+	cinemaRepo   *mock_booking.MockCinemaRepository
+	atomic       *mock_atomic.MockAtomicSessionProvider
+	session      *mock_atomic.MockAtomicSession
+}
+
+func newMockFields(ctrl *gomock.Controller) mockFields {
+	return mockFields{
+		bookingRepo:  mock_booking.NewMockBookingRepository(ctrl),
+		holdRepo:     mock_booking.NewMockHoldRepository(ctrl),
+		showtimeRepo: mock_booking.NewMockShowtimeRepository(ctrl),
+		cinemaRepo:   mock_booking.NewMockCinemaRepository(ctrl),
+		atomic:       mock_atomic.NewMockAtomicSessionProvider(ctrl),
+		session:      mock_atomic.NewMockAtomicSession(ctrl),
+	}
+}
+
+func newService(mocks mockFields) *BookingService {
+	return InitBookingService(mocks.bookingRepo, mocks.holdRepo, mocks.showtimeRepo, mocks.cinemaRepo, mocks.atomic, 0)
+}
+
+var mockScreen = entity.Screen{
+	ModelID: entity.ModelID{Id: 2},
+	ScreenData: entity.ScreenData{SeatMap: entity.SeatMap{Rows: []entity.SeatRow{
+		{Row: "A", Seats: 2},
+		{Row: "B", Seats: 1},
+	}}},
+}
+
+func mockShowtime(start time.Time) entity.Showtime {
+	return entity.Showtime{
+		ModelID:      entity.ModelID{Id: 1},
+		ShowtimeData: entity.ShowtimeData{ScreenID: 2, StartTime: start, Price: 50000},
+	}
+}
+
+func TestInitBookingServiceDefaultHoldDuration(t *testing.T) {
+	assert.Equal(t, DefaultHoldDuration, InitBookingService(nil, nil, nil, nil, nil, 0).HoldDuration)
+	assert.Equal(t, time.Minute, InitBookingService(nil, nil, nil, nil, nil, time.Minute).HoldDuration)
+}
+
+func TestGetSeatsBookingService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	showtime := mockShowtime(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		want     contract.SeatAvailabilityResponse
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error showtime id not found",
+			wantErr: appErr.ErrShowtimeIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(entity.Showtime{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "success booked take precedence over held",
+			want: contract.SeatAvailabilityResponse{
+				ShowtimeID: 1,
+				ScreenID:   2,
+				Available:  1,
+				Seats: []*contract.SeatResponse{
+					{Seat: "A1", Status: contract.SeatBooked},
+					{Seat: "A2", Status: contract.SeatHeld},
+					{Seat: "B1", Status: contract.SeatAvailable},
+				},
+			},
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(showtime, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(mockScreen, nil).Times(1)
+				mock.bookingRepo.EXPECT().GetBookedSeats(gomock.Any(), int64(1)).Return([]string{"A1"}, nil).Times(1)
+				mock.holdRepo.EXPECT().GetHeldSeats(gomock.Any(), int64(1), []string{"A1", "A2", "B1"}).
+					Return(map[string]bool{"A1": true, "A2": true}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			got, err := newService(mocks).GetSeats(context.Background(), 1)
+			if err != tt.wantErr {
+				t.Errorf("Booking.GetSeats() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateHoldBookingService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	request := contract.HoldRequest{UserID: "user-1", ShowtimeID: 1, Seats: []string{"A1", "B1"}}
+	showtime := mockShowtime(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		request  contract.HoldRequest
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error showtime started",
+			request: request,
+			wantErr: appErr.ErrShowtimeStarted,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(mockShowtime(time.Now().Add(-time.Minute)), nil).Times(1)
+			},
+		},
+		{
+			name:    "error seat not found",
+			request: contract.HoldRequest{UserID: "user-1", ShowtimeID: 1, Seats: []string{"A3"}},
+			wantErr: appErr.ErrSeatNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(showtime, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(mockScreen, nil).Times(1)
+			},
+		},
+		{
+			name:    "error seat booked",
+			request: request,
+			wantErr: appErr.ErrSeatTaken,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(showtime, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(mockScreen, nil).Times(1)
+				mock.bookingRepo.EXPECT().GetBookedSeats(gomock.Any(), int64(1)).Return([]string{"B1"}, nil).Times(1)
+			},
+		},
+		{
+			name:    "error seat held",
+			request: request,
+			wantErr: appErr.ErrSeatTaken,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(showtime, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(mockScreen, nil).Times(1)
+				mock.bookingRepo.EXPECT().GetBookedSeats(gomock.Any(), int64(1)).Return(nil, nil).Times(1)
+				mock.holdRepo.EXPECT().Create(gomock.Any(), gomock.Any(), DefaultHoldDuration).Return([]string{"A1"}, appErr.ErrSeatTaken).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(showtime, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(mockScreen, nil).Times(1)
+				mock.bookingRepo.EXPECT().GetBookedSeats(gomock.Any(), int64(1)).Return([]string{"A2"}, nil).Times(1)
+				mock.holdRepo.EXPECT().Create(gomock.Any(), gomock.Any(), DefaultHoldDuration).Return(nil, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			got, err := newService(mocks).CreateHold(context.Background(), tt.request)
+			if err != tt.wantErr {
+				t.Errorf("Booking.CreateHold() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.NotEmpty(t, got.ID)
+				assert.Equal(t, tt.request.Seats, got.Seats)
+				assert.Equal(t, tt.request.UserID, got.UserID)
+			}
+		})
+	}
+}
+
+func TestConfirmBookingService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	holdID := "6a1f8c2e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+	request := contract.BookingRequest{UserID: "user-1", HoldID: holdID}
+	hold := entity.Hold{ID: holdID, ShowtimeID: 1, UserID: "user-1", Seats: []string{"A1", "A2"}}
+	params := &entity.Booking{
+		BookingData: entity.BookingData{ShowtimeID: 1, UserID: "user-1", HoldID: holdID, TotalPrice: 100000},
+		Seats:       []string{"A1", "A2"},
+	}
+	booking := entity.Booking{ModelID: entity.ModelID{Id: 5}, BookingData: params.BookingData, Seats: params.Seats}
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error hold expired",
+			wantErr: appErr.ErrHoldIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.holdRepo.EXPECT().Get(gomock.Any(), holdID).Return(entity.Hold{}, appErr.ErrHoldIdNotFound).Times(1)
+			},
+		},
+		{
+			name:    "error hold of another user",
+			wantErr: appErr.ErrHoldIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.holdRepo.EXPECT().Get(gomock.Any(), holdID).Return(entity.Hold{ID: holdID, UserID: "user-2"}, nil).Times(1)
+			},
+		},
+		{
+			name:    "error showtime started",
+			wantErr: appErr.ErrShowtimeStarted,
+			mockFunc: func(mock mockFields) {
+				mock.holdRepo.EXPECT().Get(gomock.Any(), holdID).Return(hold, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(mockShowtime(time.Now().Add(-time.Minute)), nil).Times(1)
+			},
+		},
+		{
+			name:    "error hold expired before commit roll back",
+			wantErr: appErr.ErrHoldIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.holdRepo.EXPECT().Get(gomock.Any(), holdID).Return(hold, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(mockShowtime(time.Now().Add(time.Hour)), nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, false)
+				mock.holdRepo.EXPECT().Extend(gomock.Any(), &hold, ConfirmHoldDuration).Return(appErr.ErrHoldIdNotFound).Times(1)
+			},
+		},
+		{
+			name:    "error seat booked roll back",
+			wantErr: appErr.ErrSeatTaken,
+			mockFunc: func(mock mockFields) {
+				mock.holdRepo.EXPECT().Get(gomock.Any(), holdID).Return(hold, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(mockShowtime(time.Now().Add(time.Hour)), nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, false)
+				mock.holdRepo.EXPECT().Extend(gomock.Any(), &hold, ConfirmHoldDuration).Return(nil).Times(1)
+				mock.bookingRepo.EXPECT().Create(gomock.Any(), params).Return(entity.Booking{}, appErr.ErrSeatTaken).Times(1)
+			},
+		},
+		{
+			name: "success release hold",
+			mockFunc: func(mock mockFields) {
+				mock.holdRepo.EXPECT().Get(gomock.Any(), holdID).Return(hold, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(mockShowtime(time.Now().Add(time.Hour)), nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, true)
+				mock.holdRepo.EXPECT().Extend(gomock.Any(), &hold, ConfirmHoldDuration).Return(nil).Times(1)
+				mock.bookingRepo.EXPECT().Create(gomock.Any(), params).Return(booking, nil).Times(1)
+				mock.holdRepo.EXPECT().Release(gomock.Any(), &hold).Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			got, err := newService(mocks).Confirm(context.Background(), request)
+			if err != tt.wantErr {
+				t.Errorf("Booking.Confirm() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, 5, got.ID)
+				assert.Equal(t, int64(100000), got.TotalPrice)
+			}
+		})
+	}
+}
+
+func TestGetBookingService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error booking id not found",
+			wantErr: appErr.ErrBookingIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.bookingRepo.EXPECT().Get(gomock.Any(), int64(5)).Return(entity.Booking{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error booking of another user",
+			wantErr: appErr.ErrBookingIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.bookingRepo.EXPECT().Get(gomock.Any(), int64(5)).Return(entity.Booking{
+					BookingData: entity.BookingData{UserID: "user-2"},
+				}, nil).Times(1)
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func(mock mockFields) {
+				mock.bookingRepo.EXPECT().Get(gomock.Any(), int64(5)).Return(entity.Booking{
+					BookingData: entity.BookingData{UserID: "user-1"},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			_, err := newService(mocks).GetBooking(context.Background(), 5, "user-1")
+			if err != tt.wantErr {
+				t.Errorf("Booking.GetBooking() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
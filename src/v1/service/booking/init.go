@@ -0,0 +1,31 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+)
+
+type BookingRepository interface {
+	Create(ctx context.Context, data *entity.Booking) (entity.Booking, error)
+	Get(ctx context.Context, id int64) (entity.Booking, error)
+	GetByUser(ctx context.Context, userID string) ([]*entity.Booking, error)
+	GetBookedSeats(ctx context.Context, showtimeID int64) ([]string, error)
+}
+
+type HoldRepository interface {
+	Create(ctx context.Context, hold *entity.Hold, ttl time.Duration) ([]string, error)
+	Get(ctx context.Context, id string) (entity.Hold, error)
+	GetHeldSeats(ctx context.Context, showtimeID int64, seats []string) (map[string]bool, error)
+	Extend(ctx context.Context, hold *entity.Hold, ttl time.Duration) error
+	Release(ctx context.Context, hold *entity.Hold) error
+}
+
+type ShowtimeRepository interface {
+	Get(ctx context.Context, id int64) (entity.Showtime, error)
+}
+
+type CinemaRepository interface {
+	GetScreen(ctx context.Context, id int) (entity.Screen, error)
+}
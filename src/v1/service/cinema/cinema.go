@@ -0,0 +1,145 @@
+package cinema
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+type CinemaService struct {
+	CinemaRepo CinemaRepository
+}
+
+func InitCinemaService(cRepo CinemaRepository) *CinemaService {
+	return &CinemaService{
+		CinemaRepo: cRepo,
+	}
+}
+
+func mapperCinemaResponse(cinema *entity.Cinema) *contract.CinemaResponse {
+	return &contract.CinemaResponse{
+		ID:        int(cinema.Id),
+		Name:      cinema.Name,
+		City:      cinema.City,
+		Address:   cinema.Address,
+		CreatedAt: cinema.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: cinema.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (cs *CinemaService) getCinema(ctx context.Context, id int) (cinema entity.Cinema, err error) {
+	cinema, err = cs.CinemaRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrCinemaIdNotFound
+		}
+		log.Println("get cinema err: ", err)
+	}
+	return
+}
+
+func (cs *CinemaService) Get(ctx context.Context, id int) (res contract.CinemaResponse, err error) {
+
+	cinema, err := cs.getCinema(ctx, id)
+	if err != nil {
+		return
+	}
+
+	res = *mapperCinemaResponse(&cinema)
+
+	return
+}
+
+func (cs *CinemaService) GetList(ctx context.Context, params contract.GetListParam) (res contract.GetListCinemaResponse, err error) {
+
+	cinemas, err := cs.CinemaRepo.GetList(ctx, params)
+	if err != nil {
+		log.Println("get list cinema err: ", err)
+		return
+	}
+
+	count, err := cs.CinemaRepo.GetCinemaCount(ctx, params)
+	if err != nil {
+		log.Println("get count cinema err: ", err)
+		return
+	}
+
+	res = contract.GetListCinemaResponse{
+		Data:       stream.Map(stream.OfSlice(cinemas), mapperCinemaResponse).ToSlice(),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	return
+}
+
+func (cs *CinemaService) Create(ctx context.Context, request contract.CinemaRequest) (res contract.CinemaResponse, err error) {
+
+	req := &entity.Cinema{
+		CinemaData: entity.CinemaData{
+			Name:    request.Name,
+			City:    request.City,
+			Address: request.Address,
+		},
+	}
+
+	cinema, err := cs.CinemaRepo.Create(ctx, req)
+	if err != nil {
+		log.Println("create cinema err: ", err)
+		return
+	}
+
+	res = *mapperCinemaResponse(&cinema)
+
+	return
+}
+
+func (cs *CinemaService) Update(ctx context.Context, request contract.CinemaRequest, id int) (res contract.CinemaResponse, err error) {
+
+	cinema, err := cs.getCinema(ctx, id)
+	if err != nil {
+		return
+	}
+
+	cinema.CinemaData = entity.CinemaData{
+		Name:    request.Name,
+		City:    request.City,
+		Address: request.Address,
+	}
+
+	err = cs.CinemaRepo.Update(ctx, &cinema)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrCinemaIdNotFound
+		}
+		log.Println("update cinema err: ", err)
+		return
+	}
+
+	res = *mapperCinemaResponse(&cinema)
+
+	return
+}
+
+func (cs *CinemaService) Delete(ctx context.Context, id int) (err error) {
+
+	cinema, err := cs.getCinema(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = cs.CinemaRepo.Delete(ctx, cinema.Id)
+	if err != nil {
+		log.Println("delete cinema err: ", err)
+		return
+	}
+
+	return
+}
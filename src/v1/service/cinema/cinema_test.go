@@ -0,0 +1,159 @@
+package cinema
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_cinema "github.com/Risuii/movie/src/v1/service/mock/cinema"
+)
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+
+}
+
+func TestGetCinemaService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCinemaRepo := mock_cinema.NewMockCinemaRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		want     contract.CinemaResponse
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error cinema id not found",
+			want:    contract.CinemaResponse{},
+			wantErr: appErr.ErrCinemaIdNotFound,
+			mockFunc: func() {
+				mockCinemaRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Cinema{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.CinemaResponse{
+				ID:        1,
+				Name:      "Grand Indonesia",
+				City:      "Jakarta",
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+			},
+			mockFunc: func() {
+				mockCinemaRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Cinema{
+					ModelID:    entity.ModelID{Id: 1},
+					CinemaData: entity.CinemaData{Name: "Grand Indonesia", City: "Jakarta"},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			c := InitCinemaService(mockCinemaRepo)
+			got, err := c.Get(context.Background(), 1)
+			if err != tt.wantErr {
+				t.Errorf("Cinema.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateScreenService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCinemaRepo := mock_cinema.NewMockCinemaRepository(ctrl)
+
+	request := contract.ScreenRequest{
+		Name:    "Studio 1",
+		SeatMap: []contract.SeatRow{{Row: "A", Seats: 10}, {Row: "B", Seats: 12}},
+	}
+	params := &entity.Screen{ScreenData: entity.ScreenData{
+		CinemaID: 1,
+		Name:     "Studio 1",
+		SeatMap:  entity.SeatMap{Rows: []entity.SeatRow{{Row: "A", Seats: 10}, {Row: "B", Seats: 12}}},
+	}}
+
+	tests := []struct {
+		name     string
+		want     contract.ScreenResponse
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error cinema id not found",
+			want:    contract.ScreenResponse{},
+			wantErr: appErr.ErrCinemaIdNotFound,
+			mockFunc: func() {
+				mockCinemaRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Cinema{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error duplicate screen",
+			want:    contract.ScreenResponse{},
+			wantErr: appErr.ErrDuplicateScreen,
+			mockFunc: func() {
+				mockCinemaRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Cinema{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mockCinemaRepo.EXPECT().CreateScreen(gomock.Any(), params).Return(entity.Screen{}, appErr.ErrDuplicateScreen).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.ScreenResponse{
+				ID:        2,
+				CinemaID:  1,
+				Name:      "Studio 1",
+				SeatMap:   request.SeatMap,
+				Capacity:  22,
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+			},
+			mockFunc: func() {
+				mockCinemaRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Cinema{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mockCinemaRepo.EXPECT().CreateScreen(gomock.Any(), params).Return(entity.Screen{
+					ModelID:    entity.ModelID{Id: 2},
+					ScreenData: params.ScreenData,
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			c := InitCinemaService(mockCinemaRepo)
+			got, err := c.CreateScreen(context.Background(), request, 1)
+			if err != tt.wantErr {
+				t.Errorf("Cinema.CreateScreen() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package cinema
+
+import (
+	"context"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+type CinemaRepository interface {
+	Create(ctx context.Context, data *entity.Cinema) (entity.Cinema, error)
+	GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Cinema, error)
+	GetCinemaCount(ctx context.Context, param contract.GetListParam) (int64, error)
+	Get(ctx context.Context, id int) (entity.Cinema, error)
+	Update(ctx context.Context, data *entity.Cinema) error
+	Delete(ctx context.Context, id int64) error
+	GetScreens(ctx context.Context, cinemaID int64) ([]*entity.Screen, error)
+	GetScreen(ctx context.Context, id int) (entity.Screen, error)
+	CreateScreen(ctx context.Context, data *entity.Screen) (entity.Screen, error)
+	UpdateScreen(ctx context.Context, data *entity.Screen) error
+	DeleteScreen(ctx context.Context, id int64) error
+}
@@ -0,0 +1,144 @@
+package cinema
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func mapperSeatMap(rows []contract.SeatRow) entity.SeatMap {
+	return entity.SeatMap{
+		Rows: stream.Map(stream.OfSlice(rows), func(r contract.SeatRow) entity.SeatRow {
+			return entity.SeatRow{Row: r.Row, Seats: r.Seats}
+		}).ToSlice(),
+	}
+}
+
+func mapperScreenResponse(screen *entity.Screen) *contract.ScreenResponse {
+	return &contract.ScreenResponse{
+		ID:       int(screen.Id),
+		CinemaID: screen.CinemaID,
+		Name:     screen.Name,
+		SeatMap: stream.Map(stream.OfSlice(screen.SeatMap.Rows), func(r entity.SeatRow) contract.SeatRow {
+			return contract.SeatRow{Row: r.Row, Seats: r.Seats}
+		}).ToSlice(),
+		Capacity:  screen.SeatMap.Capacity(),
+		CreatedAt: screen.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: screen.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (cs *CinemaService) getScreen(ctx context.Context, id int) (screen entity.Screen, err error) {
+	screen, err = cs.CinemaRepo.GetScreen(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrScreenIdNotFound
+		}
+		log.Println("get screen err: ", err)
+	}
+	return
+}
+
+func (cs *CinemaService) GetScreen(ctx context.Context, id int) (res contract.ScreenResponse, err error) {
+
+	screen, err := cs.getScreen(ctx, id)
+	if err != nil {
+		return
+	}
+
+	res = *mapperScreenResponse(&screen)
+
+	return
+}
+
+// GetScreens return every screen of the cinema
+func (cs *CinemaService) GetScreens(ctx context.Context, cinemaID int) (res []*contract.ScreenResponse, err error) {
+
+	cinema, err := cs.getCinema(ctx, cinemaID)
+	if err != nil {
+		return
+	}
+
+	screens, err := cs.CinemaRepo.GetScreens(ctx, cinema.Id)
+	if err != nil {
+		log.Println("get screens err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(screens), mapperScreenResponse).ToSlice()
+
+	return
+}
+
+func (cs *CinemaService) CreateScreen(ctx context.Context, request contract.ScreenRequest, cinemaID int) (res contract.ScreenResponse, err error) {
+
+	cinema, err := cs.getCinema(ctx, cinemaID)
+	if err != nil {
+		return
+	}
+
+	req := &entity.Screen{
+		ScreenData: entity.ScreenData{
+			CinemaID: cinema.Id,
+			Name:     request.Name,
+			SeatMap:  mapperSeatMap(request.SeatMap),
+		},
+	}
+
+	screen, err := cs.CinemaRepo.CreateScreen(ctx, req)
+	if err != nil {
+		log.Println("create screen err: ", err)
+		return
+	}
+
+	res = *mapperScreenResponse(&screen)
+
+	return
+}
+
+func (cs *CinemaService) UpdateScreen(ctx context.Context, request contract.ScreenRequest, id int) (res contract.ScreenResponse, err error) {
+
+	screen, err := cs.getScreen(ctx, id)
+	if err != nil {
+		return
+	}
+
+	screen.Name = request.Name
+	screen.SeatMap = mapperSeatMap(request.SeatMap)
+
+	err = cs.CinemaRepo.UpdateScreen(ctx, &screen)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrScreenIdNotFound
+		}
+		log.Println("update screen err: ", err)
+		return
+	}
+
+	res = *mapperScreenResponse(&screen)
+
+	return
+}
+
+func (cs *CinemaService) DeleteScreen(ctx context.Context, id int) (err error) {
+
+	screen, err := cs.getScreen(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = cs.CinemaRepo.DeleteScreen(ctx, screen.Id)
+	if err != nil {
+		log.Println("delete screen err: ", err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,130 @@
+package genre
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+type GenreService struct {
+	GenreRepo GenreRepository
+}
+
+func InitGenreService(gRepo GenreRepository) *GenreService {
+	return &GenreService{
+		GenreRepo: gRepo,
+	}
+}
+
+func mapperGenreResponse(genre *entity.Genre) contract.GenreResponse {
+	return contract.GenreResponse{
+		ID:        int(genre.Id),
+		Name:      genre.Name,
+		CreatedAt: genre.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: genre.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (gs *GenreService) Get(ctx context.Context, id int) (res contract.GenreResponse, err error) {
+
+	genre, err := gs.GenreRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrGenreIdNotFound
+		}
+		log.Println("get genre err: ", err)
+		return
+	}
+
+	res = mapperGenreResponse(&genre)
+
+	return
+}
+
+func (gs *GenreService) GetList(ctx context.Context) (res []contract.GenreResponse, err error) {
+
+	genres, err := gs.GenreRepo.GetList(ctx)
+	if err != nil {
+		log.Println("get list genre err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(genres), func(g *entity.Genre) contract.GenreResponse {
+		return mapperGenreResponse(g)
+	}).ToSlice()
+
+	return
+}
+
+func (gs *GenreService) Create(ctx context.Context, request contract.GenreRequest) (res contract.GenreResponse, err error) {
+
+	req := &entity.Genre{
+		GenreData: entity.GenreData{
+			Name: request.Name,
+		},
+	}
+
+	genre, err := gs.GenreRepo.Create(ctx, req)
+	if err != nil {
+		log.Println("create genre err: ", err)
+		return
+	}
+
+	res = mapperGenreResponse(&genre)
+
+	return
+}
+
+func (gs *GenreService) Update(ctx context.Context, request contract.GenreRequest, id int) (res contract.GenreResponse, err error) {
+
+	genre, err := gs.GenreRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrGenreIdNotFound
+		}
+		log.Println("find genre err: ", err)
+		return
+	}
+
+	genre.Name = request.Name
+
+	err = gs.GenreRepo.Update(ctx, &genre)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrGenreIdNotFound
+		}
+		log.Println("update genre err: ", err)
+		return
+	}
+
+	res = mapperGenreResponse(&genre)
+
+	return
+}
+
+func (gs *GenreService) Delete(ctx context.Context, id int) (err error) {
+
+	genre, err := gs.GenreRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrGenreIdNotFound
+		}
+		log.Println("get genre err: ", err)
+		return
+	}
+
+	err = gs.GenreRepo.Delete(ctx, genre.Id)
+	if err != nil {
+		log.Println("delete genre err: ", err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,354 @@
+package genre
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_genre "github.com/Risuii/movie/src/v1/service/mock/genre"
+)
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+
+}
+
+func TestGetGenreService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreRepo := mock_genre.NewMockGenreRepository(ctrl)
+
+	type args struct {
+		ctx context.Context
+		id  int
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		want     contract.GenreResponse
+		wantErr  error
+		mockFunc func(arg args)
+	}{
+		{
+			name: "error genre id not found",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want:    contract.GenreResponse{},
+			wantErr: appErr.ErrGenreIdNotFound,
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Genre{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "error get genre",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want:    contract.GenreResponse{},
+			wantErr: assert.AnError,
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Genre{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want: contract.GenreResponse{
+				ID:        1,
+				Name:      "horror",
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+			},
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Genre{
+					ModelID:   entity.ModelID{Id: 1},
+					GenreData: entity.GenreData{Name: "horror"},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.args)
+
+			g := InitGenreService(mockGenreRepo)
+			got, err := g.Get(tt.args.ctx, tt.args.id)
+			if err != tt.wantErr {
+				t.Errorf("Genre.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetListGenreService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreRepo := mock_genre.NewMockGenreRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		want     []contract.GenreResponse
+		wantErr  bool
+		mockFunc func()
+	}{
+		{
+			name:    "error",
+			want:    nil,
+			wantErr: true,
+			mockFunc: func() {
+				mockGenreRepo.EXPECT().GetList(gomock.Any()).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: []contract.GenreResponse{
+				{ID: 1, Name: "drama", CreatedAt: "0001-01-01 00:00:00", UpdatedAt: "0001-01-01 00:00:00"},
+				{ID: 2, Name: "horror", CreatedAt: "0001-01-01 00:00:00", UpdatedAt: "0001-01-01 00:00:00"},
+			},
+			wantErr: false,
+			mockFunc: func() {
+				mockGenreRepo.EXPECT().GetList(gomock.Any()).Return([]*entity.Genre{
+					{ModelID: entity.ModelID{Id: 1}, GenreData: entity.GenreData{Name: "drama"}},
+					{ModelID: entity.ModelID{Id: 2}, GenreData: entity.GenreData{Name: "horror"}},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			g := InitGenreService(mockGenreRepo)
+			got, err := g.GetList(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Genre.GetList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateGenreService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreRepo := mock_genre.NewMockGenreRepository(ctrl)
+
+	type args struct {
+		ctx     context.Context
+		request contract.GenreRequest
+		params  *entity.Genre
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		want     contract.GenreResponse
+		wantErr  error
+		mockFunc func(arg args)
+	}{
+		{
+			name: "error duplicate",
+			args: args{
+				ctx:     context.Background(),
+				request: contract.GenreRequest{Name: "horror"},
+				params:  &entity.Genre{GenreData: entity.GenreData{Name: "horror"}},
+			},
+			want:    contract.GenreResponse{},
+			wantErr: appErr.ErrDuplicateGenre,
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Create(gomock.Any(), arg.params).Return(entity.Genre{}, appErr.ErrDuplicateGenre).Times(1)
+			},
+		},
+		{
+			name: "success",
+			args: args{
+				ctx:     context.Background(),
+				request: contract.GenreRequest{Name: "horror"},
+				params:  &entity.Genre{GenreData: entity.GenreData{Name: "horror"}},
+			},
+			want: contract.GenreResponse{
+				ID:        1,
+				Name:      "horror",
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+			},
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Create(gomock.Any(), arg.params).Return(entity.Genre{
+					ModelID:   entity.ModelID{Id: 1},
+					GenreData: entity.GenreData{Name: "horror"},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.args)
+
+			g := InitGenreService(mockGenreRepo)
+			got, err := g.Create(tt.args.ctx, tt.args.request)
+			if err != tt.wantErr {
+				t.Errorf("Genre.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateGenreService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreRepo := mock_genre.NewMockGenreRepository(ctrl)
+
+	type args struct {
+		ctx     context.Context
+		request contract.GenreRequest
+		id      int
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantErr  error
+		mockFunc func(arg args)
+	}{
+		{
+			name: "error id not found",
+			args: args{
+				ctx:     context.Background(),
+				request: contract.GenreRequest{Name: "horror"},
+				id:      1,
+			},
+			wantErr: appErr.ErrGenreIdNotFound,
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Genre{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "error update",
+			args: args{
+				ctx:     context.Background(),
+				request: contract.GenreRequest{Name: "horror"},
+				id:      1,
+			},
+			wantErr: assert.AnError,
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Genre{}, nil).Times(1)
+				mockGenreRepo.EXPECT().Update(gomock.Any(), &entity.Genre{GenreData: entity.GenreData{Name: "horror"}}).Return(assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			args: args{
+				ctx:     context.Background(),
+				request: contract.GenreRequest{Name: "horror"},
+				id:      1,
+			},
+			wantErr: nil,
+			mockFunc: func(arg args) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Genre{}, nil).Times(1)
+				mockGenreRepo.EXPECT().Update(gomock.Any(), &entity.Genre{GenreData: entity.GenreData{Name: "horror"}}).Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.args)
+
+			g := InitGenreService(mockGenreRepo)
+			_, err := g.Update(tt.args.ctx, tt.args.request, tt.args.id)
+			if err != tt.wantErr {
+				t.Errorf("Genre.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteGenreService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreRepo := mock_genre.NewMockGenreRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		id       int
+		wantErr  error
+		mockFunc func(id int)
+	}{
+		{
+			name:    "error id not found",
+			id:      1,
+			wantErr: appErr.ErrGenreIdNotFound,
+			mockFunc: func(id int) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Genre{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error delete",
+			id:      1,
+			wantErr: assert.AnError,
+			mockFunc: func(id int) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Genre{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mockGenreRepo.EXPECT().Delete(gomock.Any(), int64(1)).Return(assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			id:      1,
+			wantErr: nil,
+			mockFunc: func(id int) {
+				mockGenreRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Genre{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mockGenreRepo.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.id)
+
+			g := InitGenreService(mockGenreRepo)
+			err := g.Delete(context.Background(), tt.id)
+			if err != tt.wantErr {
+				t.Errorf("Genre.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
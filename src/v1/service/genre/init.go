@@ -0,0 +1,15 @@
+package genre
+
+import (
+	"context"
+
+	"github.com/Risuii/movie/src/entity"
+)
+
+type GenreRepository interface {
+	Create(ctx context.Context, data *entity.Genre) (entity.Genre, error)
+	GetList(ctx context.Context) ([]*entity.Genre, error)
+	Get(ctx context.Context, id int) (entity.Genre, error)
+	Update(ctx context.Context, data *entity.Genre) error
+	Delete(ctx context.Context, id int64) error
+}
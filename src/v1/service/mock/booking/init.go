@@ -0,0 +1,273 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: booking/init.go
+//
+// Generated by this command:
+//
+//	mockgen -source=booking/init.go -destination=mock/booking/init.go
+//
+// Package mock_booking is a generated GoMock package.
+package mock_booking
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/Risuii/movie/src/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBookingRepository is a mock of BookingRepository interface.
+type MockBookingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingRepositoryMockRecorder
+}
+
+// MockBookingRepositoryMockRecorder is the mock recorder for MockBookingRepository.
+type MockBookingRepositoryMockRecorder struct {
+	mock *MockBookingRepository
+}
+
+// NewMockBookingRepository creates a new mock instance.
+func NewMockBookingRepository(ctrl *gomock.Controller) *MockBookingRepository {
+	mock := &MockBookingRepository{ctrl: ctrl}
+	mock.recorder = &MockBookingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingRepository) EXPECT() *MockBookingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBookingRepository) Create(ctx context.Context, data *entity.Booking) (entity.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(entity.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookingRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookingRepository)(nil).Create), ctx, data)
+}
+
+// Get mocks base method.
+func (m *MockBookingRepository) Get(ctx context.Context, id int64) (entity.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBookingRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBookingRepository)(nil).Get), ctx, id)
+}
+
+// GetBookedSeats mocks base method.
+func (m *MockBookingRepository) GetBookedSeats(ctx context.Context, showtimeID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookedSeats", ctx, showtimeID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookedSeats indicates an expected call of GetBookedSeats.
+func (mr *MockBookingRepositoryMockRecorder) GetBookedSeats(ctx, showtimeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookedSeats", reflect.TypeOf((*MockBookingRepository)(nil).GetBookedSeats), ctx, showtimeID)
+}
+
+// GetByUser mocks base method.
+func (m *MockBookingRepository) GetByUser(ctx context.Context, userID string) ([]*entity.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockBookingRepositoryMockRecorder) GetByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockBookingRepository)(nil).GetByUser), ctx, userID)
+}
+
+// MockHoldRepository is a mock of HoldRepository interface.
+type MockHoldRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldRepositoryMockRecorder
+}
+
+// MockHoldRepositoryMockRecorder is the mock recorder for MockHoldRepository.
+type MockHoldRepositoryMockRecorder struct {
+	mock *MockHoldRepository
+}
+
+// NewMockHoldRepository creates a new mock instance.
+func NewMockHoldRepository(ctrl *gomock.Controller) *MockHoldRepository {
+	mock := &MockHoldRepository{ctrl: ctrl}
+	mock.recorder = &MockHoldRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldRepository) EXPECT() *MockHoldRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHoldRepository) Create(ctx context.Context, hold *entity.Hold, ttl time.Duration) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, hold, ttl)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHoldRepositoryMockRecorder) Create(ctx, hold, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHoldRepository)(nil).Create), ctx, hold, ttl)
+}
+
+// Extend mocks base method.
+func (m *MockHoldRepository) Extend(ctx context.Context, hold *entity.Hold, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", ctx, hold, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockHoldRepositoryMockRecorder) Extend(ctx, hold, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockHoldRepository)(nil).Extend), ctx, hold, ttl)
+}
+
+// Get mocks base method.
+func (m *MockHoldRepository) Get(ctx context.Context, id string) (entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHoldRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHoldRepository)(nil).Get), ctx, id)
+}
+
+// GetHeldSeats mocks base method.
+func (m *MockHoldRepository) GetHeldSeats(ctx context.Context, showtimeID int64, seats []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldSeats", ctx, showtimeID, seats)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeldSeats indicates an expected call of GetHeldSeats.
+func (mr *MockHoldRepositoryMockRecorder) GetHeldSeats(ctx, showtimeID, seats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldSeats", reflect.TypeOf((*MockHoldRepository)(nil).GetHeldSeats), ctx, showtimeID, seats)
+}
+
+// Release mocks base method.
+func (m *MockHoldRepository) Release(ctx context.Context, hold *entity.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, hold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockHoldRepositoryMockRecorder) Release(ctx, hold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockHoldRepository)(nil).Release), ctx, hold)
+}
+
+// MockShowtimeRepository is a mock of ShowtimeRepository interface.
+type MockShowtimeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShowtimeRepositoryMockRecorder
+}
+
+// MockShowtimeRepositoryMockRecorder is the mock recorder for MockShowtimeRepository.
+type MockShowtimeRepositoryMockRecorder struct {
+	mock *MockShowtimeRepository
+}
+
+// NewMockShowtimeRepository creates a new mock instance.
+func NewMockShowtimeRepository(ctrl *gomock.Controller) *MockShowtimeRepository {
+	mock := &MockShowtimeRepository{ctrl: ctrl}
+	mock.recorder = &MockShowtimeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShowtimeRepository) EXPECT() *MockShowtimeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockShowtimeRepository) Get(ctx context.Context, id int64) (entity.Showtime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Showtime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockShowtimeRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockShowtimeRepository)(nil).Get), ctx, id)
+}
+
+// MockCinemaRepository is a mock of CinemaRepository interface.
+type MockCinemaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCinemaRepositoryMockRecorder
+}
+
+// MockCinemaRepositoryMockRecorder is the mock recorder for MockCinemaRepository.
+type MockCinemaRepositoryMockRecorder struct {
+	mock *MockCinemaRepository
+}
+
+// NewMockCinemaRepository creates a new mock instance.
+func NewMockCinemaRepository(ctrl *gomock.Controller) *MockCinemaRepository {
+	mock := &MockCinemaRepository{ctrl: ctrl}
+	mock.recorder = &MockCinemaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCinemaRepository) EXPECT() *MockCinemaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetScreen mocks base method.
+func (m *MockCinemaRepository) GetScreen(ctx context.Context, id int) (entity.Screen, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreen", ctx, id)
+	ret0, _ := ret[0].(entity.Screen)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreen indicates an expected call of GetScreen.
+func (mr *MockCinemaRepositoryMockRecorder) GetScreen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreen", reflect.TypeOf((*MockCinemaRepository)(nil).GetScreen), ctx, id)
+}
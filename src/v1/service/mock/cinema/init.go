@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cinema/init.go
+//
+// Generated by this command:
+//
+//	mockgen -source=cinema/init.go -destination=mock/cinema/init.go
+//
+// Package mock_cinema is a generated GoMock package.
+package mock_cinema
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/Risuii/movie/src/entity"
+	contract "github.com/Risuii/movie/src/v1/contract"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCinemaRepository is a mock of CinemaRepository interface.
+type MockCinemaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCinemaRepositoryMockRecorder
+}
+
+// MockCinemaRepositoryMockRecorder is the mock recorder for MockCinemaRepository.
+type MockCinemaRepositoryMockRecorder struct {
+	mock *MockCinemaRepository
+}
+
+// NewMockCinemaRepository creates a new mock instance.
+func NewMockCinemaRepository(ctrl *gomock.Controller) *MockCinemaRepository {
+	mock := &MockCinemaRepository{ctrl: ctrl}
+	mock.recorder = &MockCinemaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCinemaRepository) EXPECT() *MockCinemaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCinemaRepository) Create(ctx context.Context, data *entity.Cinema) (entity.Cinema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(entity.Cinema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCinemaRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCinemaRepository)(nil).Create), ctx, data)
+}
+
+// CreateScreen mocks base method.
+func (m *MockCinemaRepository) CreateScreen(ctx context.Context, data *entity.Screen) (entity.Screen, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScreen", ctx, data)
+	ret0, _ := ret[0].(entity.Screen)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScreen indicates an expected call of CreateScreen.
+func (mr *MockCinemaRepositoryMockRecorder) CreateScreen(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScreen", reflect.TypeOf((*MockCinemaRepository)(nil).CreateScreen), ctx, data)
+}
+
+// Delete mocks base method.
+func (m *MockCinemaRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCinemaRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCinemaRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteScreen mocks base method.
+func (m *MockCinemaRepository) DeleteScreen(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScreen", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScreen indicates an expected call of DeleteScreen.
+func (mr *MockCinemaRepositoryMockRecorder) DeleteScreen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScreen", reflect.TypeOf((*MockCinemaRepository)(nil).DeleteScreen), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCinemaRepository) Get(ctx context.Context, id int) (entity.Cinema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Cinema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCinemaRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCinemaRepository)(nil).Get), ctx, id)
+}
+
+// GetCinemaCount mocks base method.
+func (m *MockCinemaRepository) GetCinemaCount(ctx context.Context, param contract.GetListParam) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCinemaCount", ctx, param)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCinemaCount indicates an expected call of GetCinemaCount.
+func (mr *MockCinemaRepositoryMockRecorder) GetCinemaCount(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCinemaCount", reflect.TypeOf((*MockCinemaRepository)(nil).GetCinemaCount), ctx, param)
+}
+
+// GetList mocks base method.
+func (m *MockCinemaRepository) GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Cinema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, params)
+	ret0, _ := ret[0].([]*entity.Cinema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockCinemaRepositoryMockRecorder) GetList(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockCinemaRepository)(nil).GetList), ctx, params)
+}
+
+// GetScreen mocks base method.
+func (m *MockCinemaRepository) GetScreen(ctx context.Context, id int) (entity.Screen, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreen", ctx, id)
+	ret0, _ := ret[0].(entity.Screen)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreen indicates an expected call of GetScreen.
+func (mr *MockCinemaRepositoryMockRecorder) GetScreen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreen", reflect.TypeOf((*MockCinemaRepository)(nil).GetScreen), ctx, id)
+}
+
+// GetScreens mocks base method.
+func (m *MockCinemaRepository) GetScreens(ctx context.Context, cinemaID int64) ([]*entity.Screen, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreens", ctx, cinemaID)
+	ret0, _ := ret[0].([]*entity.Screen)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreens indicates an expected call of GetScreens.
+func (mr *MockCinemaRepositoryMockRecorder) GetScreens(ctx, cinemaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreens", reflect.TypeOf((*MockCinemaRepository)(nil).GetScreens), ctx, cinemaID)
+}
+
+// Update mocks base method.
+func (m *MockCinemaRepository) Update(ctx context.Context, data *entity.Cinema) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCinemaRepositoryMockRecorder) Update(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCinemaRepository)(nil).Update), ctx, data)
+}
+
+// UpdateScreen mocks base method.
+func (m *MockCinemaRepository) UpdateScreen(ctx context.Context, data *entity.Screen) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScreen", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScreen indicates an expected call of UpdateScreen.
+func (mr *MockCinemaRepositoryMockRecorder) UpdateScreen(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScreen", reflect.TypeOf((*MockCinemaRepository)(nil).UpdateScreen), ctx, data)
+}
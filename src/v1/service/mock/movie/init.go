@@ -11,6 +11,7 @@ package mock_movie
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/Risuii/movie/src/entity"
 	contract "github.com/Risuii/movie/src/v1/contract"
@@ -55,6 +56,35 @@ func (mr *MockMovieRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMovieRepository)(nil).Create), ctx, data)
 }
 
+// CreateRevision mocks base method.
+func (m *MockMovieRepository) CreateRevision(ctx context.Context, data *entity.MovieRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevision", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRevision indicates an expected call of CreateRevision.
+func (mr *MockMovieRepositoryMockRecorder) CreateRevision(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevision", reflect.TypeOf((*MockMovieRepository)(nil).CreateRevision), ctx, data)
+}
+
+// DeferCacheInvalidation mocks base method.
+func (m *MockMovieRepository) DeferCacheInvalidation(ctx context.Context) (context.Context, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferCacheInvalidation", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// DeferCacheInvalidation indicates an expected call of DeferCacheInvalidation.
+func (mr *MockMovieRepositoryMockRecorder) DeferCacheInvalidation(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferCacheInvalidation", reflect.TypeOf((*MockMovieRepository)(nil).DeferCacheInvalidation), ctx)
+}
+
 // Delete mocks base method.
 func (m *MockMovieRepository) Delete(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -69,6 +99,20 @@ func (mr *MockMovieRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMovieRepository)(nil).Delete), ctx, id)
 }
 
+// Export mocks base method.
+func (m *MockMovieRepository) Export(ctx context.Context, params contract.GetListParam, fn func(*entity.Movie) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, params, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockMovieRepositoryMockRecorder) Export(ctx, params, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockMovieRepository)(nil).Export), ctx, params, fn)
+}
+
 // Get mocks base method.
 func (m *MockMovieRepository) Get(ctx context.Context, id int) (entity.Movie, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +128,126 @@ func (mr *MockMovieRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMovieRepository)(nil).Get), ctx, id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockMovieRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]*entity.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].(map[int64]*entity.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockMovieRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockMovieRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// GetCursorList mocks base method.
+func (m *MockMovieRepository) GetCursorList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursorList", ctx, params)
+	ret0, _ := ret[0].([]*entity.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursorList indicates an expected call of GetCursorList.
+func (mr *MockMovieRepositoryMockRecorder) GetCursorList(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursorList", reflect.TypeOf((*MockMovieRepository)(nil).GetCursorList), ctx, params)
+}
+
+// GetDeletedCount mocks base method.
+func (m *MockMovieRepository) GetDeletedCount(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedCount", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedCount indicates an expected call of GetDeletedCount.
+func (mr *MockMovieRepositoryMockRecorder) GetDeletedCount(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedCount", reflect.TypeOf((*MockMovieRepository)(nil).GetDeletedCount), ctx)
+}
+
+// GetDeletedList mocks base method.
+func (m *MockMovieRepository) GetDeletedList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedList", ctx, params)
+	ret0, _ := ret[0].([]*entity.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedList indicates an expected call of GetDeletedList.
+func (mr *MockMovieRepositoryMockRecorder) GetDeletedList(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedList", reflect.TypeOf((*MockMovieRepository)(nil).GetDeletedList), ctx, params)
+}
+
+// GetDuplicateCount mocks base method.
+func (m *MockMovieRepository) GetDuplicateCount(ctx context.Context, threshold float64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicateCount", ctx, threshold)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicateCount indicates an expected call of GetDuplicateCount.
+func (mr *MockMovieRepositoryMockRecorder) GetDuplicateCount(ctx, threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateCount", reflect.TypeOf((*MockMovieRepository)(nil).GetDuplicateCount), ctx, threshold)
+}
+
+// GetDuplicates mocks base method.
+func (m *MockMovieRepository) GetDuplicates(ctx context.Context, params contract.GetDuplicateParam) ([]*entity.MovieDuplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicates", ctx, params)
+	ret0, _ := ret[0].([]*entity.MovieDuplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockMovieRepositoryMockRecorder) GetDuplicates(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockMovieRepository)(nil).GetDuplicates), ctx, params)
+}
+
+// GetGenreIDs mocks base method.
+func (m *MockMovieRepository) GetGenreIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenreIDs", ctx, ids)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenreIDs indicates an expected call of GetGenreIDs.
+func (mr *MockMovieRepositoryMockRecorder) GetGenreIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreIDs", reflect.TypeOf((*MockMovieRepository)(nil).GetGenreIDs), ctx, ids)
+}
+
+// GetImportJob mocks base method.
+func (m *MockMovieRepository) GetImportJob(ctx context.Context, id string) (entity.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id)
+	ret0, _ := ret[0].(entity.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockMovieRepositoryMockRecorder) GetImportJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockMovieRepository)(nil).GetImportJob), ctx, id)
+}
+
 // GetList mocks base method.
 func (m *MockMovieRepository) GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +278,258 @@ func (mr *MockMovieRepositoryMockRecorder) GetMovieCount(ctx, param any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieCount", reflect.TypeOf((*MockMovieRepository)(nil).GetMovieCount), ctx, param)
 }
 
+// GetMovieCredits mocks base method.
+func (m *MockMovieRepository) GetMovieCredits(ctx context.Context, movieID int64) ([]*entity.MovieCredit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieCredits", ctx, movieID)
+	ret0, _ := ret[0].([]*entity.MovieCredit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieCredits indicates an expected call of GetMovieCredits.
+func (mr *MockMovieRepositoryMockRecorder) GetMovieCredits(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieCredits", reflect.TypeOf((*MockMovieRepository)(nil).GetMovieCredits), ctx, movieID)
+}
+
+// GetRevision mocks base method.
+func (m *MockMovieRepository) GetRevision(ctx context.Context, movieID int64, revision int) (entity.MovieRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, movieID, revision)
+	ret0, _ := ret[0].(entity.MovieRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockMovieRepositoryMockRecorder) GetRevision(ctx, movieID, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockMovieRepository)(nil).GetRevision), ctx, movieID, revision)
+}
+
+// GetRevisionCount mocks base method.
+func (m *MockMovieRepository) GetRevisionCount(ctx context.Context, movieID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisionCount", ctx, movieID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisionCount indicates an expected call of GetRevisionCount.
+func (mr *MockMovieRepositoryMockRecorder) GetRevisionCount(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisionCount", reflect.TypeOf((*MockMovieRepository)(nil).GetRevisionCount), ctx, movieID)
+}
+
+// GetRevisions mocks base method.
+func (m *MockMovieRepository) GetRevisions(ctx context.Context, movieID int64, params contract.GetListParam) ([]*entity.MovieRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, movieID, params)
+	ret0, _ := ret[0].([]*entity.MovieRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockMovieRepositoryMockRecorder) GetRevisions(ctx, movieID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockMovieRepository)(nil).GetRevisions), ctx, movieID, params)
+}
+
+// GetSearchCount mocks base method.
+func (m *MockMovieRepository) GetSearchCount(ctx context.Context, params contract.SearchParam) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSearchCount", ctx, params)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSearchCount indicates an expected call of GetSearchCount.
+func (mr *MockMovieRepositoryMockRecorder) GetSearchCount(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchCount", reflect.TypeOf((*MockMovieRepository)(nil).GetSearchCount), ctx, params)
+}
+
+// GetSnapshot mocks base method.
+func (m *MockMovieRepository) GetSnapshot(ctx context.Context, id int64) (entity.MovieSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot", ctx, id)
+	ret0, _ := ret[0].(entity.MovieSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockMovieRepositoryMockRecorder) GetSnapshot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockMovieRepository)(nil).GetSnapshot), ctx, id)
+}
+
+// HasBookings mocks base method.
+func (m *MockMovieRepository) HasBookings(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasBookings", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasBookings indicates an expected call of HasBookings.
+func (mr *MockMovieRepositoryMockRecorder) HasBookings(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasBookings", reflect.TypeOf((*MockMovieRepository)(nil).HasBookings), ctx, id)
+}
+
+// LockMovieIDByTitle mocks base method.
+func (m *MockMovieRepository) LockMovieIDByTitle(ctx context.Context, title string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockMovieIDByTitle", ctx, title)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockMovieIDByTitle indicates an expected call of LockMovieIDByTitle.
+func (mr *MockMovieRepositoryMockRecorder) LockMovieIDByTitle(ctx, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockMovieIDByTitle", reflect.TypeOf((*MockMovieRepository)(nil).LockMovieIDByTitle), ctx, title)
+}
+
+// LockSnapshot mocks base method.
+func (m *MockMovieRepository) LockSnapshot(ctx context.Context, id int64) (entity.MovieSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSnapshot", ctx, id)
+	ret0, _ := ret[0].(entity.MovieSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockSnapshot indicates an expected call of LockSnapshot.
+func (mr *MockMovieRepositoryMockRecorder) LockSnapshot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSnapshot", reflect.TypeOf((*MockMovieRepository)(nil).LockSnapshot), ctx, id)
+}
+
+// Purge mocks base method.
+func (m *MockMovieRepository) Purge(ctx context.Context, id int64) (entity.ImageKeys, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(entity.ImageKeys)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockMovieRepositoryMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockMovieRepository)(nil).Purge), ctx, id)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockMovieRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) ([]entity.ImageKeys, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", ctx, before)
+	ret0, _ := ret[0].([]entity.ImageKeys)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockMovieRepositoryMockRecorder) PurgeDeletedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockMovieRepository)(nil).PurgeDeletedBefore), ctx, before)
+}
+
+// ReplaceMovieCredits mocks base method.
+func (m *MockMovieRepository) ReplaceMovieCredits(ctx context.Context, movieID int64, credits []*entity.MovieCredit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMovieCredits", ctx, movieID, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceMovieCredits indicates an expected call of ReplaceMovieCredits.
+func (mr *MockMovieRepositoryMockRecorder) ReplaceMovieCredits(ctx, movieID, credits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMovieCredits", reflect.TypeOf((*MockMovieRepository)(nil).ReplaceMovieCredits), ctx, movieID, credits)
+}
+
+// ReplaceMovieGenres mocks base method.
+func (m *MockMovieRepository) ReplaceMovieGenres(ctx context.Context, movieID int64, genreIDs []int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMovieGenres", ctx, movieID, genreIDs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceMovieGenres indicates an expected call of ReplaceMovieGenres.
+func (mr *MockMovieRepositoryMockRecorder) ReplaceMovieGenres(ctx, movieID, genreIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMovieGenres", reflect.TypeOf((*MockMovieRepository)(nil).ReplaceMovieGenres), ctx, movieID, genreIDs)
+}
+
+// Restore mocks base method.
+func (m *MockMovieRepository) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockMovieRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockMovieRepository)(nil).Restore), ctx, id)
+}
+
+// SaveImportJob mocks base method.
+func (m *MockMovieRepository) SaveImportJob(ctx context.Context, job *entity.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveImportJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveImportJob indicates an expected call of SaveImportJob.
+func (mr *MockMovieRepositoryMockRecorder) SaveImportJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImportJob", reflect.TypeOf((*MockMovieRepository)(nil).SaveImportJob), ctx, job)
+}
+
+// Search mocks base method.
+func (m *MockMovieRepository) Search(ctx context.Context, params contract.SearchParam) ([]*entity.MovieSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, params)
+	ret0, _ := ret[0].([]*entity.MovieSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockMovieRepositoryMockRecorder) Search(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockMovieRepository)(nil).Search), ctx, params)
+}
+
+// Suggest mocks base method.
+func (m *MockMovieRepository) Suggest(ctx context.Context, params contract.SuggestParam) ([]*entity.MovieSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, params)
+	ret0, _ := ret[0].([]*entity.MovieSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockMovieRepositoryMockRecorder) Suggest(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockMovieRepository)(nil).Suggest), ctx, params)
+}
+
 // Update mocks base method.
 func (m *MockMovieRepository) Update(ctx context.Context, data *entity.Movie) error {
 	m.ctrl.T.Helper()
@@ -127,3 +543,82 @@ func (mr *MockMovieRepositoryMockRecorder) Update(ctx, data any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMovieRepository)(nil).Update), ctx, data)
 }
+
+// UpdateImageKeys mocks base method.
+func (m *MockMovieRepository) UpdateImageKeys(ctx context.Context, movieID int64, keys entity.ImageKeys) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImageKeys", ctx, movieID, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateImageKeys indicates an expected call of UpdateImageKeys.
+func (mr *MockMovieRepositoryMockRecorder) UpdateImageKeys(ctx, movieID, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImageKeys", reflect.TypeOf((*MockMovieRepository)(nil).UpdateImageKeys), ctx, movieID, keys)
+}
+
+// MockImageStorage is a mock of ImageStorage interface.
+type MockImageStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageStorageMockRecorder
+}
+
+// MockImageStorageMockRecorder is the mock recorder for MockImageStorage.
+type MockImageStorageMockRecorder struct {
+	mock *MockImageStorage
+}
+
+// NewMockImageStorage creates a new mock instance.
+func NewMockImageStorage(ctrl *gomock.Controller) *MockImageStorage {
+	mock := &MockImageStorage{ctrl: ctrl}
+	mock.recorder = &MockImageStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageStorage) EXPECT() *MockImageStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockImageStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImageStorageMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImageStorage)(nil).Delete), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockImageStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, data, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockImageStorageMockRecorder) Put(ctx, key, data, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockImageStorage)(nil).Put), ctx, key, data, contentType)
+}
+
+// URL mocks base method.
+func (m *MockImageStorage) URL(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockImageStorageMockRecorder) URL(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockImageStorage)(nil).URL), key)
+}
@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: review/init.go
+//
+// Generated by this command:
+//
+//	mockgen -source=review/init.go -destination=mock/review/init.go
+//
+// Package mock_review is a generated GoMock package.
+package mock_review
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/Risuii/movie/src/entity"
+	contract "github.com/Risuii/movie/src/v1/contract"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewRepository) Create(ctx context.Context, data *entity.Review) (entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewRepository)(nil).Create), ctx, data)
+}
+
+// DeferCacheInvalidation mocks base method.
+func (m *MockReviewRepository) DeferCacheInvalidation(ctx context.Context) (context.Context, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferCacheInvalidation", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// DeferCacheInvalidation indicates an expected call of DeferCacheInvalidation.
+func (mr *MockReviewRepositoryMockRecorder) DeferCacheInvalidation(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferCacheInvalidation", reflect.TypeOf((*MockReviewRepository)(nil).DeferCacheInvalidation), ctx)
+}
+
+// Delete mocks base method.
+func (m *MockReviewRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReviewRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReviewRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockReviewRepository) Get(ctx context.Context, movieID, id int64) (entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, movieID, id)
+	ret0, _ := ret[0].(entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReviewRepositoryMockRecorder) Get(ctx, movieID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReviewRepository)(nil).Get), ctx, movieID, id)
+}
+
+// GetList mocks base method.
+func (m *MockReviewRepository) GetList(ctx context.Context, movieID int64, params contract.GetListParam) ([]*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, movieID, params)
+	ret0, _ := ret[0].([]*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockReviewRepositoryMockRecorder) GetList(ctx, movieID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockReviewRepository)(nil).GetList), ctx, movieID, params)
+}
+
+// GetReviewCount mocks base method.
+func (m *MockReviewRepository) GetReviewCount(ctx context.Context, movieID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewCount", ctx, movieID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewCount indicates an expected call of GetReviewCount.
+func (mr *MockReviewRepositoryMockRecorder) GetReviewCount(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewCount", reflect.TypeOf((*MockReviewRepository)(nil).GetReviewCount), ctx, movieID)
+}
+
+// LockMovie mocks base method.
+func (m *MockReviewRepository) LockMovie(ctx context.Context, movieID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockMovie", ctx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockMovie indicates an expected call of LockMovie.
+func (mr *MockReviewRepositoryMockRecorder) LockMovie(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockMovie", reflect.TypeOf((*MockReviewRepository)(nil).LockMovie), ctx, movieID)
+}
+
+// RefreshMovieRating mocks base method.
+func (m *MockReviewRepository) RefreshMovieRating(ctx context.Context, movieID int64) (entity.MovieRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshMovieRating", ctx, movieID)
+	ret0, _ := ret[0].(entity.MovieRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshMovieRating indicates an expected call of RefreshMovieRating.
+func (mr *MockReviewRepositoryMockRecorder) RefreshMovieRating(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshMovieRating", reflect.TypeOf((*MockReviewRepository)(nil).RefreshMovieRating), ctx, movieID)
+}
+
+// Update mocks base method.
+func (m *MockReviewRepository) Update(ctx context.Context, data *entity.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReviewRepositoryMockRecorder) Update(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReviewRepository)(nil).Update), ctx, data)
+}
+
+// MockMovieRepository is a mock of MovieRepository interface.
+type MockMovieRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieRepositoryMockRecorder
+}
+
+// MockMovieRepositoryMockRecorder is the mock recorder for MockMovieRepository.
+type MockMovieRepositoryMockRecorder struct {
+	mock *MockMovieRepository
+}
+
+// NewMockMovieRepository creates a new mock instance.
+func NewMockMovieRepository(ctrl *gomock.Controller) *MockMovieRepository {
+	mock := &MockMovieRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieRepository) EXPECT() *MockMovieRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockMovieRepository) Get(ctx context.Context, id int) (entity.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMovieRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMovieRepository)(nil).Get), ctx, id)
+}
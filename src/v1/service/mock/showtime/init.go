@@ -0,0 +1,220 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: showtime/init.go
+//
+// Generated by this command:
+//
+//	mockgen -source=showtime/init.go -destination=mock/showtime/init.go
+//
+// Package mock_showtime is a generated GoMock package.
+package mock_showtime
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/Risuii/movie/src/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShowtimeRepository is a mock of ShowtimeRepository interface.
+type MockShowtimeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShowtimeRepositoryMockRecorder
+}
+
+// MockShowtimeRepositoryMockRecorder is the mock recorder for MockShowtimeRepository.
+type MockShowtimeRepositoryMockRecorder struct {
+	mock *MockShowtimeRepository
+}
+
+// NewMockShowtimeRepository creates a new mock instance.
+func NewMockShowtimeRepository(ctrl *gomock.Controller) *MockShowtimeRepository {
+	mock := &MockShowtimeRepository{ctrl: ctrl}
+	mock.recorder = &MockShowtimeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShowtimeRepository) EXPECT() *MockShowtimeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShowtimeRepository) Create(ctx context.Context, data *entity.Showtime) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShowtimeRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShowtimeRepository)(nil).Create), ctx, data)
+}
+
+// Delete mocks base method.
+func (m *MockShowtimeRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShowtimeRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShowtimeRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockShowtimeRepository) Get(ctx context.Context, id int64) (entity.Showtime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Showtime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockShowtimeRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockShowtimeRepository)(nil).Get), ctx, id)
+}
+
+// GetByCinema mocks base method.
+func (m *MockShowtimeRepository) GetByCinema(ctx context.Context, cinemaID int64, from, to time.Time) ([]*entity.Showtime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCinema", ctx, cinemaID, from, to)
+	ret0, _ := ret[0].([]*entity.Showtime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCinema indicates an expected call of GetByCinema.
+func (mr *MockShowtimeRepositoryMockRecorder) GetByCinema(ctx, cinemaID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCinema", reflect.TypeOf((*MockShowtimeRepository)(nil).GetByCinema), ctx, cinemaID, from, to)
+}
+
+// GetByMovie mocks base method.
+func (m *MockShowtimeRepository) GetByMovie(ctx context.Context, movieID int64, from, to time.Time, city string) ([]*entity.Showtime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMovie", ctx, movieID, from, to, city)
+	ret0, _ := ret[0].([]*entity.Showtime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMovie indicates an expected call of GetByMovie.
+func (mr *MockShowtimeRepositoryMockRecorder) GetByMovie(ctx, movieID, from, to, city any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMovie", reflect.TypeOf((*MockShowtimeRepository)(nil).GetByMovie), ctx, movieID, from, to, city)
+}
+
+// Update mocks base method.
+func (m *MockShowtimeRepository) Update(ctx context.Context, data *entity.Showtime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShowtimeRepositoryMockRecorder) Update(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShowtimeRepository)(nil).Update), ctx, data)
+}
+
+// MockMovieRepository is a mock of MovieRepository interface.
+type MockMovieRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieRepositoryMockRecorder
+}
+
+// MockMovieRepositoryMockRecorder is the mock recorder for MockMovieRepository.
+type MockMovieRepositoryMockRecorder struct {
+	mock *MockMovieRepository
+}
+
+// NewMockMovieRepository creates a new mock instance.
+func NewMockMovieRepository(ctrl *gomock.Controller) *MockMovieRepository {
+	mock := &MockMovieRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieRepository) EXPECT() *MockMovieRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockMovieRepository) Get(ctx context.Context, id int) (entity.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMovieRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMovieRepository)(nil).Get), ctx, id)
+}
+
+// MockCinemaRepository is a mock of CinemaRepository interface.
+type MockCinemaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCinemaRepositoryMockRecorder
+}
+
+// MockCinemaRepositoryMockRecorder is the mock recorder for MockCinemaRepository.
+type MockCinemaRepositoryMockRecorder struct {
+	mock *MockCinemaRepository
+}
+
+// NewMockCinemaRepository creates a new mock instance.
+func NewMockCinemaRepository(ctrl *gomock.Controller) *MockCinemaRepository {
+	mock := &MockCinemaRepository{ctrl: ctrl}
+	mock.recorder = &MockCinemaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCinemaRepository) EXPECT() *MockCinemaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCinemaRepository) Get(ctx context.Context, id int) (entity.Cinema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Cinema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCinemaRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCinemaRepository)(nil).Get), ctx, id)
+}
+
+// GetScreen mocks base method.
+func (m *MockCinemaRepository) GetScreen(ctx context.Context, id int) (entity.Screen, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreen", ctx, id)
+	ret0, _ := ret[0].(entity.Screen)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreen indicates an expected call of GetScreen.
+func (mr *MockCinemaRepositoryMockRecorder) GetScreen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreen", reflect.TypeOf((*MockCinemaRepository)(nil).GetScreen), ctx, id)
+}
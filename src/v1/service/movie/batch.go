@@ -0,0 +1,30 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+)
+
+// BatchGet return one item per requested id in request order,
+// movie that does not exist or is deleted is returned with Found false
+func (ms *MovieService) BatchGet(ctx context.Context, request contract.BatchGetMovieRequest) (res []*contract.BatchGetMovieResponse, err error) {
+	movies, err := ms.MovieRepo.GetByIDs(ctx, stream.Distinct(stream.OfSlice(request.IDs)).ToSlice())
+	if err != nil {
+		log.Println("batch get movie err: ", err)
+		return
+	}
+
+	res = make([]*contract.BatchGetMovieResponse, len(request.IDs))
+	for i, id := range request.IDs {
+		res[i] = &contract.BatchGetMovieResponse{ID: id}
+		if m, ok := movies[id]; ok {
+			res[i].Found = true
+			res[i].Movie = ms.mapperMovieItemResponse(m)
+		}
+	}
+
+	return
+}
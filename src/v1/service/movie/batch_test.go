@@ -0,0 +1,67 @@
+package movie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestBatchGetMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+
+	request := contract.BatchGetMovieRequest{IDs: []int64{3, 7, 1, 3}}
+
+	tests := []struct {
+		name     string
+		want     []*contract.BatchGetMovieResponse
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error get movies",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetByIDs(gomock.Any(), []int64{3, 7, 1}).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success keep request order and mark not found",
+			want: []*contract.BatchGetMovieResponse{
+				{ID: 3, Found: true, Movie: &contract.MovieResponse{ID: 3, Title: "avengers", CreatedAt: "0001-01-01 00:00:00", UpdatedAt: "0001-01-01 00:00:00"}},
+				{ID: 7},
+				{ID: 1, Found: true, Movie: &contract.MovieResponse{ID: 1, Title: "spiderman", CreatedAt: "0001-01-01 00:00:00", UpdatedAt: "0001-01-01 00:00:00"}},
+				{ID: 3, Found: true, Movie: &contract.MovieResponse{ID: 3, Title: "avengers", CreatedAt: "0001-01-01 00:00:00", UpdatedAt: "0001-01-01 00:00:00"}},
+			},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetByIDs(gomock.Any(), []int64{3, 7, 1}).Return(map[int64]*entity.Movie{
+					1: {ModelID: entity.ModelID{Id: 1}, MovieData: entity.MovieData{Title: "spiderman"}},
+					3: {ModelID: entity.ModelID{Id: 3}, MovieData: entity.MovieData{Title: "avengers"}},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitMovieService(mockMovieRepo, nil, nil, false).BatchGet(context.Background(), request)
+			if err != tt.wantErr {
+				t.Errorf("Movie.BatchGet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package movie
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+)
+
+// bulkItem is one id of a bulk operation
+type bulkItem struct {
+	operation *contract.BulkOperationRequest
+	id        int64
+}
+
+// Bulk apply every operation to its id in request order and report one result per id,
+// the movie cache is invalidated once after the batch instead of after every row.
+// Bulk has no If-Match so every item is written as if If-Match is *
+func (ms *MovieService) Bulk(ctx context.Context, request contract.BulkRequest) (res contract.BulkResponse, err error) {
+	ctx, invalidateCache := ms.MovieRepo.DeferCacheInvalidation(ctx)
+	defer invalidateCache()
+
+	var items []bulkItem
+	res = contract.BulkResponse{Mode: request.Mode}
+	for i := range request.Operations {
+		operation := &request.Operations[i]
+		for _, id := range operation.IDs {
+			items = append(items, bulkItem{operation: operation, id: id})
+			res.Results = append(res.Results, &contract.BulkItemResponse{
+				Operation: i,
+				Action:    operation.Action,
+				ID:        id,
+			})
+		}
+	}
+
+	if request.Mode == contract.BulkModeBestEffort {
+		for i, item := range items {
+			err := frsAtomic.Atomic(ctx, ms.Atomic, func(ctx context.Context) error {
+				return ms.bulkItem(ctx, item)
+			})
+			setBulkResult(res.Results[i], err)
+		}
+	} else {
+		failed := -1
+		txErr := frsAtomic.Atomic(ctx, ms.Atomic, func(ctx context.Context) error {
+			for i, item := range items {
+				if err := ms.bulkItem(ctx, item); err != nil {
+					failed = i
+					return err
+				}
+			}
+			return nil
+		})
+		if txErr != nil && failed < 0 {
+			log.Println("bulk movie err: ", txErr)
+			return res, txErr
+		}
+
+		for i, result := range res.Results {
+			switch {
+			case failed < 0:
+				setBulkResult(result, nil)
+			case i == failed:
+				setBulkResult(result, txErr)
+			default:
+				result.Status = contract.BulkStatusRolledBack
+				result.Reason = fmt.Sprintf("rolled back because id %d of operation %d failed", items[failed].id, res.Results[failed].Operation)
+			}
+		}
+	}
+
+	for _, result := range res.Results {
+		if result.Status == contract.BulkStatusSucceeded {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+
+	return
+}
+
+// bulkItem write one id of the operation, it must run in a transaction
+func (ms *MovieService) bulkItem(ctx context.Context, item bulkItem) error {
+	switch item.operation.Action {
+	case contract.BulkActionUpdate:
+		movie := entity.Movie{ModelID: entity.ModelID{Id: item.id}}
+		return ms.updateLocked(ctx, &movie, contract.ETagMatch{Any: true}, func(data *entity.MovieData) []int64 {
+			mapperMoviePatchRequest(data, &item.operation.Patch)
+			return item.operation.Patch.GenreIDs
+		})
+	case contract.BulkActionDelete:
+		return ms.deleteLocked(ctx, item.id, contract.ETagMatch{Any: true})
+	case contract.BulkActionRestore:
+		return ms.restoreLocked(ctx, item.id)
+	default:
+		return fmt.Errorf("unknown bulk action %s", item.operation.Action)
+	}
+}
+
+func setBulkResult(result *contract.BulkItemResponse, err error) {
+	if err != nil {
+		result.Status = contract.BulkStatusFailed
+		result.Reason = err.Error()
+		return
+	}
+
+	result.Status = contract.BulkStatusSucceeded
+}
@@ -0,0 +1,139 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestBulkMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	rating := float32(7.5)
+	update := contract.BulkOperationRequest{Action: contract.BulkActionUpdate, IDs: []int64{1, 2}, Patch: contract.MoviePatchRequest{Rating: &rating}}
+	remove := contract.BulkOperationRequest{Action: contract.BulkActionDelete, IDs: []int64{3}}
+	restore := contract.BulkOperationRequest{Action: contract.BulkActionRestore, IDs: []int64{4}}
+
+	var invalidated int
+	expectDeferCache := func() {
+		mockMovieRepo.EXPECT().DeferCacheInvalidation(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, func()) {
+			return ctx, func() { invalidated++ }
+		}).Times(1)
+	}
+
+	expectUpdate := func(id int64) {
+		mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), id).Return(entity.MovieSnapshot{Title: "avengers", Rating: 9, Version: 2}, nil).Times(1)
+		mockMovieRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *entity.Movie) error {
+			assert.Equal(t, id, m.Id)
+			assert.Equal(t, entity.MovieData{Title: "avengers", Rating: 7.5}, m.MovieData)
+			m.Version = 3
+			return nil
+		}).Times(1)
+		mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	}
+
+	tests := []struct {
+		name          string
+		request       contract.BulkRequest
+		want          []string
+		wantSucceeded int
+		wantErr       bool
+		mockFunc      func()
+	}{
+		{
+			name:    "error begin transaction",
+			request: contract.BulkRequest{Mode: contract.BulkModeAllOrNothing, Operations: []contract.BulkOperationRequest{remove}},
+			wantErr: true,
+			mockFunc: func() {
+				expectDeferCache()
+				mockAtomic.EXPECT().BeginSession(gomock.Any()).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:          "all or nothing roll back every item when one fail",
+			request:       contract.BulkRequest{Mode: contract.BulkModeAllOrNothing, Operations: []contract.BulkOperationRequest{update, remove}},
+			want:          []string{contract.BulkStatusRolledBack, contract.BulkStatusFailed, contract.BulkStatusRolledBack},
+			wantSucceeded: 0,
+			mockFunc: func() {
+				expectDeferCache()
+				expectAtomic(mockAtomic, mockSession, false)
+				expectUpdate(1)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(2)).Return(entity.MovieSnapshot{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:          "all or nothing success",
+			request:       contract.BulkRequest{Mode: contract.BulkModeAllOrNothing, Operations: []contract.BulkOperationRequest{update, remove, restore}},
+			want:          []string{contract.BulkStatusSucceeded, contract.BulkStatusSucceeded, contract.BulkStatusSucceeded, contract.BulkStatusSucceeded},
+			wantSucceeded: 4,
+			mockFunc: func() {
+				expectDeferCache()
+				expectAtomic(mockAtomic, mockSession, true)
+				expectUpdate(1)
+				expectUpdate(2)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(3)).Return(entity.MovieSnapshot{Version: 1}, nil).Times(1)
+				mockMovieRepo.EXPECT().Delete(gomock.Any(), int64(3)).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(4)).Return(entity.MovieSnapshot{Deleted: true, Version: 1}, nil).Times(1)
+				mockMovieRepo.EXPECT().Restore(gomock.Any(), int64(4)).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+		},
+		{
+			name:          "best effort keep succeeded item",
+			request:       contract.BulkRequest{Mode: contract.BulkModeBestEffort, Operations: []contract.BulkOperationRequest{restore, remove}},
+			want:          []string{contract.BulkStatusFailed, contract.BulkStatusSucceeded},
+			wantSucceeded: 1,
+			mockFunc: func() {
+				expectDeferCache()
+				expectAtomic(mockAtomic, mockSession, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(4)).Return(entity.MovieSnapshot{Version: 1}, nil).Times(1)
+				mockMovieRepo.EXPECT().Restore(gomock.Any(), int64(4)).Return(sql.ErrNoRows).Times(1)
+				expectAtomic(mockAtomic, mockSession, true)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(3)).Return(entity.MovieSnapshot{Version: 1}, nil).Times(1)
+				mockMovieRepo.EXPECT().Delete(gomock.Any(), int64(3)).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalidated = 0
+			tt.mockFunc()
+
+			got, err := InitMovieService(mockMovieRepo, nil, mockAtomic, true).Bulk(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Movie.Bulk() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, 1, invalidated)
+			if tt.wantErr {
+				return
+			}
+
+			statuses := make([]string, len(got.Results))
+			for i, result := range got.Results {
+				statuses[i] = result.Status
+			}
+
+			assert.Equal(t, tt.want, statuses)
+			assert.Equal(t, tt.wantSucceeded, got.Succeeded)
+			assert.Equal(t, len(tt.want)-tt.wantSucceeded, got.Failed)
+		})
+	}
+}
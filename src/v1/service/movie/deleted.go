@@ -0,0 +1,141 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+func (ms *MovieService) GetDeletedList(ctx context.Context, params contract.GetListParam) (res contract.GetListResponse, err error) {
+
+	movie, err := ms.MovieRepo.GetDeletedList(ctx, params)
+	if err != nil {
+		log.Println("get deleted movie err: ", err)
+		return
+	}
+
+	count, err := ms.MovieRepo.GetDeletedCount(ctx)
+	if err != nil {
+		log.Println("get deleted movie count err: ", err)
+		return
+	}
+
+	res = contract.GetListResponse{
+		Data:       ms.mapperMovieListResponse(movie),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	for i, m := range movie {
+		if m.DeletedAt != nil {
+			res.Data[i].DeletedAt = m.DeletedAt.Format("2006-01-02 15:04:05")
+		}
+	}
+
+	return
+}
+
+// Restore undo soft delete, movie that is not deleted is reported as not found
+func (ms *MovieService) Restore(ctx context.Context, id int) (res contract.MovieResponse, err error) {
+
+	err = ms.atomic(ctx, func(ctx context.Context) error {
+		return ms.restoreLocked(ctx, int64(id))
+	})
+	if err != nil {
+		return
+	}
+
+	return ms.Get(ctx, id, contract.GetMovieParam{})
+}
+
+// restoreLocked lock and restore the movie with its revision, it must run in a transaction
+func (ms *MovieService) restoreLocked(ctx context.Context, id int64) error {
+	before, err := ms.lockSnapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = ms.MovieRepo.Restore(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("restore movie err: ", err)
+		return err
+	}
+
+	after := before
+	after.Deleted = false
+	after.Version++
+
+	return ms.recordRevision(ctx, entity.RevisionActionRestore, id, &before, after, nil)
+}
+
+// Purge permanently delete the movie whether it is soft deleted or not,
+// movie with booking can not be purged
+func (ms *MovieService) Purge(ctx context.Context, id int, match contract.ETagMatch) (err error) {
+
+	if ms.RequireIfMatch || !match.IsEmpty() {
+		snapshot, err := ms.MovieRepo.GetSnapshot(ctx, int64(id))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = appErr.ErrMovieIdNotFound
+			}
+			log.Println("get movie snapshot err: ", err)
+			return err
+		}
+
+		if err = ms.checkPrecondition(match, snapshot.Version); err != nil {
+			return err
+		}
+	}
+
+	booked, err := ms.MovieRepo.HasBookings(ctx, int64(id))
+	if err != nil {
+		log.Println("get movie bookings err: ", err)
+		return
+	}
+
+	if booked {
+		err = appErr.ErrMovieHasBookings
+		log.Println("purge movie err: ", err)
+		return
+	}
+
+	keys, err := ms.MovieRepo.Purge(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("purge movie err: ", err)
+		return
+	}
+
+	ms.deleteImages(ctx, keys)
+
+	return
+}
+
+// PurgeDeleted permanently delete movie soft deleted before the time
+// and return the number of purged movie
+func (ms *MovieService) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+
+	keys, err := ms.MovieRepo.PurgeDeletedBefore(ctx, before)
+	if err != nil {
+		log.Println("purge deleted movie err: ", err)
+		return 0, err
+	}
+
+	for _, k := range keys {
+		ms.deleteImages(ctx, k)
+	}
+
+	return len(keys), nil
+}
@@ -0,0 +1,208 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestGetDeletedListMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	params := contract.GetListParam{Page: 1, Limit: 10}
+
+	mockMovieRepo.EXPECT().GetDeletedList(gomock.Any(), params).Return([]*entity.Movie{
+		{ModelID: entity.ModelID{Id: 1}, ModelLogTime: entity.ModelLogTime{DeletedAt: &deletedAt}},
+	}, nil).Times(1)
+	mockMovieRepo.EXPECT().GetDeletedCount(gomock.Any()).Return(int64(1), nil).Times(1)
+
+	got, err := InitMovieService(mockMovieRepo, nil, nil, false).GetDeletedList(context.Background(), params)
+	assert.NoError(t, err)
+	assert.Len(t, got.Data, 1)
+	assert.Equal(t, "2024-01-02 03:04:05", got.Data[0].DeletedAt)
+}
+
+func TestRestoreMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error movie id not found",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func() {
+				expectAtomic(mockAtomic, mockSession, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error movie is not deleted",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func() {
+				expectAtomic(mockAtomic, mockSession, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{}, nil).Times(1)
+				mockMovieRepo.EXPECT().Restore(gomock.Any(), int64(1)).Return(sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				expectAtomic(mockAtomic, mockSession, true)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{Deleted: true}, nil).Times(1)
+				mockMovieRepo.EXPECT().Restore(gomock.Any(), int64(1)).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data *entity.MovieRevision) error {
+						assert.Equal(t, entity.RevisionActionRestore, data.Action)
+						assert.False(t, data.After.Deleted)
+						return nil
+					}).Times(1)
+				mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			_, err := InitMovieService(mockMovieRepo, nil, mockAtomic, false).Restore(context.Background(), 1)
+			if err != tt.wantErr {
+				t.Errorf("Movie.Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPurgeMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockStorage := mock_movie.NewMockImageStorage(ctrl)
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error movie has bookings",
+			wantErr: appErr.ErrMovieHasBookings,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().HasBookings(gomock.Any(), int64(1)).Return(true, nil).Times(1)
+			},
+		},
+		{
+			name:    "error movie id not found",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().HasBookings(gomock.Any(), int64(1)).Return(false, nil).Times(1)
+				mockMovieRepo.EXPECT().Purge(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "success remove poster",
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().HasBookings(gomock.Any(), int64(1)).Return(false, nil).Times(1)
+				mockMovieRepo.EXPECT().Purge(gomock.Any(), int64(1)).Return(entity.ImageKeys{
+					entity.ImageSizeOriginal: "movies/1/a/original.png",
+				}, nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "movies/1/a/original.png").Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			err := InitMovieService(mockMovieRepo, mockStorage, nil, false).Purge(context.Background(), 1, contract.ETagMatch{})
+			if err != tt.wantErr {
+				t.Errorf("Movie.Purge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoviePreconditionService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	p := InitMovieService(mockMovieRepo, nil, mockAtomic, true)
+
+	t.Run("delete without if match", func(t *testing.T) {
+		mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+		expectAtomic(mockAtomic, mockSession, false)
+		mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{Version: 2}, nil).Times(1)
+
+		err := p.Delete(context.Background(), 1, contract.ETagMatch{})
+		assert.Equal(t, appErr.ErrPreconditionRequired, err)
+	})
+
+	t.Run("delete any version", func(t *testing.T) {
+		mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+		expectAtomic(mockAtomic, mockSession, true)
+		mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{Version: 2}, nil).Times(1)
+		mockMovieRepo.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil).Times(1)
+		mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		err := p.Delete(context.Background(), 1, contract.ETagMatch{Any: true})
+		assert.NoError(t, err)
+	})
+
+	t.Run("purge stale version", func(t *testing.T) {
+		mockMovieRepo.EXPECT().GetSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{Version: 2}, nil).Times(1)
+
+		err := p.Purge(context.Background(), 1, contract.ETagMatch{Versions: []int{1}})
+		assert.Equal(t, appErr.ErrPreconditionFailed, err)
+	})
+}
+
+func TestPurgeDeletedMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockStorage := mock_movie.NewMockImageStorage(ctrl)
+
+	before := time.Now().AddDate(0, 0, -30)
+
+	mockMovieRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), before).Return([]entity.ImageKeys{
+		{},
+		{entity.ImageSizeSmall: "movies/2/a/small.jpg"},
+	}, nil).Times(1)
+	mockStorage.EXPECT().Delete(gomock.Any(), "movies/2/a/small.jpg").Return(nil).Times(1)
+
+	count, err := InitMovieService(mockMovieRepo, mockStorage, nil, false).PurgeDeleted(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
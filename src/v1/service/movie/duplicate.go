@@ -0,0 +1,44 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+)
+
+func mapperDuplicateResponse(d *entity.MovieDuplicate) *contract.DuplicateResponse {
+	return &contract.DuplicateResponse{
+		Movie:      contract.DuplicateMovieResponse{ID: int(d.MovieID), Title: d.MovieTitle},
+		Duplicate:  contract.DuplicateMovieResponse{ID: int(d.DuplicateID), Title: d.DuplicateTitle},
+		Similarity: d.Similarity,
+	}
+}
+
+// GetDuplicates report pair of active movie with similar title for cleanup,
+// exact duplicate is already rejected by the normalized title index
+func (ms *MovieService) GetDuplicates(ctx context.Context, params contract.GetDuplicateParam) (res contract.GetListDuplicateResponse, err error) {
+
+	duplicates, err := ms.MovieRepo.GetDuplicates(ctx, params)
+	if err != nil {
+		log.Println("get movie duplicates err: ", err)
+		return
+	}
+
+	count, err := ms.MovieRepo.GetDuplicateCount(ctx, params.Threshold)
+	if err != nil {
+		log.Println("get movie duplicate count err: ", err)
+		return
+	}
+
+	res = contract.GetListDuplicateResponse{
+		Data:       stream.Map(stream.OfSlice(duplicates), mapperDuplicateResponse).ToSlice(),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	return
+}
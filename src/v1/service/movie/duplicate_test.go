@@ -0,0 +1,81 @@
+package movie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestGetDuplicatesMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+
+	params := contract.GetDuplicateParam{Page: 1, Limit: 10, Threshold: 0.5}
+
+	tests := []struct {
+		name     string
+		want     contract.GetListDuplicateResponse
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error get duplicates",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetDuplicates(gomock.Any(), params).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "error get duplicate count",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetDuplicates(gomock.Any(), params).Return([]*entity.MovieDuplicate{}, nil).Times(1)
+				mockMovieRepo.EXPECT().GetDuplicateCount(gomock.Any(), 0.5).Return(int64(0), assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.GetListDuplicateResponse{
+				Data: []*contract.DuplicateResponse{
+					{
+						Movie:      contract.DuplicateMovieResponse{ID: 1, Title: "pengabdi setan"},
+						Duplicate:  contract.DuplicateMovieResponse{ID: 6, Title: "pengabdi setan!"},
+						Similarity: 0.93,
+					},
+				},
+			},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetDuplicates(gomock.Any(), params).Return([]*entity.MovieDuplicate{
+					{MovieID: 1, MovieTitle: "pengabdi setan", DuplicateID: 6, DuplicateTitle: "pengabdi setan!", Similarity: 0.93},
+				}, nil).Times(1)
+				mockMovieRepo.EXPECT().GetDuplicateCount(gomock.Any(), 0.5).Return(int64(1), nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitMovieService(mockMovieRepo, nil, nil, false).GetDuplicates(context.Background(), params)
+			if err != tt.wantErr {
+				t.Errorf("Movie.GetDuplicates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, tt.want.Data, got.Data)
+				assert.Equal(t, int64(1), got.Pagination.TotalData)
+			}
+		})
+	}
+}
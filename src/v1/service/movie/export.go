@@ -0,0 +1,23 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+// Export call fn for every movie matching params in list order, the movie is mapped
+// like an item of the list endpoint and is passed as soon as it is read from the database
+func (ms *MovieService) Export(ctx context.Context, params contract.GetListParam, fn func(m *contract.MovieResponse) error) (err error) {
+	err = ms.MovieRepo.Export(ctx, params, func(m *entity.Movie) error {
+		return fn(ms.mapperMovieItemResponse(m))
+	})
+	if err != nil {
+		log.Println("export movie err: ", err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,86 @@
+package movie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestExportMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+
+	params := contract.GetListParam{Keyword: "avengers"}
+	movies := []*entity.Movie{
+		{ModelID: entity.ModelID{Id: 1}, MovieData: entity.MovieData{Title: "avengers", Rating: 9}, Genres: []string{"action"}},
+		{ModelID: entity.ModelID{Id: 2}, MovieData: entity.MovieData{Title: "avengers: endgame", Rating: 9.5}},
+	}
+
+	exportMovies := func(_ context.Context, _ contract.GetListParam, fn func(m *entity.Movie) error) error {
+		for _, m := range movies {
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		fnErr    error
+		wantIDs  []int
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error query",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Export(gomock.Any(), params, gomock.Any()).Return(assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "error write stop the export",
+			fnErr:   assert.AnError,
+			wantIDs: []int{1},
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Export(gomock.Any(), params, gomock.Any()).DoAndReturn(exportMovies).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			wantIDs: []int{1, 2},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Export(gomock.Any(), params, gomock.Any()).DoAndReturn(exportMovies).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			var gotIDs []int
+			err := InitMovieService(mockMovieRepo, nil, nil, false).Export(context.Background(), params, func(m *contract.MovieResponse) error {
+				gotIDs = append(gotIDs, m.ID)
+				return tt.fnErr
+			})
+			if err != tt.wantErr {
+				t.Errorf("Movie.Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
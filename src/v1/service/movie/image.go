@@ -0,0 +1,196 @@
+package movie
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"log"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/google/uuid"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+const (
+	// maxImagePixels guard against small file decoding into a huge image
+	maxImagePixels = 40_000_000
+
+	thumbnailQuality = 85
+)
+
+// thumbnailSizes is width of each generated thumbnail, height keep the aspect ratio
+var thumbnailSizes = []struct {
+	Name  string
+	Width int
+}{
+	{Name: entity.ImageSizeSmall, Width: 185},
+	{Name: entity.ImageSizeMedium, Width: 342},
+	{Name: entity.ImageSizeLarge, Width: 780},
+}
+
+// imageURLs return url of every stored size, nil when no poster is uploaded
+func (ms *MovieService) imageURLs(keys entity.ImageKeys) map[string]string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	urls := make(map[string]string, len(keys))
+	for size, key := range keys {
+		urls[size] = ms.Storage.URL(key)
+	}
+	return urls
+}
+
+// mapperMovieImage prefer uploaded poster over free text image url
+func (ms *MovieService) mapperMovieImage(res *contract.MovieResponse, keys entity.ImageKeys) {
+	res.Images = ms.imageURLs(keys)
+	if original, ok := res.Images[entity.ImageSizeOriginal]; ok {
+		res.Image = original
+	}
+}
+
+// UploadImage store the poster and its thumbnails under a new prefix then
+// point the movie to them, previous poster is removed after the movie is updated
+func (ms *MovieService) UploadImage(ctx context.Context, request contract.ImageRequest, id int) (res contract.MovieResponse, err error) {
+
+	movie, err := ms.MovieRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("get movie err: ", err)
+		return
+	}
+
+	images, err := buildThumbnails(request.Data)
+	if err != nil {
+		return
+	}
+
+	prefix := fmt.Sprintf("movies/%d/%s", movie.Id, uuid.NewString())
+	keys := entity.ImageKeys{
+		entity.ImageSizeOriginal: fmt.Sprintf("%s/%s.%s", prefix, entity.ImageSizeOriginal, contract.ImageContentTypes[request.ContentType]),
+	}
+
+	err = ms.Storage.Put(ctx, keys[entity.ImageSizeOriginal], request.Data, request.ContentType)
+	if err == nil {
+		for _, size := range thumbnailSizes {
+			keys[size.Name] = fmt.Sprintf("%s/%s.jpg", prefix, size.Name)
+			if err = ms.Storage.Put(ctx, keys[size.Name], images[size.Name], "image/jpeg"); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = ms.MovieRepo.UpdateImageKeys(ctx, movie.Id, keys)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+	}
+	if err != nil {
+		log.Println("upload image err: ", err)
+		ms.deleteImages(ctx, keys)
+		return
+	}
+
+	ms.deleteImages(ctx, movie.ImageKeys)
+
+	return ms.Get(ctx, id, contract.GetMovieParam{})
+}
+
+// deleteImages is best effort, leftover object only waste space
+func (ms *MovieService) deleteImages(ctx context.Context, keys entity.ImageKeys) {
+	for _, key := range keys {
+		if err := ms.Storage.Delete(ctx, key); err != nil {
+			log.Println("delete image err: ", err, key)
+		}
+	}
+}
+
+// buildThumbnails decode the poster and encode every thumbnail size as jpeg,
+// image narrower than a size is encoded at its own width
+func buildThumbnails(data []byte) (map[string][]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		log.Println("decode image config err: ", err)
+		return nil, appErr.ErrInvalidImage
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		log.Println("decode image err: ", err)
+		return nil, appErr.ErrInvalidImage
+	}
+
+	// jpeg has no alpha so transparent area is flattened on white
+	bounds := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	images := make(map[string][]byte, len(thumbnailSizes))
+	for _, size := range thumbnailSizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resizeImage(flat, size.Width), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			log.Println("encode thumbnail err: ", err)
+			return nil, err
+		}
+		images[size.Name] = buf.Bytes()
+	}
+
+	return images, nil
+}
+
+// resizeImage shrink src to width with box filter, each destination pixel
+// is the average of source pixels it cover
+func resizeImage(src *image.RGBA, width int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= width {
+		return src
+	}
+
+	height := sh * width / sw
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, (y+1)*sh/height
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, (x+1)*sw/width
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					i += 4
+					n++
+				}
+			}
+
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
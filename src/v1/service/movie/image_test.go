@@ -0,0 +1,142 @@
+package movie
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func mockPNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.Set(0, 0, color.Transparent)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestBuildThumbnails(t *testing.T) {
+	images, err := buildThumbnails(mockPNG(t, 500, 750))
+	assert.NoError(t, err)
+
+	for _, tt := range []struct {
+		size          string
+		width, height int
+	}{
+		{size: entity.ImageSizeSmall, width: 185, height: 277},
+		{size: entity.ImageSizeMedium, width: 342, height: 513},
+		{size: entity.ImageSizeLarge, width: 500, height: 750},
+	} {
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(images[tt.size]))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.width, cfg.Width, tt.size)
+		assert.Equal(t, tt.height, cfg.Height, tt.size)
+	}
+
+	_, err = buildThumbnails([]byte("not an image"))
+	assert.Equal(t, appErr.ErrInvalidImage, err)
+}
+
+func TestUploadImageMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockStorage := mock_movie.NewMockImageStorage(ctrl)
+
+	request := contract.ImageRequest{Data: mockPNG(t, 400, 600), ContentType: "image/png"}
+	oldKeys := entity.ImageKeys{entity.ImageSizeOriginal: "movies/1/old/original.jpg"}
+	movie := entity.Movie{ModelID: entity.ModelID{Id: 1}, ImageKeys: oldKeys}
+
+	tests := []struct {
+		name     string
+		request  contract.ImageRequest
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error movie id not found",
+			request: request,
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error invalid image",
+			request: contract.ImageRequest{Data: []byte("GIF89a broken"), ContentType: "image/gif"},
+			wantErr: appErr.ErrInvalidImage,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+			},
+		},
+		{
+			name:    "error storage remove uploaded object",
+			request: request,
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+				mockStorage.EXPECT().Put(gomock.Any(), gomock.Any(), request.Data, "image/png").Return(nil).Times(1)
+				mockStorage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), "image/jpeg").Return(assert.AnError).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+		},
+		{
+			name:    "success remove previous poster",
+			request: request,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+				mockStorage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(4)
+				mockMovieRepo.EXPECT().UpdateImageKeys(gomock.Any(), int64(1), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id int64, keys entity.ImageKeys) error {
+						assert.Len(t, keys, 4)
+						assert.Regexp(t, `^movies/1/[0-9a-f-]{36}/original\.png$`, keys[entity.ImageSizeOriginal])
+						return nil
+					}).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "movies/1/old/original.jpg").Return(nil).Times(1)
+				mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{
+					ModelID:   entity.ModelID{Id: 1},
+					ImageKeys: entity.ImageKeys{entity.ImageSizeOriginal: "movies/1/new/original.png"},
+				}, nil).Times(1)
+				mockStorage.EXPECT().URL("movies/1/new/original.png").Return("/uploads/movies/1/new/original.png").Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			p := InitMovieService(mockMovieRepo, mockStorage, nil, false)
+			got, err := p.UploadImage(context.Background(), tt.request, 1)
+			if err != tt.wantErr {
+				t.Errorf("Movie.UploadImage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, "/uploads/movies/1/new/original.png", got.Image)
+				assert.Equal(t, map[string]string{entity.ImageSizeOriginal: "/uploads/movies/1/new/original.png"}, got.Images)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/google/uuid"
+	"github.com/mariomac/gostream/stream"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+const (
+	// ImportBatchSize is number of row written in one transaction
+	ImportBatchSize = 100
+
+	// ImportAsyncRows is number of row from which the import run in background,
+	// smaller import finish well within the request timeout
+	ImportAsyncRows = 1000
+
+	// MaxRunningImports is number of background import of an instance, more import is rejected
+	MaxRunningImports = 2
+
+	// ImportStaleAfter is how long a running import can go without saving its progress,
+	// every batch is saved well within it so the instance running the job is gone after it
+	ImportStaleAfter = 10 * time.Minute
+
+	importPanicReason = "import stopped unexpectedly"
+	importStaleReason = "import stopped responding"
+)
+
+// errDryRun roll back the batch of a dry run import
+var errDryRun = errors.New("dry run")
+
+func mapperImportJobResponse(job *entity.ImportJob) contract.ImportJobResponse {
+	res := contract.ImportJobResponse{
+		ID:        job.ID,
+		Status:    job.Status,
+		DryRun:    job.DryRun,
+		Total:     job.Total,
+		Processed: job.Processed,
+		Rows:      make([]*contract.ImportRowResponse, 0, len(job.Rows)),
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if job.FinishedAt != nil {
+		res.FinishedAt = job.FinishedAt.Format("2006-01-02 15:04:05")
+	}
+
+	for _, row := range job.Rows {
+		if row.Status == "" {
+			// not processed yet
+			continue
+		}
+
+		switch row.Status {
+		case entity.ImportRowCreated:
+			res.Summary.Created++
+		case entity.ImportRowUpdated:
+			res.Summary.Updated++
+		case entity.ImportRowSkipped:
+			res.Summary.Skipped++
+		case entity.ImportRowError:
+			res.Summary.Error++
+		}
+
+		res.Rows = append(res.Rows, &contract.ImportRowResponse{
+			Line:    row.Line,
+			Title:   row.Title,
+			Status:  row.Status,
+			MovieID: row.MovieID,
+			Reason:  row.Reason,
+		})
+	}
+
+	return res
+}
+
+// Import upsert every valid row by its normalized title in batch of
+// ImportBatchSize, each batch is one transaction. Large import or import
+// asked to be async run in background and its progress is read by GetImportJob,
+// at most MaxRunningImports run in background at once.
+// Dry run process the rows the same way but roll back every batch
+func (ms *MovieService) Import(ctx context.Context, request contract.ImportRequest) (res contract.ImportJobResponse, err error) {
+
+	async := request.Async || len(request.Rows) >= ImportAsyncRows
+	if async && !ms.acquireImport() {
+		err = appErr.ErrImportBusy
+		log.Println("import movie err: ", err)
+		return
+	}
+
+	job := &entity.ImportJob{
+		ID:        uuid.NewString(),
+		Status:    entity.ImportStatusPending,
+		DryRun:    request.DryRun,
+		Total:     len(request.Rows),
+		Rows:      make([]entity.ImportRowResult, len(request.Rows)),
+		CreatedAt: time.Now(),
+	}
+
+	if err = ms.MovieRepo.SaveImportJob(ctx, job); err != nil {
+		log.Println("save import job err: ", err)
+		if async {
+			ms.releaseImport()
+		}
+		return
+	}
+
+	if async {
+		res = mapperImportJobResponse(job)
+		// keep request id and user for the revision but not the request deadline
+		go func(ctx context.Context) {
+			defer ms.releaseImport()
+			ms.runImport(ctx, job, request.Rows)
+		}(context.WithoutCancel(ctx))
+		return
+	}
+
+	ms.runImport(ctx, job, request.Rows)
+
+	return mapperImportJobResponse(job), nil
+}
+
+func (ms *MovieService) GetImportJob(ctx context.Context, id string) (res contract.ImportJobResponse, err error) {
+
+	job, err := ms.MovieRepo.GetImportJob(ctx, id)
+	if err != nil {
+		log.Println("get import job err: ", err)
+		return
+	}
+
+	// the instance running the job stopped without finishing it
+	unfinished := job.Status == entity.ImportStatusPending || job.Status == entity.ImportStatusRunning
+	if unfinished && time.Since(job.UpdatedAt) > ImportStaleAfter {
+		log.Println("import job is stale: ", job.ID, job.UpdatedAt)
+		ms.finishImport(ctx, &job, entity.ImportStatusFailed, importStaleReason)
+	}
+
+	return mapperImportJobResponse(&job), nil
+}
+
+func (ms *MovieService) acquireImport() bool {
+	select {
+	case ms.imports <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (ms *MovieService) releaseImport() {
+	<-ms.imports
+}
+
+// runImport save the job after every batch so progress is visible, failing
+// to save it does not stop the import. A panic mark the job failed instead of
+// leaving it running
+func (ms *MovieService) runImport(ctx context.Context, job *entity.ImportJob, rows []contract.ImportRow) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("import movie panic: ", r, "\n", string(debug.Stack()))
+			ms.finishImport(ctx, job, entity.ImportStatusFailed, importPanicReason)
+		}
+	}()
+
+	job.Status = entity.ImportStatusRunning
+	if err := ms.MovieRepo.SaveImportJob(ctx, job); err != nil {
+		log.Println("save import job err: ", err)
+	}
+
+	for start := 0; start < len(rows); start += ImportBatchSize {
+		end := min(start+ImportBatchSize, len(rows))
+
+		copy(job.Rows[start:end], ms.importBatch(ctx, rows[start:end], job.DryRun))
+		job.Processed = end
+
+		if end < len(rows) {
+			if err := ms.MovieRepo.SaveImportJob(ctx, job); err != nil {
+				log.Println("save import job err: ", err)
+			}
+		}
+	}
+
+	ms.finishImport(ctx, job, entity.ImportStatusCompleted, "")
+}
+
+func (ms *MovieService) finishImport(ctx context.Context, job *entity.ImportJob, status, reason string) {
+	finishedAt := time.Now()
+	job.Status = status
+	job.Error = reason
+	job.FinishedAt = &finishedAt
+	if err := ms.MovieRepo.SaveImportJob(ctx, job); err != nil {
+		log.Println("save import job err: ", err)
+	}
+}
+
+// importBatch write the batch in one transaction, row with unknown genre is
+// reported before the transaction so it does not roll back the other rows
+func (ms *MovieService) importBatch(ctx context.Context, rows []contract.ImportRow, dryRun bool) []entity.ImportRowResult {
+	results := make([]entity.ImportRowResult, len(rows))
+	for i, row := range rows {
+		results[i] = entity.ImportRowResult{Line: row.Line, Title: row.Request.Title, Reason: row.Reason}
+		if row.Reason != "" {
+			results[i].Status = entity.ImportRowError
+		}
+	}
+
+	if err := ms.checkImportGenres(ctx, rows, results); err != nil {
+		for i := range results {
+			if results[i].Status == "" {
+				results[i].Status = entity.ImportRowError
+				results[i].Reason = err.Error()
+			}
+		}
+		return results
+	}
+
+	failed := -1
+	err := ms.atomic(ctx, func(ctx context.Context) error {
+		for i, row := range rows {
+			if results[i].Status != "" {
+				continue
+			}
+
+			var err error
+			results[i].Status, results[i].MovieID, err = ms.importRow(ctx, row)
+			if err != nil {
+				failed = i
+				return err
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err == nil || err == errDryRun {
+		return results
+	}
+
+	// the whole batch is rolled back
+	for i := range results {
+		if results[i].Status == entity.ImportRowError && i != failed {
+			continue
+		}
+
+		results[i].Status = entity.ImportRowError
+		results[i].MovieID = 0
+		if i == failed {
+			results[i].Reason = err.Error()
+		} else if failed >= 0 {
+			results[i].Reason = fmt.Sprintf("rolled back because line %d failed", rows[failed].Line)
+		} else {
+			results[i].Reason = err.Error()
+		}
+	}
+
+	return results
+}
+
+// checkImportGenres report row linking genre that does not exist
+func (ms *MovieService) checkImportGenres(ctx context.Context, rows []contract.ImportRow, results []entity.ImportRowResult) error {
+	var genreIDs []int64
+	for i, row := range rows {
+		if results[i].Status == "" {
+			genreIDs = append(genreIDs, row.Request.GenreIDs...)
+		}
+	}
+
+	if len(genreIDs) == 0 {
+		return nil
+	}
+
+	existIDs, err := ms.MovieRepo.GetGenreIDs(ctx, stream.Distinct(stream.OfSlice(genreIDs)).ToSlice())
+	if err != nil {
+		log.Println("get genre ids err: ", err)
+		return err
+	}
+
+	exist := make(map[int64]bool, len(existIDs))
+	for _, id := range existIDs {
+		exist[id] = true
+	}
+
+	for i, row := range rows {
+		if results[i].Status != "" {
+			continue
+		}
+
+		for _, id := range row.Request.GenreIDs {
+			if !exist[id] {
+				results[i].Status = entity.ImportRowError
+				results[i].Reason = fmt.Sprintf("genre %d does not exist", id)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// importRow create the movie when no active movie has the title, otherwise
+// it overwrite the field the row has and is skipped when nothing change.
+// Genre is only replaced when the row has genre_ids
+func (ms *MovieService) importRow(ctx context.Context, row contract.ImportRow) (status string, movieID int64, err error) {
+	request := row.Request
+	data := entity.MovieData{
+		Title:       request.Title,
+		Description: request.Description,
+		Rating:      *request.Rating,
+		Image:       request.Image,
+		Runtime:     request.Runtime,
+	}
+
+	movieID, err = ms.MovieRepo.LockMovieIDByTitle(ctx, request.Title)
+	if errors.Is(err, sql.ErrNoRows) {
+		movie, err := ms.MovieRepo.Create(ctx, &entity.Movie{MovieData: data})
+		if err != nil {
+			log.Println("import create movie err: ", err)
+			return status, 0, err
+		}
+
+		if request.GenreIDs != nil {
+			if _, err = ms.replaceMovieGenres(ctx, int64(movie.ID), request.GenreIDs); err != nil {
+				return status, 0, err
+			}
+		}
+
+		err = ms.recordRevision(ctx, entity.RevisionActionCreate, int64(movie.ID), nil,
+			movieSnapshot(data, request.GenreIDs, false, movie.Version), nil)
+		return entity.ImportRowCreated, int64(movie.ID), err
+	}
+	if err != nil {
+		log.Println("import find movie err: ", err)
+		return status, 0, err
+	}
+
+	before, err := ms.lockSnapshot(ctx, movieID)
+	if err != nil {
+		return status, 0, err
+	}
+
+	// file without the column keep the value of the movie, e.g. poster uploaded after the last import
+	if !row.Fields["description"] {
+		data.Description = before.Description
+	}
+	if !row.Fields["image"] {
+		data.Image = before.Image
+	}
+	if !row.Fields["runtime"] {
+		data.Runtime = before.Runtime
+	}
+
+	genreIDs := before.GenreIDs
+	if request.GenreIDs != nil {
+		genreIDs = request.GenreIDs
+	}
+
+	diff, err := entity.DiffSnapshots(&before, movieSnapshot(data, genreIDs, false, before.Version))
+	if err != nil {
+		log.Println("diff movie snapshot err: ", err)
+		return status, 0, err
+	}
+
+	if len(diff) == 0 {
+		return entity.ImportRowSkipped, movieID, nil
+	}
+
+	movie := entity.Movie{ModelID: entity.ModelID{Id: movieID}, MovieData: data}
+	if err = ms.MovieRepo.Update(ctx, &movie); err != nil {
+		log.Println("import update movie err: ", err)
+		return status, 0, err
+	}
+
+	if request.GenreIDs != nil {
+		if _, err = ms.replaceMovieGenres(ctx, movieID, request.GenreIDs); err != nil {
+			return status, 0, err
+		}
+	}
+
+	err = ms.recordRevision(ctx, entity.RevisionActionUpdate, movieID, &before,
+		movieSnapshot(data, genreIDs, false, movie.Version), nil)
+	return entity.ImportRowUpdated, movieID, err
+}
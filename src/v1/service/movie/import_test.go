@@ -0,0 +1,241 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestImportMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	rows := []contract.ImportRow{
+		{Line: 2, Reason: "title failed on required"},
+		{Line: 3, Request: contract.MovieRequest{Title: "new movie", Rating: float32Ptr(7), GenreIDs: []int64{1}}},
+		{Line: 4, Request: contract.MovieRequest{Title: "unknown genre", Rating: float32Ptr(7), GenreIDs: []int64{9}}},
+		{Line: 5, Request: contract.MovieRequest{Title: "avengers", Rating: float32Ptr(9.5)}, Fields: map[string]bool{"title": true, "rating": true}},
+		{Line: 6, Request: contract.MovieRequest{Title: "the greatest showman", Rating: float32Ptr(10)}},
+	}
+
+	// avengers is updated without the column missing from the file and the greatest showman is already the same
+	expectRows := func() {
+		mockMovieRepo.EXPECT().GetGenreIDs(gomock.Any(), gomock.InAnyOrder([]int64{1, 9})).Return([]int64{1}, nil).Times(1)
+		mockMovieRepo.EXPECT().LockMovieIDByTitle(gomock.Any(), "new movie").Return(int64(0), sql.ErrNoRows).Times(1)
+		mockMovieRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(contract.MovieResponseDB{ID: 10, Version: 1}, nil).Times(1)
+		mockMovieRepo.EXPECT().ReplaceMovieGenres(gomock.Any(), int64(10), []int64{1}).Return([]string{"drama"}, nil).Times(1)
+		mockMovieRepo.EXPECT().LockMovieIDByTitle(gomock.Any(), "avengers").Return(int64(3), nil).Times(1)
+		mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(3)).
+			Return(entity.MovieSnapshot{Title: "Avengers", Description: "old description", Rating: 9.5, Image: "poster.jpg", Runtime: 143, GenreIDs: []int64{2}, Version: 4}, nil).Times(1)
+		mockMovieRepo.EXPECT().Update(gomock.Any(), &entity.Movie{
+			ModelID:   entity.ModelID{Id: 3},
+			MovieData: entity.MovieData{Title: "avengers", Description: "old description", Rating: 9.5, Image: "poster.jpg", Runtime: 143},
+		}).Return(nil).Times(1)
+		mockMovieRepo.EXPECT().LockMovieIDByTitle(gomock.Any(), "the greatest showman").Return(int64(4), nil).Times(1)
+		mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(4)).
+			Return(entity.MovieSnapshot{Title: "the greatest showman", Rating: 10, GenreIDs: []int64{}, Version: 2}, nil).Times(1)
+		mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	}
+
+	tests := []struct {
+		name     string
+		request  contract.ImportRequest
+		want     []contract.ImportRowResponse
+		summary  contract.ImportSummaryResponse
+		mockFunc func()
+	}{
+		{
+			name:    "success upsert by title",
+			request: contract.ImportRequest{Rows: rows},
+			want: []contract.ImportRowResponse{
+				{Line: 2, Status: entity.ImportRowError, Reason: "title failed on required"},
+				{Line: 3, Title: "new movie", Status: entity.ImportRowCreated, MovieID: 10},
+				{Line: 4, Title: "unknown genre", Status: entity.ImportRowError, Reason: "genre 9 does not exist"},
+				{Line: 5, Title: "avengers", Status: entity.ImportRowUpdated, MovieID: 3},
+				{Line: 6, Title: "the greatest showman", Status: entity.ImportRowSkipped, MovieID: 4},
+			},
+			summary: contract.ImportSummaryResponse{Created: 1, Updated: 1, Skipped: 1, Error: 2},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().SaveImportJob(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+				expectAtomic(mockAtomic, mockSession, true)
+				expectRows()
+			},
+		},
+		{
+			name:    "success dry run is rolled back",
+			request: contract.ImportRequest{DryRun: true, Rows: rows},
+			want: []contract.ImportRowResponse{
+				{Line: 2, Status: entity.ImportRowError, Reason: "title failed on required"},
+				{Line: 3, Title: "new movie", Status: entity.ImportRowCreated, MovieID: 10},
+				{Line: 4, Title: "unknown genre", Status: entity.ImportRowError, Reason: "genre 9 does not exist"},
+				{Line: 5, Title: "avengers", Status: entity.ImportRowUpdated, MovieID: 3},
+				{Line: 6, Title: "the greatest showman", Status: entity.ImportRowSkipped, MovieID: 4},
+			},
+			summary: contract.ImportSummaryResponse{Created: 1, Updated: 1, Skipped: 1, Error: 2},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().SaveImportJob(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+				expectAtomic(mockAtomic, mockSession, false)
+				expectRows()
+			},
+		},
+		{
+			name: "error batch is rolled back",
+			request: contract.ImportRequest{Rows: []contract.ImportRow{
+				{Line: 2, Request: contract.MovieRequest{Title: "first", Rating: float32Ptr(7)}},
+				{Line: 3, Request: contract.MovieRequest{Title: "second", Rating: float32Ptr(7)}},
+				{Line: 4, Request: contract.MovieRequest{Title: "third", Rating: float32Ptr(7)}},
+			}},
+			want: []contract.ImportRowResponse{
+				{Line: 2, Title: "first", Status: entity.ImportRowError, Reason: "rolled back because line 3 failed"},
+				{Line: 3, Title: "second", Status: entity.ImportRowError, Reason: appErr.ErrDuplicatemovie.Error()},
+				{Line: 4, Title: "third", Status: entity.ImportRowError, Reason: "rolled back because line 3 failed"},
+			},
+			summary: contract.ImportSummaryResponse{Error: 3},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().SaveImportJob(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+				expectAtomic(mockAtomic, mockSession, false)
+				mockMovieRepo.EXPECT().LockMovieIDByTitle(gomock.Any(), gomock.Any()).Return(int64(0), sql.ErrNoRows).Times(2)
+				mockMovieRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(contract.MovieResponseDB{ID: 11, Version: 1}, nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(contract.MovieResponseDB{}, appErr.ErrDuplicatemovie).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitMovieService(mockMovieRepo, nil, mockAtomic, false).Import(context.Background(), tt.request)
+			assert.NoError(t, err)
+
+			assert.Equal(t, entity.ImportStatusCompleted, got.Status)
+			assert.True(t, got.Finished())
+			assert.Equal(t, tt.request.DryRun, got.DryRun)
+			assert.Equal(t, len(tt.want), got.Processed)
+			assert.Equal(t, tt.summary, got.Summary)
+			for i, row := range got.Rows {
+				assert.Equal(t, tt.want[i], *row)
+			}
+		})
+	}
+}
+
+func TestImportMovieServiceSaveJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+
+	p := InitMovieService(mockMovieRepo, nil, nil, false)
+
+	mockMovieRepo.EXPECT().SaveImportJob(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
+	_, err := p.Import(context.Background(), contract.ImportRequest{Rows: []contract.ImportRow{{Line: 2}}})
+	assert.Equal(t, assert.AnError, err)
+
+	mockMovieRepo.EXPECT().GetImportJob(gomock.Any(), "job").Return(entity.ImportJob{}, appErr.ErrImportJobNotFound).Times(1)
+	_, err = p.GetImportJob(context.Background(), "job")
+	assert.Equal(t, appErr.ErrImportJobNotFound, err)
+
+	mockMovieRepo.EXPECT().GetImportJob(gomock.Any(), "job").Return(entity.ImportJob{
+		ID:        "job",
+		Status:    entity.ImportStatusRunning,
+		Total:     2,
+		Rows:      []entity.ImportRowResult{{Line: 2, Status: entity.ImportRowCreated, MovieID: 1}, {}},
+		UpdatedAt: time.Now(),
+	}, nil).Times(1)
+	got, err := p.GetImportJob(context.Background(), "job")
+	assert.NoError(t, err)
+	assert.False(t, got.Finished())
+	assert.Len(t, got.Rows, 1)
+	assert.Equal(t, contract.ImportSummaryResponse{Created: 1}, got.Summary)
+}
+
+func TestImportMovieServiceBackground(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+
+	rows := []contract.ImportRow{{Line: 2, Request: contract.MovieRequest{Title: "avengers", Rating: float32Ptr(9), GenreIDs: []int64{1}}}}
+
+	t.Run("error too many running import", func(t *testing.T) {
+		p := InitMovieService(mockMovieRepo, nil, nil, false)
+		for i := 0; i < MaxRunningImports; i++ {
+			assert.True(t, p.acquireImport())
+		}
+
+		_, err := p.Import(context.Background(), contract.ImportRequest{Async: true, Rows: rows})
+		assert.Equal(t, appErr.ErrImportBusy, err)
+	})
+
+	t.Run("panic mark the job failed", func(t *testing.T) {
+		p := InitMovieService(mockMovieRepo, nil, nil, false)
+
+		failed := make(chan entity.ImportJob, 1)
+		mockMovieRepo.EXPECT().SaveImportJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *entity.ImportJob) error {
+			if job.Status == entity.ImportStatusFailed {
+				failed <- *job
+			}
+			return nil
+		}).Times(3)
+		mockMovieRepo.EXPECT().GetGenreIDs(gomock.Any(), []int64{1}).DoAndReturn(func(context.Context, []int64) ([]int64, error) {
+			panic("boom")
+		}).Times(1)
+
+		res, err := p.Import(context.Background(), contract.ImportRequest{Async: true, Rows: rows})
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ImportStatusPending, res.Status)
+
+		select {
+		case job := <-failed:
+			assert.Equal(t, importPanicReason, job.Error)
+			assert.NotNil(t, job.FinishedAt)
+		case <-time.After(time.Second):
+			t.Fatal("import job is not failed")
+		}
+
+		// the slot is released once the job is saved
+		assert.Eventually(t, func() bool { return len(p.imports) == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("stale running job is failed", func(t *testing.T) {
+		p := InitMovieService(mockMovieRepo, nil, nil, false)
+
+		mockMovieRepo.EXPECT().GetImportJob(gomock.Any(), "job").Return(entity.ImportJob{
+			ID:        "job",
+			Status:    entity.ImportStatusRunning,
+			Total:     1,
+			Rows:      []entity.ImportRowResult{{}},
+			UpdatedAt: time.Now().Add(-ImportStaleAfter - time.Minute),
+		}, nil).Times(1)
+		mockMovieRepo.EXPECT().SaveImportJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *entity.ImportJob) error {
+			assert.Equal(t, entity.ImportStatusFailed, job.Status)
+			return nil
+		}).Times(1)
+
+		got, err := p.GetImportJob(context.Background(), "job")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ImportStatusFailed, got.Status)
+		assert.Equal(t, importStaleReason, got.Error)
+		assert.True(t, got.Finished())
+	})
+}
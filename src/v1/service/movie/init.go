@@ -2,6 +2,7 @@ package movie
 
 import (
 	"context"
+	"time"
 
 	"github.com/Risuii/movie/src/entity"
 	"github.com/Risuii/movie/src/v1/contract"
@@ -10,8 +11,44 @@ import (
 type MovieRepository interface {
 	Create(ctx context.Context, data *entity.Movie) (contract.MovieResponseDB, error)
 	GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error)
+	GetCursorList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error)
+	Export(ctx context.Context, params contract.GetListParam, fn func(m *entity.Movie) error) error
 	GetMovieCount(ctx context.Context, param contract.GetListParam) (int64, error)
 	Get(ctx context.Context, id int) (entity.Movie, error)
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]*entity.Movie, error)
+	DeferCacheInvalidation(ctx context.Context) (context.Context, func())
 	Update(ctx context.Context, data *entity.Movie) error
 	Delete(ctx context.Context, id int64) error
+	ReplaceMovieGenres(ctx context.Context, movieID int64, genreIDs []int64) ([]string, error)
+	GetMovieCredits(ctx context.Context, movieID int64) ([]*entity.MovieCredit, error)
+	ReplaceMovieCredits(ctx context.Context, movieID int64, credits []*entity.MovieCredit) error
+	UpdateImageKeys(ctx context.Context, movieID int64, keys entity.ImageKeys) error
+	GetDeletedList(ctx context.Context, params contract.GetListParam) ([]*entity.Movie, error)
+	GetDeletedCount(ctx context.Context) (int64, error)
+	Restore(ctx context.Context, id int64) error
+	HasBookings(ctx context.Context, id int64) (bool, error)
+	Purge(ctx context.Context, id int64) (entity.ImageKeys, error)
+	PurgeDeletedBefore(ctx context.Context, before time.Time) ([]entity.ImageKeys, error)
+	GetSnapshot(ctx context.Context, id int64) (entity.MovieSnapshot, error)
+	LockSnapshot(ctx context.Context, id int64) (entity.MovieSnapshot, error)
+	CreateRevision(ctx context.Context, data *entity.MovieRevision) error
+	GetRevisions(ctx context.Context, movieID int64, params contract.GetListParam) ([]*entity.MovieRevision, error)
+	GetRevisionCount(ctx context.Context, movieID int64) (int64, error)
+	GetRevision(ctx context.Context, movieID int64, revision int) (entity.MovieRevision, error)
+	GetDuplicates(ctx context.Context, params contract.GetDuplicateParam) ([]*entity.MovieDuplicate, error)
+	GetDuplicateCount(ctx context.Context, threshold float64) (int64, error)
+	Search(ctx context.Context, params contract.SearchParam) ([]*entity.MovieSearchResult, error)
+	GetSearchCount(ctx context.Context, params contract.SearchParam) (int64, error)
+	Suggest(ctx context.Context, params contract.SuggestParam) ([]*entity.MovieSuggestion, error)
+	LockMovieIDByTitle(ctx context.Context, title string) (int64, error)
+	GetGenreIDs(ctx context.Context, ids []int64) ([]int64, error)
+	SaveImportJob(ctx context.Context, job *entity.ImportJob) error
+	GetImportJob(ctx context.Context, id string) (entity.ImportJob, error)
+}
+
+// ImageStorage keep uploaded poster, key is slash separated path
+type ImageStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
 }
@@ -5,50 +5,88 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/Risuii/movie/src/entity"
 	"github.com/Risuii/movie/src/v1/contract"
 	"github.com/mariomac/gostream/stream"
 
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
 	frsUtils "github.com/Risuii/frs-lib/utils"
 	appErr "github.com/Risuii/movie/src/errors"
 )
 
 type MovieService struct {
 	MovieRepo MovieRepository
+	Storage   ImageStorage
+	Atomic    frsAtomic.AtomicSessionProvider
+
+	// RequireIfMatch reject update and delete without If-Match
+	RequireIfMatch bool
+
+	// imports hold a slot of every background import
+	imports chan struct{}
 }
 
-func InitMovieService(mRepo MovieRepository) *MovieService {
+func InitMovieService(mRepo MovieRepository, storage ImageStorage, atomic frsAtomic.AtomicSessionProvider, requireIfMatch bool) *MovieService {
 	return &MovieService{
-		MovieRepo: mRepo,
+		MovieRepo:      mRepo,
+		Storage:        storage,
+		Atomic:         atomic,
+		RequireIfMatch: requireIfMatch,
+		imports:        make(chan struct{}, MaxRunningImports),
 	}
 }
 
-func useNewValueIfNotNull(newValue, oldValue string) string {
-	if newValue != "" {
-		return newValue
-	}
-	return oldValue
+// atomic run fn in a transaction and invalidate the movie cache once after the transaction end,
+// write in fn only mark the cache as stale so a read before the commit can not cache the old row again
+func (ms *MovieService) atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, invalidateCache := ms.MovieRepo.DeferCacheInvalidation(ctx)
+	defer invalidateCache()
+
+	return frsAtomic.Atomic(ctx, ms.Atomic, fn)
 }
 
-func useNewFloatValueIfNotZero(newValue, oldValue float32) float32 {
-	if newValue != 0 {
-		return newValue
+// checkPrecondition compare If-Match with the current version of the movie,
+// missing If-Match is only rejected when RequireIfMatch is set
+func (ms *MovieService) checkPrecondition(match contract.ETagMatch, version int) error {
+	if match.IsEmpty() {
+		if ms.RequireIfMatch {
+			log.Println("check precondition err: ", appErr.ErrPreconditionRequired)
+			return appErr.ErrPreconditionRequired
+		}
+		return nil
 	}
-	return oldValue
-}
 
-func mapperMovieRequest(movie *entity.Movie, request *contract.MovieRequest) *entity.Movie {
-	movie.Title = useNewValueIfNotNull(request.Title, movie.Title)
-	movie.Description = useNewValueIfNotNull(request.Description, movie.Description)
-	movie.Rating = useNewFloatValueIfNotZero(request.Rating, movie.Rating)
-	movie.Image = useNewValueIfNotNull(request.Image, movie.Image)
+	if !match.Match(version) {
+		log.Println("check precondition err: ", appErr.ErrPreconditionFailed, version)
+		return appErr.ErrPreconditionFailed
+	}
 
-	return movie
+	return nil
 }
 
-func (ms *MovieService) Get(ctx context.Context, id int) (res contract.MovieResponse, err error) {
+// mapperMoviePatchRequest apply merge patch to data, field not in the patch is kept
+func mapperMoviePatchRequest(data *entity.MovieData, request *contract.MoviePatchRequest) {
+	if request.Title != nil {
+		data.Title = *request.Title
+	}
+	if request.Description != nil {
+		data.Description = *request.Description
+	}
+	if request.Rating != nil {
+		data.Rating = *request.Rating
+	}
+	if request.Image != nil {
+		data.Image = *request.Image
+	}
+	if request.Runtime != nil {
+		data.Runtime = *request.Runtime
+	}
+}
+
+func (ms *MovieService) Get(ctx context.Context, id int, params contract.GetMovieParam) (res contract.MovieResponse, err error) {
 
 	movie, err := ms.MovieRepo.Get(ctx, id)
 	if err != nil {
@@ -59,21 +97,128 @@ func (ms *MovieService) Get(ctx context.Context, id int) (res contract.MovieResp
 		return
 	}
 
+	var cast []*contract.CreditResponse
+	if params.IncludeCast {
+		cast, err = ms.getTopBilledCast(ctx, movie.Id, params.CastLimit)
+		if err != nil {
+			return
+		}
+	}
+
 	res = contract.MovieResponse{
 		ID:          int(movie.Id),
 		Title:       movie.Title,
 		Description: movie.Description,
 		Rating:      movie.Rating,
 		Image:       movie.Image,
+		Runtime:     movie.Runtime,
+		Genres:      movie.Genres,
 		CreatedAt:   movie.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:   movie.UpdatedAt.Format("2006-01-02 15:04:05"),
+
+		AudienceRating:      movie.AverageRating,
+		AudienceRatingCount: movie.RatingCount,
+
+		Cast: cast,
+
+		Version: movie.Version,
+	}
+	ms.mapperMovieImage(&res, movie.ImageKeys)
+
+	return
+}
+
+func (ms *MovieService) getTopBilledCast(ctx context.Context, movieID int64, limit int) ([]*contract.CreditResponse, error) {
+	credits, err := ms.MovieRepo.GetMovieCredits(ctx, movieID)
+	if err != nil {
+		log.Println("get movie credits err: ", err)
+		return nil, err
+	}
+
+	cast := stream.OfSlice(credits).Filter(func(c *entity.MovieCredit) bool {
+		return c.Role == entity.CreditRoleActor
+	}).Limit(limit)
+
+	return stream.Map(cast, mapperCreditResponse).ToSlice(), nil
+}
+
+func mapperCreditResponse(c *entity.MovieCredit) *contract.CreditResponse {
+	return &contract.CreditResponse{
+		PersonID:      c.PersonID,
+		PersonName:    c.PersonName,
+		Role:          c.Role,
+		CharacterName: c.CharacterName,
+		BillingOrder:  c.BillingOrder,
+	}
+}
+
+// GetCredits return every credit of the movie
+func (ms *MovieService) GetCredits(ctx context.Context, id int) (res []*contract.CreditResponse, err error) {
+
+	movie, err := ms.MovieRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("get movie err: ", err)
+		return
 	}
 
+	credits, err := ms.MovieRepo.GetMovieCredits(ctx, movie.Id)
+	if err != nil {
+		log.Println("get movie credits err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(credits), mapperCreditResponse).ToSlice()
+
 	return
 }
 
+// ReplaceCredits replace every credit of the movie in one transaction
+func (ms *MovieService) ReplaceCredits(ctx context.Context, request contract.MovieCreditsRequest, id int) (res []*contract.CreditResponse, err error) {
+
+	movie, err := ms.MovieRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("get movie err: ", err)
+		return
+	}
+
+	credits := stream.Map(stream.OfSlice(request.Credits), func(c contract.CreditRequest) *entity.MovieCredit {
+		return &entity.MovieCredit{
+			PersonID:      c.PersonID,
+			Role:          c.Role,
+			CharacterName: c.CharacterName,
+			BillingOrder:  c.BillingOrder,
+		}
+	}).ToSlice()
+
+	err = ms.atomic(ctx, func(ctx context.Context) error {
+		err := ms.MovieRepo.ReplaceMovieCredits(ctx, movie.Id, credits)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = appErr.ErrPersonIdNotFound
+			}
+			log.Println("replace movie credits err: ", err)
+		}
+		return err
+	})
+	if err != nil {
+		return
+	}
+
+	return ms.GetCredits(ctx, id)
+}
+
 func (ms *MovieService) GetList(ctx context.Context, params contract.GetListParam) (res contract.GetListResponse, err error) {
 
+	if params.CursorMode {
+		return ms.getCursorList(ctx, params)
+	}
+
 	movie, err := ms.MovieRepo.GetList(ctx, params)
 	if err != nil {
 		log.Println("get list movie err: ", err)
@@ -88,40 +233,146 @@ func (ms *MovieService) GetList(ctx context.Context, params contract.GetListPara
 
 	pagination := frsUtils.GetPaginationData(params.Page, params.Limit, int(count))
 
-	responseMovieList := stream.Map(stream.OfSlice(movie), func(m *entity.Movie) *contract.MovieResponse {
-		return &contract.MovieResponse{
-			ID:          int(m.Id),
-			Title:       m.Title,
-			Description: m.Description,
-			Rating:      m.Rating,
-			Image:       m.Image,
-			CreatedAt:   m.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:   m.UpdatedAt.Format("2006-01-02 15:04:05"),
+	res = contract.GetListResponse{
+		Data:       ms.mapperMovieListResponse(movie),
+		Pagination: pagination,
+	}
+
+	return
+}
+
+// getCursorList return one page of keyset pagination,
+// repository return one extra row to tell whether there is another page in the walking direction
+func (ms *MovieService) getCursorList(ctx context.Context, params contract.GetListParam) (res contract.GetListResponse, err error) {
+	movie, err := ms.MovieRepo.GetCursorList(ctx, params)
+	if err != nil {
+		log.Println("get cursor list movie err: ", err)
+		return
+	}
+
+	count, err := ms.MovieRepo.GetMovieCount(ctx, params)
+	if err != nil {
+		log.Println("get count movie err: ", err)
+		return
+	}
+
+	hasMore := len(movie) > params.Limit
+	if hasMore {
+		movie = movie[:params.Limit]
+	}
+
+	backward := params.Cursor != nil && params.Cursor.Backward
+	if backward {
+		for i, j := 0, len(movie)-1; i < j; i, j = i+1, j-1 {
+			movie[i], movie[j] = movie[j], movie[i]
 		}
-	}).ToSlice()
+	}
 
 	res = contract.GetListResponse{
-		Data:       responseMovieList,
-		Pagination: pagination,
+		Data:       ms.mapperMovieListResponse(movie),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	if len(movie) == 0 {
+		return
+	}
+
+	// walking forward there is a next page when extra row exist and a prev page when it is not the first page,
+	// walking backward it is the other way around
+	if backward || hasMore {
+		res.NextCursor = buildCursor(params.Sort, movie[len(movie)-1], false)
+	}
+
+	if (backward && hasMore) || (!backward && params.Cursor != nil) {
+		res.PrevCursor = buildCursor(params.Sort, movie[0], true)
 	}
 
 	return
 }
 
+func buildCursor(sort []contract.SortField, m *entity.Movie, backward bool) string {
+	values := make([]string, len(sort))
+	for i, s := range sort {
+		switch s.Field {
+		case contract.SortFieldTitle:
+			values[i] = m.Title
+		case contract.SortFieldRating:
+			values[i] = strconv.FormatFloat(float64(m.Rating), 'f', -1, 32)
+		case contract.SortFieldCreatedAt:
+			values[i] = m.CreatedAt.Format(time.RFC3339Nano)
+		case contract.SortFieldUpdatedAt:
+			values[i] = m.UpdatedAt.Format(time.RFC3339Nano)
+		}
+	}
+
+	return contract.EncodeCursor(contract.Cursor{
+		Sort:     contract.SortSignature(sort),
+		Values:   values,
+		ID:       m.Id,
+		Backward: backward,
+	})
+}
+
+func (ms *MovieService) mapperMovieListResponse(movie []*entity.Movie) []*contract.MovieResponse {
+	return stream.Map(stream.OfSlice(movie), ms.mapperMovieItemResponse).ToSlice()
+}
+
+func (ms *MovieService) mapperMovieItemResponse(m *entity.Movie) *contract.MovieResponse {
+	res := &contract.MovieResponse{
+		ID:          int(m.Id),
+		Title:       m.Title,
+		Description: m.Description,
+		Rating:      m.Rating,
+		Image:       m.Image,
+		Runtime:     m.Runtime,
+		Genres:      m.Genres,
+		CreatedAt:   m.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   m.UpdatedAt.Format("2006-01-02 15:04:05"),
+
+		AudienceRating:      m.AverageRating,
+		AudienceRatingCount: m.RatingCount,
+
+		Version: m.Version,
+	}
+	ms.mapperMovieImage(res, m.ImageKeys)
+
+	return res
+}
+
 func (ms *MovieService) Create(ctx context.Context, request contract.MovieRequest) (res contract.MovieResponse, err error) {
 
 	req := &entity.Movie{
 		MovieData: entity.MovieData{
 			Title:       request.Title,
 			Description: request.Description,
-			Rating:      request.Rating,
+			Rating:      *request.Rating,
 			Image:       request.Image,
+			Runtime:     request.Runtime,
 		},
 	}
 
-	movie, err := ms.MovieRepo.Create(ctx, req)
+	var movie contract.MovieResponseDB
+	var genres []string
+
+	err = ms.atomic(ctx, func(ctx context.Context) error {
+		var err error
+		movie, err = ms.MovieRepo.Create(ctx, req)
+		if err != nil {
+			log.Println("error create movie err: ", err)
+			return err
+		}
+
+		if request.GenreIDs != nil {
+			genres, err = ms.replaceMovieGenres(ctx, int64(movie.ID), request.GenreIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return ms.recordRevision(ctx, entity.RevisionActionCreate, int64(movie.ID), nil,
+			movieSnapshot(req.MovieData, request.GenreIDs, false, movie.Version), nil)
+	})
 	if err != nil {
-		log.Println("error create movie err: ", err)
 		return
 	}
 
@@ -131,14 +382,65 @@ func (ms *MovieService) Create(ctx context.Context, request contract.MovieReques
 		Description: movie.Description,
 		Rating:      movie.Rating,
 		Image:       movie.Image,
+		Runtime:     movie.Runtime,
+		Genres:      genres,
 		CreatedAt:   movie.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:   movie.UpdatedAt.Format("2006-01-02 15:04:05"),
+
+		Version: movie.Version,
 	}
 
 	return
 }
 
-func (ms *MovieService) Update(ctx context.Context, request contract.MovieRequest, id int) (res contract.MovieResponse, err error) {
+// replaceMovieGenres link movie to genreIDs, it fail with ErrGenreIdNotFound
+// when one of the genre does not exist so the transaction is rolled back
+func (ms *MovieService) replaceMovieGenres(ctx context.Context, movieID int64, genreIDs []int64) ([]string, error) {
+	uniqueIDs := stream.Distinct(stream.OfSlice(genreIDs)).ToSlice()
+
+	genres, err := ms.MovieRepo.ReplaceMovieGenres(ctx, movieID, uniqueIDs)
+	if err != nil {
+		log.Println("replace movie genres err: ", err)
+		return nil, err
+	}
+
+	if len(genres) != len(uniqueIDs) {
+		log.Println("replace movie genres err: ", appErr.ErrGenreIdNotFound)
+		return nil, appErr.ErrGenreIdNotFound
+	}
+
+	return genres, nil
+}
+
+// Update apply merge patch to the movie, genre is only replaced when genre_ids is in the patch
+func (ms *MovieService) Update(ctx context.Context, request contract.MoviePatchRequest, id int) (res contract.MovieResponse, err error) {
+	return ms.update(ctx, id, request.IfMatch, func(data *entity.MovieData) []int64 {
+		mapperMoviePatchRequest(data, &request)
+		return request.GenreIDs
+	})
+}
+
+// Replace overwrite every editable field and linked genre of the movie
+func (ms *MovieService) Replace(ctx context.Context, request contract.MovieRequest, id int) (res contract.MovieResponse, err error) {
+	return ms.update(ctx, id, request.IfMatch, func(data *entity.MovieData) []int64 {
+		*data = entity.MovieData{
+			Title:       request.Title,
+			Description: request.Description,
+			Rating:      *request.Rating,
+			Image:       request.Image,
+			Runtime:     request.Runtime,
+		}
+
+		if request.GenreIDs == nil {
+			return []int64{}
+		}
+		return request.GenreIDs
+	})
+}
+
+// update write the movie with its revision in one transaction, apply change
+// data read from the locked row and return genre id to link, nil keep linked genre
+func (ms *MovieService) update(ctx context.Context, id int, ifMatch contract.ETagMatch, apply func(data *entity.MovieData) []int64) (res contract.MovieResponse, err error) {
 
 	movie, err := ms.MovieRepo.Get(ctx, id)
 	if err != nil {
@@ -149,11 +451,10 @@ func (ms *MovieService) Update(ctx context.Context, request contract.MovieReques
 		return
 	}
 
-	movie = *mapperMovieRequest(&movie, &request)
-
-	err = ms.MovieRepo.Update(ctx, &movie)
+	err = ms.atomic(ctx, func(ctx context.Context) error {
+		return ms.updateLocked(ctx, &movie, ifMatch, apply)
+	})
 	if err != nil {
-		log.Println("update movie err: ", err)
 		return
 	}
 
@@ -163,14 +464,69 @@ func (ms *MovieService) Update(ctx context.Context, request contract.MovieReques
 		Description: movie.Description,
 		Rating:      movie.Rating,
 		Image:       movie.Image,
+		Runtime:     movie.Runtime,
+		Genres:      movie.Genres,
 		CreatedAt:   movie.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:   time.Now().Format("2006-01-02 15:04:05"),
+
+		AudienceRating:      movie.AverageRating,
+		AudienceRatingCount: movie.RatingCount,
+
+		Version: movie.Version,
 	}
+	ms.mapperMovieImage(&res, movie.ImageKeys)
 
 	return
 }
 
-func (ms *MovieService) Delete(ctx context.Context, id int) (err error) {
+// updateLocked lock the movie and write it with its revision, it must run in a transaction.
+// movie.Id is the movie to update, its data, genre and version is filled with the written one
+func (ms *MovieService) updateLocked(ctx context.Context, movie *entity.Movie, ifMatch contract.ETagMatch, apply func(data *entity.MovieData) []int64) error {
+	before, err := ms.lockSnapshot(ctx, movie.Id)
+	if err != nil {
+		return err
+	}
+
+	if before.Deleted {
+		log.Println("update movie err: ", appErr.ErrMovieIdNotFound)
+		return appErr.ErrMovieIdNotFound
+	}
+
+	if err = ms.checkPrecondition(ifMatch, before.Version); err != nil {
+		return err
+	}
+
+	// apply on the locked row instead of the cached one so
+	// concurrent update is not silently overwritten
+	movie.MovieData = entity.MovieData{
+		Title:       before.Title,
+		Description: before.Description,
+		Rating:      before.Rating,
+		Image:       before.Image,
+		Runtime:     before.Runtime,
+	}
+	requestGenreIDs := apply(&movie.MovieData)
+
+	err = ms.MovieRepo.Update(ctx, movie)
+	if err != nil {
+		log.Println("update movie err: ", err)
+		return err
+	}
+
+	genreIDs := before.GenreIDs
+	if requestGenreIDs != nil {
+		genreIDs = requestGenreIDs
+		movie.Genres, err = ms.replaceMovieGenres(ctx, movie.Id, requestGenreIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ms.recordRevision(ctx, entity.RevisionActionUpdate, movie.Id, &before,
+		movieSnapshot(movie.MovieData, genreIDs, false, movie.Version), nil)
+}
+
+func (ms *MovieService) Delete(ctx context.Context, id int, match contract.ETagMatch) (err error) {
 
 	movie, err := ms.MovieRepo.Get(ctx, id)
 	if err != nil {
@@ -181,11 +537,36 @@ func (ms *MovieService) Delete(ctx context.Context, id int) (err error) {
 		return
 	}
 
-	err = ms.MovieRepo.Delete(ctx, movie.Id)
+	err = ms.atomic(ctx, func(ctx context.Context) error {
+		return ms.deleteLocked(ctx, movie.Id, match)
+	})
 	if err != nil {
-		log.Println("delete err: ", err)
 		return
 	}
 
 	return
 }
+
+// deleteLocked lock and soft delete the movie with its revision, it must run in a transaction
+func (ms *MovieService) deleteLocked(ctx context.Context, id int64, match contract.ETagMatch) error {
+	before, err := ms.lockSnapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = ms.checkPrecondition(match, before.Version); err != nil {
+		return err
+	}
+
+	err = ms.MovieRepo.Delete(ctx, id)
+	if err != nil {
+		log.Println("delete err: ", err)
+		return err
+	}
+
+	after := before
+	after.Deleted = true
+	after.Version++
+
+	return ms.recordRevision(ctx, entity.RevisionActionDelete, id, &before, after, nil)
+}
@@ -17,9 +17,37 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	appErr "github.com/Risuii/movie/src/errors"
 	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
 )
 
+// expectAtomic expect one transaction that is committed or rolled back
+func expectAtomic(mock *mock_atomic.MockAtomicSessionProvider, session *mock_atomic.MockAtomicSession, commit bool) {
+	mock.EXPECT().BeginSession(gomock.Any()).DoAndReturn(func(ctx context.Context) (*frsAtomic.AtomicSessionContext, error) {
+		return frsAtomic.NewAtomicSessionContext(ctx, session), nil
+	}).Times(1)
+
+	if commit {
+		session.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+	} else {
+		session.EXPECT().Rollback(gomock.Any()).Return(nil).Times(1)
+	}
+}
+
+func float32Ptr(f float32) *float32 {
+	return &f
+}
+
+// expectDeferCache pass the context through, the repository mark the cache stale and the
+// returned func is the one that delete it
+func expectDeferCache(mock *mock_movie.MockMovieRepository) {
+	mock.EXPECT().DeferCacheInvalidation(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, func()) {
+		return ctx, func() {}
+	}).AnyTimes()
+}
+
 func TestMain(m *testing.M) {
 	os.Chdir("../../../../")
 
@@ -36,18 +64,25 @@ func TestGetMovieService(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
 
 	type mockFields struct {
 		movieRepo *mock_movie.MockMovieRepository
+		atomic    *mock_atomic.MockAtomicSessionProvider
+		session   *mock_atomic.MockAtomicSession
 	}
 
 	mocks := mockFields{
 		movieRepo: mockMovieRepo,
+		atomic:    mockAtomic,
+		session:   mockSession,
 	}
 
 	type args struct {
-		ctx context.Context
-		id  int
+		ctx    context.Context
+		id     int
+		params contract.GetMovieParam
 	}
 
 	tests := []struct {
@@ -96,14 +131,53 @@ func TestGetMovieService(t *testing.T) {
 				mock.movieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{}, nil).Times(1)
 			},
 		},
+		{
+			name: "error get cast",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				params: contract.GetMovieParam{IncludeCast: true, CastLimit: 1},
+			},
+			want:    contract.MovieResponse{},
+			wantErr: true,
+			mockFunc: func(mock mockFields, arg args) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mock.movieRepo.EXPECT().GetMovieCredits(gomock.Any(), int64(1)).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success with top billed cast",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				params: contract.GetMovieParam{IncludeCast: true, CastLimit: 1},
+			},
+			want: contract.MovieResponse{
+				ID:        1,
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+				Cast: []*contract.CreditResponse{
+					{PersonID: 2, PersonName: "actor-1", Role: entity.CreditRoleActor, CharacterName: "lead", BillingOrder: 1},
+				},
+			},
+			wantErr: false,
+			mockFunc: func(mock mockFields, arg args) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mock.movieRepo.EXPECT().GetMovieCredits(gomock.Any(), int64(1)).Return([]*entity.MovieCredit{
+					{PersonID: 1, PersonName: "director-1", Role: entity.CreditRoleDirector},
+					{PersonID: 2, PersonName: "actor-1", Role: entity.CreditRoleActor, CharacterName: "lead", BillingOrder: 1},
+					{PersonID: 3, PersonName: "actor-2", Role: entity.CreditRoleActor, CharacterName: "support", BillingOrder: 2},
+				}, nil).Times(1)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(t.Name(), func(t *testing.T) {
 			tt.mockFunc(mocks, tt.args)
 
-			p := InitMovieService(mockMovieRepo)
-			got, err := p.Get(tt.args.ctx, tt.args.id)
+			p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
+			got, err := p.Get(tt.args.ctx, tt.args.id, tt.args.params)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Movie.Get() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -119,13 +193,19 @@ func TestGetListMovieService(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
 
 	type mockFields struct {
 		movieRepo *mock_movie.MockMovieRepository
+		atomic    *mock_atomic.MockAtomicSessionProvider
+		session   *mock_atomic.MockAtomicSession
 	}
 
 	mocks := mockFields{
 		movieRepo: mockMovieRepo,
+		atomic:    mockAtomic,
+		session:   mockSession,
 	}
 
 	type args struct {
@@ -228,7 +308,7 @@ func TestGetListMovieService(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc(mocks, tt.args)
 
-			p := InitMovieService(mockMovieRepo)
+			p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
 			got, err := p.GetList(context.Background(), tt.args.params)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("movie.GetList() error = %v, wantErr %v", err, tt.wantErr)
@@ -240,18 +320,148 @@ func TestGetListMovieService(t *testing.T) {
 	}
 }
 
+func TestGetCursorListMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	type mockFields struct {
+		movieRepo *mock_movie.MockMovieRepository
+		atomic    *mock_atomic.MockAtomicSessionProvider
+		session   *mock_atomic.MockAtomicSession
+	}
+
+	mocks := mockFields{
+		movieRepo: mockMovieRepo,
+		atomic:    mockAtomic,
+		session:   mockSession,
+	}
+
+	type args struct {
+		ctx    context.Context
+		params contract.GetListParam
+	}
+
+	mockEntityMovie := []*entity.Movie{
+		{ModelID: entity.ModelID{Id: 1}, MovieData: entity.MovieData{Title: "a", Rating: 9}},
+		{ModelID: entity.ModelID{Id: 2}, MovieData: entity.MovieData{Title: "b", Rating: 8}},
+		{ModelID: entity.ModelID{Id: 3}, MovieData: entity.MovieData{Title: "c", Rating: 7}},
+	}
+
+	sort := []contract.SortField{{Field: contract.SortFieldRating, Desc: true}}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantLen   int
+		wantFirst int
+		wantNext  *contract.Cursor
+		wantPrev  *contract.Cursor
+		wantErr   bool
+		mockFunc  func(mock mockFields, args args)
+	}{
+		{
+			name: "error get cursor list",
+			args: args{
+				ctx:    context.Background(),
+				params: contract.GetListParam{Page: 1, Limit: 2, CursorMode: true, Sort: sort},
+			},
+			wantErr: true,
+			mockFunc: func(mock mockFields, args args) {
+				mock.movieRepo.EXPECT().GetCursorList(gomock.Any(), args.params).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success first page",
+			args: args{
+				ctx:    context.Background(),
+				params: contract.GetListParam{Page: 1, Limit: 2, CursorMode: true, Sort: sort},
+			},
+			wantLen:   2,
+			wantFirst: 1,
+			wantNext:  &contract.Cursor{Sort: "rating:desc", Values: []string{"8"}, ID: 2},
+			mockFunc: func(mock mockFields, args args) {
+				mock.movieRepo.EXPECT().GetCursorList(gomock.Any(), args.params).Return(mockEntityMovie, nil).Times(1)
+				mock.movieRepo.EXPECT().GetMovieCount(gomock.Any(), args.params).Return(int64(3), nil).Times(1)
+			},
+		},
+		{
+			name: "success backward page",
+			args: args{
+				ctx: context.Background(),
+				params: contract.GetListParam{Page: 1, Limit: 2, CursorMode: true, Sort: sort,
+					Cursor: &contract.Cursor{Sort: "rating:desc", Values: []string{"6"}, ID: 4, Backward: true}},
+			},
+			wantLen:   2,
+			wantFirst: 2,
+			wantNext:  &contract.Cursor{Sort: "rating:desc", Values: []string{"7"}, ID: 3},
+			wantPrev:  &contract.Cursor{Sort: "rating:desc", Values: []string{"8"}, ID: 2, Backward: true},
+			mockFunc: func(mock mockFields, args args) {
+				mock.movieRepo.EXPECT().GetCursorList(gomock.Any(), args.params).Return([]*entity.Movie{
+					mockEntityMovie[2], mockEntityMovie[1], mockEntityMovie[0],
+				}, nil).Times(1)
+				mock.movieRepo.EXPECT().GetMovieCount(gomock.Any(), args.params).Return(int64(4), nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks, tt.args)
+
+			p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
+			got, err := p.GetList(tt.args.ctx, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("movie.GetList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Len(t, got.Data, tt.wantLen)
+			assert.Equal(t, tt.wantFirst, got.Data[0].ID)
+
+			for _, c := range []struct {
+				token string
+				want  *contract.Cursor
+			}{{got.NextCursor, tt.wantNext}, {got.PrevCursor, tt.wantPrev}} {
+				if c.want == nil {
+					assert.Empty(t, c.token)
+					continue
+				}
+
+				cursor, err := contract.DecodeCursor(c.token, sort)
+				assert.Nil(t, err)
+				assert.Equal(t, c.want, cursor)
+			}
+		})
+	}
+}
+
 func TestCreateMovieService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
 
 	type mockFields struct {
 		movieRepo *mock_movie.MockMovieRepository
+		atomic    *mock_atomic.MockAtomicSessionProvider
+		session   *mock_atomic.MockAtomicSession
 	}
 
 	mocks := mockFields{
 		movieRepo: mockMovieRepo,
+		atomic:    mockAtomic,
+		session:   mockSession,
 	}
 
 	type args struct {
@@ -271,12 +481,13 @@ func TestCreateMovieService(t *testing.T) {
 			name: "error",
 			args: args{
 				ctx:     context.Background(),
-				request: contract.MovieRequest{},
+				request: contract.MovieRequest{Rating: float32Ptr(0)},
 				params:  &entity.Movie{},
 			},
 			want:    contract.MovieResponse{},
 			wantErr: true,
 			mockFunc: func(mock mockFields, arg args) {
+				expectAtomic(mock.atomic, mock.session, false)
 				mockMovieRepo.EXPECT().Create(gomock.Any(), arg.params).Return(contract.MovieResponseDB{}, assert.AnError).Times(1)
 			},
 		},
@@ -287,7 +498,7 @@ func TestCreateMovieService(t *testing.T) {
 				request: contract.MovieRequest{
 					Title:       "test-title-1",
 					Description: "test-description-1",
-					Rating:      1,
+					Rating:      float32Ptr(1),
 					Image:       "test-image-1",
 				},
 				params: &entity.Movie{
@@ -305,7 +516,71 @@ func TestCreateMovieService(t *testing.T) {
 			},
 			wantErr: false,
 			mockFunc: func(mock mockFields, arg args) {
+				expectAtomic(mock.atomic, mock.session, true)
 				mockMovieRepo.EXPECT().Create(gomock.Any(), arg.params).Return(contract.MovieResponseDB{}, nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "error genre not found",
+			args: args{
+				ctx: context.Background(),
+				request: contract.MovieRequest{
+					Title:    "test-title-1",
+					Rating:   float32Ptr(1),
+					GenreIDs: []int64{1, 2, 2},
+				},
+				params: &entity.Movie{
+					MovieData: entity.MovieData{
+						Title:  "test-title-1",
+						Rating: 1,
+					},
+				},
+			},
+			want:    contract.MovieResponse{},
+			wantErr: true,
+			mockFunc: func(mock mockFields, arg args) {
+				expectAtomic(mock.atomic, mock.session, false)
+				mockMovieRepo.EXPECT().Create(gomock.Any(), arg.params).Return(contract.MovieResponseDB{ID: 1}, nil).Times(1)
+				mockMovieRepo.EXPECT().ReplaceMovieGenres(gomock.Any(), int64(1), []int64{1, 2}).Return([]string{"horror"}, nil).Times(1)
+			},
+		},
+		{
+			name: "success with genres",
+			args: args{
+				ctx: context.Background(),
+				request: contract.MovieRequest{
+					Title:    "test-title-1",
+					Rating:   float32Ptr(1),
+					GenreIDs: []int64{1, 2},
+				},
+				params: &entity.Movie{
+					MovieData: entity.MovieData{
+						Title:  "test-title-1",
+						Rating: 1,
+					},
+				},
+			},
+			want: contract.MovieResponse{
+				ID:        1,
+				Genres:    []string{"drama", "horror"},
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+			},
+			wantErr: false,
+			mockFunc: func(mock mockFields, arg args) {
+				expectAtomic(mock.atomic, mock.session, true)
+				mockMovieRepo.EXPECT().Create(gomock.Any(), arg.params).Return(contract.MovieResponseDB{ID: 1}, nil).Times(1)
+				mockMovieRepo.EXPECT().ReplaceMovieGenres(gomock.Any(), int64(1), []int64{1, 2}).Return([]string{"drama", "horror"}, nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data *entity.MovieRevision) error {
+						assert.Equal(t, int64(1), data.MovieID)
+						assert.Equal(t, entity.RevisionActionCreate, data.Action)
+						assert.Nil(t, data.Before)
+						assert.Equal(t, []int64{1, 2}, data.After.GenreIDs)
+						assert.JSONEq(t, `"test-title-1"`, string(data.Diff["title"].To))
+						return nil
+					}).Times(1)
 			},
 		},
 	}
@@ -314,7 +589,7 @@ func TestCreateMovieService(t *testing.T) {
 		t.Run(t.Name(), func(t *testing.T) {
 			tt.mockFunc(mocks, tt.args)
 
-			p := InitMovieService(mockMovieRepo)
+			p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
 			got, err := p.Create(tt.args.ctx, tt.args.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Movie.Create() error = %v, wantErr %v", err, tt.wantErr)
@@ -331,18 +606,28 @@ func TestUpdateMovieService(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
 
 	type mockFields struct {
 		movieRepo *mock_movie.MockMovieRepository
+		atomic    *mock_atomic.MockAtomicSessionProvider
+		session   *mock_atomic.MockAtomicSession
 	}
 
 	mocks := mockFields{
 		movieRepo: mockMovieRepo,
+		atomic:    mockAtomic,
+		session:   mockSession,
 	}
 
+	description := "new-description"
+	var clearRating float32
+
 	type args struct {
 		ctx     context.Context
-		request contract.MovieRequest
+		request contract.MoviePatchRequest
 		params  *entity.Movie
 		id      int
 	}
@@ -358,7 +643,7 @@ func TestUpdateMovieService(t *testing.T) {
 			name: "error id not found",
 			args: args{
 				ctx:     context.Background(),
-				request: contract.MovieRequest{},
+				request: contract.MoviePatchRequest{},
 				params:  &entity.Movie{},
 				id:      1,
 			},
@@ -372,7 +657,7 @@ func TestUpdateMovieService(t *testing.T) {
 			name: "error update",
 			args: args{
 				ctx:     context.Background(),
-				request: contract.MovieRequest{},
+				request: contract.MoviePatchRequest{},
 				params:  &entity.Movie{},
 				id:      1,
 			},
@@ -380,25 +665,80 @@ func TestUpdateMovieService(t *testing.T) {
 			wantErr: true,
 			mockFunc: func(mock mockFields, arg args) {
 				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{}, nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(0)).Return(entity.MovieSnapshot{}, nil).Times(1)
 				mockMovieRepo.EXPECT().Update(gomock.Any(), arg.params).Return(assert.AnError).Times(1)
 			},
 		},
 		{
-			name: "success",
+			name: "error precondition failed",
 			args: args{
 				ctx:     context.Background(),
-				request: contract.MovieRequest{},
-				params:  &entity.Movie{},
+				request: contract.MoviePatchRequest{IfMatch: contract.ETagMatch{Versions: []int{1}}},
 				id:      1,
 			},
+			want:    contract.MovieResponse{},
+			wantErr: true,
+			mockFunc: func(mock mockFields, arg args) {
+				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{}, nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(0)).Return(entity.MovieSnapshot{Version: 2}, nil).Times(1)
+			},
+		},
+		{
+			name: "success merge on locked row",
+			args: args{
+				ctx: context.Background(),
+				request: contract.MoviePatchRequest{
+					Description: &description,
+					Rating:      &clearRating,
+					IfMatch:     contract.ETagMatch{Versions: []int{2}},
+				},
+				params: &entity.Movie{
+					ModelID: entity.ModelID{Id: 1},
+					MovieData: entity.MovieData{
+						Title:       "old-title",
+						Description: "new-description",
+						Image:       "old-image",
+					},
+				},
+				id: 1,
+			},
 			want: contract.MovieResponse{
-				CreatedAt: "0001-01-01 00:00:00",
-				UpdatedAt: time.Now().Format("2006-01-02 15:04:05"),
+				ID:          1,
+				Title:       "old-title",
+				Description: "new-description",
+				Image:       "old-image",
+				CreatedAt:   "0001-01-01 00:00:00",
+				UpdatedAt:   time.Now().Format("2006-01-02 15:04:05"),
+				Version:     3,
 			},
 			wantErr: false,
 			mockFunc: func(mock mockFields, arg args) {
-				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{}, nil).Times(1)
-				mockMovieRepo.EXPECT().Update(gomock.Any(), arg.params).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{
+					ModelID:   entity.ModelID{Id: 1},
+					MovieData: entity.MovieData{Title: "cached-title"},
+				}, nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, true)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).
+					Return(entity.MovieSnapshot{Title: "old-title", Rating: 5, Image: "old-image", GenreIDs: []int64{3}, Version: 2}, nil).Times(1)
+				mockMovieRepo.EXPECT().Update(gomock.Any(), arg.params).
+					DoAndReturn(func(ctx context.Context, data *entity.Movie) error {
+						data.Version = 3
+						return nil
+					}).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data *entity.MovieRevision) error {
+						assert.Equal(t, entity.RevisionActionUpdate, data.Action)
+						assert.Equal(t, "old-title", data.Before.Title)
+						assert.Equal(t, []int64{3}, data.After.GenreIDs)
+						assert.Equal(t, entity.RevisionDiff{
+							"description": {From: []byte(`""`), To: []byte(`"new-description"`)},
+							"rating":      {From: []byte(`5`), To: []byte(`0`)},
+							"version":     {From: []byte(`2`), To: []byte(`3`)},
+						}, data.Diff)
+						return nil
+					}).Times(1)
 			},
 		},
 	}
@@ -407,10 +747,103 @@ func TestUpdateMovieService(t *testing.T) {
 		t.Run(t.Name(), func(t *testing.T) {
 			tt.mockFunc(mocks, tt.args)
 
-			p := InitMovieService(mockMovieRepo)
+			p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
 			got, err := p.Update(tt.args.ctx, tt.args.request, tt.args.id)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Movie.Create() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Movie.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReplaceMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	type args struct {
+		ctx     context.Context
+		request contract.MovieRequest
+		id      int
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		want     contract.MovieResponse
+		wantErr  error
+		mockFunc func(arg args)
+	}{
+		{
+			name: "error deleted movie",
+			args: args{
+				ctx:     context.Background(),
+				request: contract.MovieRequest{Title: "new-title"},
+				id:      1,
+			},
+			want:    contract.MovieResponse{},
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func(arg args) {
+				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				expectAtomic(mockAtomic, mockSession, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{Deleted: true}, nil).Times(1)
+			},
+		},
+		{
+			name: "success overwrite every field and unlink genre",
+			args: args{
+				ctx:     context.Background(),
+				request: contract.MovieRequest{Title: "new-title", Rating: float32Ptr(0), Runtime: 90},
+				id:      1,
+			},
+			want: contract.MovieResponse{
+				ID:        1,
+				Title:     "new-title",
+				Runtime:   90,
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: time.Now().Format("2006-01-02 15:04:05"),
+				Version:   3,
+			},
+			mockFunc: func(arg args) {
+				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				expectAtomic(mockAtomic, mockSession, true)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).
+					Return(entity.MovieSnapshot{Title: "old-title", Description: "old-description", Rating: 5, GenreIDs: []int64{3}, Version: 2}, nil).Times(1)
+				mockMovieRepo.EXPECT().Update(gomock.Any(), &entity.Movie{
+					ModelID:   entity.ModelID{Id: 1},
+					MovieData: entity.MovieData{Title: "new-title", Runtime: 90},
+				}).DoAndReturn(func(ctx context.Context, data *entity.Movie) error {
+					data.Version = 3
+					return nil
+				}).Times(1)
+				mockMovieRepo.EXPECT().ReplaceMovieGenres(gomock.Any(), int64(1), gomock.Len(0)).Return(nil, nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data *entity.MovieRevision) error {
+						assert.Equal(t, []int64{}, data.After.GenreIDs)
+						assert.Contains(t, data.Diff, "description")
+						assert.Contains(t, data.Diff, "rating")
+						assert.Contains(t, data.Diff, "genre_ids")
+						return nil
+					}).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.args)
+
+			p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
+			got, err := p.Replace(tt.args.ctx, tt.args.request, tt.args.id)
+			if err != tt.wantErr {
+				t.Errorf("Movie.Replace() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
@@ -424,13 +857,20 @@ func TestDeleteMovieService(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
 
 	type mockFields struct {
 		movieRepo *mock_movie.MockMovieRepository
+		atomic    *mock_atomic.MockAtomicSessionProvider
+		session   *mock_atomic.MockAtomicSession
 	}
 
 	mocks := mockFields{
 		movieRepo: mockMovieRepo,
+		atomic:    mockAtomic,
+		session:   mockSession,
 	}
 
 	type args struct {
@@ -447,7 +887,8 @@ func TestDeleteMovieService(t *testing.T) {
 		{
 			name: "error id not found",
 			args: args{
-				id: 1,
+				ctx: context.Background(),
+				id:  1,
 			},
 			wantErr: true,
 			mockFunc: func(mock mockFields, arg args) {
@@ -457,23 +898,38 @@ func TestDeleteMovieService(t *testing.T) {
 		{
 			name: "error delete",
 			args: args{
-				id: 1,
+				ctx: context.Background(),
+				id:  1,
 			},
 			wantErr: true,
 			mockFunc: func(mock mockFields, arg args) {
 				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{}, nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(0)).Return(entity.MovieSnapshot{}, nil).Times(1)
 				mockMovieRepo.EXPECT().Delete(gomock.Any(), int64(0)).Return(assert.AnError).Times(1)
 			},
 		},
 		{
 			name: "success",
 			args: args{
-				id: 1,
+				ctx: context.Background(),
+				id:  1,
 			},
 			wantErr: false,
 			mockFunc: func(mock mockFields, arg args) {
 				mockMovieRepo.EXPECT().Get(gomock.Any(), arg.id).Return(entity.Movie{}, nil).Times(1)
+				expectAtomic(mock.atomic, mock.session, true)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(0)).Return(entity.MovieSnapshot{}, nil).Times(1)
 				mockMovieRepo.EXPECT().Delete(gomock.Any(), int64(0)).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data *entity.MovieRevision) error {
+						assert.Equal(t, entity.RevisionActionDelete, data.Action)
+						assert.Equal(t, entity.RevisionDiff{
+							"deleted": {From: []byte(`false`), To: []byte(`true`)},
+							"version": {From: []byte(`0`), To: []byte(`1`)},
+						}, data.Diff)
+						return nil
+					}).Times(1)
 			},
 		},
 	}
@@ -482,8 +938,8 @@ func TestDeleteMovieService(t *testing.T) {
 		t.Run(t.Name(), func(t *testing.T) {
 			tt.mockFunc(mocks, tt.args)
 
-			p := InitMovieService(mockMovieRepo)
-			err := p.Delete(tt.args.ctx, tt.args.id)
+			p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
+			err := p.Delete(tt.args.ctx, tt.args.id, contract.ETagMatch{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Movie.Get() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -492,3 +948,38 @@ func TestDeleteMovieService(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteMovieServiceInvalidateCacheAfterCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	var committed, invalidated bool
+	mockMovieRepo.EXPECT().DeferCacheInvalidation(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, func()) {
+		return ctx, func() {
+			assert.True(t, committed, "cache is invalidated before the commit")
+			invalidated = true
+		}
+	}).Times(1)
+	mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{}, nil).Times(1)
+	mockAtomic.EXPECT().BeginSession(gomock.Any()).DoAndReturn(func(ctx context.Context) (*frsAtomic.AtomicSessionContext, error) {
+		return frsAtomic.NewAtomicSessionContext(ctx, mockSession), nil
+	}).Times(1)
+	mockSession.EXPECT().Commit(gomock.Any()).DoAndReturn(func(context.Context) error {
+		assert.False(t, invalidated)
+		committed = true
+		return nil
+	}).Times(1)
+	mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(0)).Return(entity.MovieSnapshot{}, nil).Times(1)
+	mockMovieRepo.EXPECT().Delete(gomock.Any(), int64(0)).Return(nil).Times(1)
+	mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	p := InitMovieService(mockMovieRepo, nil, mockAtomic, false)
+	err := p.Delete(context.Background(), 1, contract.ETagMatch{})
+
+	assert.NoError(t, err)
+	assert.True(t, invalidated)
+}
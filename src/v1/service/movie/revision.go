@@ -0,0 +1,208 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sort"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/Risuii/movie/src/middleware/request"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+// snapshotGenreIDs return unique genre id in ascending order, the same order
+// repository build the snapshot with so unchanged genre does not show in the diff
+func snapshotGenreIDs(genreIDs []int64) []int64 {
+	ids := stream.Distinct(stream.OfSlice(genreIDs)).ToSlice()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if ids == nil {
+		ids = []int64{}
+	}
+	return ids
+}
+
+func movieSnapshot(data entity.MovieData, genreIDs []int64, deleted bool, version int) entity.MovieSnapshot {
+	return entity.MovieSnapshot{
+		Title:       data.Title,
+		Description: data.Description,
+		Rating:      data.Rating,
+		Image:       data.Image,
+		Runtime:     data.Runtime,
+		GenreIDs:    snapshotGenreIDs(genreIDs),
+		Deleted:     deleted,
+		Version:     version,
+	}
+}
+
+// lockSnapshot lock the movie for the rest of the transaction and return its current state
+func (ms *MovieService) lockSnapshot(ctx context.Context, id int64) (entity.MovieSnapshot, error) {
+	snapshot, err := ms.MovieRepo.LockSnapshot(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("lock movie snapshot err: ", err)
+	}
+	return snapshot, err
+}
+
+// recordRevision write the revision with the transaction of the mutation,
+// actor and request id is taken from the request context
+func (ms *MovieService) recordRevision(ctx context.Context, action string, movieID int64, before *entity.MovieSnapshot, after entity.MovieSnapshot, revertOf *int) error {
+	diff, err := entity.DiffSnapshots(before, after)
+	if err != nil {
+		log.Println("diff movie snapshot err: ", err)
+		return err
+	}
+
+	err = ms.MovieRepo.CreateRevision(ctx, &entity.MovieRevision{
+		MovieID:   movieID,
+		Action:    action,
+		Actor:     auth.GetSubject(ctx),
+		RequestID: request.GetRequestID(ctx),
+		Before:    before,
+		After:     after,
+		Diff:      diff,
+		RevertOf:  revertOf,
+	})
+	if err != nil {
+		log.Println("create movie revision err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func mapperSnapshotResponse(s entity.MovieSnapshot) *contract.MovieSnapshotResponse {
+	return &contract.MovieSnapshotResponse{
+		Title:       s.Title,
+		Description: s.Description,
+		Rating:      s.Rating,
+		Image:       s.Image,
+		Runtime:     s.Runtime,
+		GenreIDs:    s.GenreIDs,
+		Deleted:     s.Deleted,
+		Version:     s.Version,
+	}
+}
+
+func mapperRevisionResponse(r *entity.MovieRevision) *contract.RevisionResponse {
+	res := &contract.RevisionResponse{
+		Revision:  r.Revision,
+		MovieID:   r.MovieID,
+		Action:    r.Action,
+		Actor:     r.Actor,
+		RequestID: r.RequestID,
+		After:     *mapperSnapshotResponse(r.After),
+		Diff:      make(map[string]contract.FieldChangeResponse, len(r.Diff)),
+		RevertOf:  r.RevertOf,
+		CreatedAt: r.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if r.Before != nil {
+		res.Before = mapperSnapshotResponse(*r.Before)
+	}
+
+	for field, change := range r.Diff {
+		res.Diff[field] = contract.FieldChangeResponse{From: change.From, To: change.To}
+	}
+
+	return res
+}
+
+// GetHistory return revision of the movie, latest first, history of
+// soft deleted movie is still available
+func (ms *MovieService) GetHistory(ctx context.Context, id int, params contract.GetListParam) (res contract.GetListRevisionResponse, err error) {
+
+	_, err = ms.MovieRepo.GetSnapshot(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("get movie snapshot err: ", err)
+		return
+	}
+
+	revisions, err := ms.MovieRepo.GetRevisions(ctx, int64(id), params)
+	if err != nil {
+		log.Println("get movie revisions err: ", err)
+		return
+	}
+
+	count, err := ms.MovieRepo.GetRevisionCount(ctx, int64(id))
+	if err != nil {
+		log.Println("get movie revision count err: ", err)
+		return
+	}
+
+	res = contract.GetListRevisionResponse{
+		Data:       stream.Map(stream.OfSlice(revisions), mapperRevisionResponse).ToSlice(),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	return
+}
+
+// Revert set editable field and genre of the movie back to the state after
+// the revision and record it as a new revision, deleted state is not changed
+// so soft deleted movie has to be restored first
+func (ms *MovieService) Revert(ctx context.Context, id, revision int) (res contract.MovieResponse, err error) {
+
+	target, err := ms.MovieRepo.GetRevision(ctx, int64(id), revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieRevisionNotFound
+		}
+		log.Println("get movie revision err: ", err)
+		return
+	}
+
+	err = ms.atomic(ctx, func(ctx context.Context) error {
+		before, err := ms.lockSnapshot(ctx, target.MovieID)
+		if err != nil {
+			return err
+		}
+
+		if before.Deleted {
+			log.Println("revert movie err: ", appErr.ErrMovieIdNotFound)
+			return appErr.ErrMovieIdNotFound
+		}
+
+		movie := entity.Movie{
+			ModelID: entity.ModelID{Id: target.MovieID},
+			MovieData: entity.MovieData{
+				Title:       target.After.Title,
+				Description: target.After.Description,
+				Rating:      target.After.Rating,
+				Image:       target.After.Image,
+				Runtime:     target.After.Runtime,
+			},
+		}
+
+		err = ms.MovieRepo.Update(ctx, &movie)
+		if err != nil {
+			log.Println("revert movie err: ", err)
+			return err
+		}
+
+		_, err = ms.replaceMovieGenres(ctx, target.MovieID, target.After.GenreIDs)
+		if err != nil {
+			return err
+		}
+
+		return ms.recordRevision(ctx, entity.RevisionActionRevert, target.MovieID, &before,
+			movieSnapshot(movie.MovieData, target.After.GenreIDs, false, movie.Version), &target.Revision)
+	})
+	if err != nil {
+		return
+	}
+
+	return ms.Get(ctx, id, contract.GetMovieParam{})
+}
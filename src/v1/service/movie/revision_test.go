@@ -0,0 +1,190 @@
+package movie
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/middleware/auth"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := entity.MovieSnapshot{Title: "old", Rating: 7, GenreIDs: []int64{1}}
+	after := entity.MovieSnapshot{Title: "new", Rating: 7, GenreIDs: []int64{1, 2}}
+
+	diff, err := entity.DiffSnapshots(&before, after)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RevisionDiff{
+		"title":     {From: []byte(`"old"`), To: []byte(`"new"`)},
+		"genre_ids": {From: []byte(`[1]`), To: []byte(`[1,2]`)},
+	}, diff)
+
+	diff, err = entity.DiffSnapshots(nil, after)
+	assert.NoError(t, err)
+	assert.Len(t, diff, 8)
+	assert.JSONEq(t, `null`, string(diff["title"].From))
+}
+
+func TestGetHistoryMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+
+	params := contract.GetListParam{Page: 1, Limit: 10}
+	revertOf := 1
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error movie id not found",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{Deleted: true}, nil).Times(1)
+				mockMovieRepo.EXPECT().GetRevisions(gomock.Any(), int64(1), params).Return([]*entity.MovieRevision{
+					{
+						MovieID:   1,
+						Revision:  2,
+						Action:    entity.RevisionActionRevert,
+						Before:    &entity.MovieSnapshot{Title: "new"},
+						After:     entity.MovieSnapshot{Title: "old"},
+						Diff:      entity.RevisionDiff{"title": {From: []byte(`"new"`), To: []byte(`"old"`)}},
+						RevertOf:  &revertOf,
+						CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+					{MovieID: 1, Revision: 1, Action: entity.RevisionActionCreate},
+				}, nil).Times(1)
+				mockMovieRepo.EXPECT().GetRevisionCount(gomock.Any(), int64(1)).Return(int64(2), nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitMovieService(mockMovieRepo, nil, nil, false).GetHistory(context.Background(), 1, params)
+			if err != tt.wantErr {
+				t.Errorf("Movie.GetHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.Len(t, got.Data, 2)
+				assert.Equal(t, "old", got.Data[0].After.Title)
+				assert.Equal(t, "new", got.Data[0].Before.Title)
+				assert.Equal(t, &revertOf, got.Data[0].RevertOf)
+				assert.Equal(t, "2024-01-02 03:04:05", got.Data[0].CreatedAt)
+				assert.Nil(t, got.Data[1].Before)
+			}
+		})
+	}
+}
+
+func TestRevertMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+	expectDeferCache(mockMovieRepo)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	target := entity.MovieRevision{
+		MovieID:  1,
+		Revision: 1,
+		After:    entity.MovieSnapshot{Title: "old", Rating: 6, GenreIDs: []int64{2}},
+	}
+	current := entity.MovieSnapshot{Title: "new", Rating: 6, GenreIDs: []int64{2}}
+
+	ctx := context.WithValue(context.Background(), auth.CtxKeyClaims, &auth.Claims{Subject: "user-1"})
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error revision not found",
+			wantErr: appErr.ErrMovieRevisionNotFound,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetRevision(gomock.Any(), int64(1), 1).Return(entity.MovieRevision{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error movie is deleted",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetRevision(gomock.Any(), int64(1), 1).Return(target, nil).Times(1)
+				expectAtomic(mockAtomic, mockSession, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(entity.MovieSnapshot{Deleted: true}, nil).Times(1)
+			},
+		},
+		{
+			name:    "error genre not found",
+			wantErr: appErr.ErrGenreIdNotFound,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetRevision(gomock.Any(), int64(1), 1).Return(target, nil).Times(1)
+				expectAtomic(mockAtomic, mockSession, false)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(current, nil).Times(1)
+				mockMovieRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockMovieRepo.EXPECT().ReplaceMovieGenres(gomock.Any(), int64(1), []int64{2}).Return(nil, nil).Times(1)
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().GetRevision(gomock.Any(), int64(1), 1).Return(target, nil).Times(1)
+				expectAtomic(mockAtomic, mockSession, true)
+				mockMovieRepo.EXPECT().LockSnapshot(gomock.Any(), int64(1)).Return(current, nil).Times(1)
+				mockMovieRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data *entity.Movie) error {
+						assert.Equal(t, "old", data.Title)
+						return nil
+					}).Times(1)
+				mockMovieRepo.EXPECT().ReplaceMovieGenres(gomock.Any(), int64(1), []int64{2}).Return([]string{"drama"}, nil).Times(1)
+				mockMovieRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data *entity.MovieRevision) error {
+						assert.Equal(t, entity.RevisionActionRevert, data.Action)
+						assert.Equal(t, "user-1", data.Actor)
+						assert.Equal(t, 1, *data.RevertOf)
+						assert.Equal(t, entity.RevisionDiff{
+							"title": {From: []byte(`"new"`), To: []byte(`"old"`)},
+						}, data.Diff)
+						return nil
+					}).Times(1)
+				mockMovieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			_, err := InitMovieService(mockMovieRepo, nil, mockAtomic, false).Revert(ctx, 1, 1)
+			if err != tt.wantErr {
+				t.Errorf("Movie.Revert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
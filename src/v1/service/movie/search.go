@@ -0,0 +1,47 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+)
+
+func (ms *MovieService) mapperSearchResponse(r *entity.MovieSearchResult) *contract.SearchMovieResponse {
+	return &contract.SearchMovieResponse{
+		MovieResponse: *ms.mapperMovieItemResponse(&r.Movie),
+		Rank:          r.Rank,
+		Highlight: contract.SearchHighlightResponse{
+			Title:       contract.FormatSearchHighlight(r.TitleHighlight),
+			Description: contract.FormatSearchHighlight(r.DescriptionHighlight),
+		},
+	}
+}
+
+// Search return movie matching the full text query ranked by ts_rank,
+// title weigh more than description
+func (ms *MovieService) Search(ctx context.Context, params contract.SearchParam) (res contract.GetListSearchResponse, err error) {
+
+	results, err := ms.MovieRepo.Search(ctx, params)
+	if err != nil {
+		log.Println("search movie err: ", err)
+		return
+	}
+
+	count, err := ms.MovieRepo.GetSearchCount(ctx, params)
+	if err != nil {
+		log.Println("get movie search count err: ", err)
+		return
+	}
+
+	res = contract.GetListSearchResponse{
+		Data:       stream.Map(stream.OfSlice(results), ms.mapperSearchResponse).ToSlice(),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	return
+}
@@ -0,0 +1,96 @@
+package movie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestSearchMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+
+	params := contract.SearchParam{Page: 1, Limit: 10, Query: "'setan'", Config: "indonesian"}
+
+	tests := []struct {
+		name     string
+		want     []*contract.SearchMovieResponse
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error search",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Search(gomock.Any(), params).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "error search count",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Search(gomock.Any(), params).Return([]*entity.MovieSearchResult{}, nil).Times(1)
+				mockMovieRepo.EXPECT().GetSearchCount(gomock.Any(), params).Return(int64(0), assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success escape highlight",
+			want: []*contract.SearchMovieResponse{
+				{
+					MovieResponse: contract.MovieResponse{
+						ID:          1,
+						Title:       "pengabdi setan",
+						Description: "<b>setan</b> & ibu",
+						CreatedAt:   "0001-01-01 00:00:00",
+						UpdatedAt:   "0001-01-01 00:00:00",
+					},
+					Rank: 0.6,
+					Highlight: contract.SearchHighlightResponse{
+						Title:       "pengabdi <mark>setan</mark>",
+						Description: "&lt;b&gt;<mark>setan</mark>&lt;/b&gt; &amp; ibu",
+					},
+				},
+			},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Search(gomock.Any(), params).Return([]*entity.MovieSearchResult{
+					{
+						Movie: entity.Movie{
+							ModelID:   entity.ModelID{Id: 1},
+							MovieData: entity.MovieData{Title: "pengabdi setan", Description: "<b>setan</b> & ibu"},
+						},
+						Rank:                 0.6,
+						TitleHighlight:       "pengabdi \x02setan\x03",
+						DescriptionHighlight: "<b>\x02setan\x03</b> & ibu",
+					},
+				}, nil).Times(1)
+				mockMovieRepo.EXPECT().GetSearchCount(gomock.Any(), params).Return(int64(1), nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitMovieService(mockMovieRepo, nil, nil, false).Search(context.Background(), params)
+			if err != tt.wantErr {
+				t.Errorf("Movie.Search() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, tt.want, got.Data)
+				assert.Equal(t, int64(1), got.Pagination.TotalData)
+			}
+		})
+	}
+}
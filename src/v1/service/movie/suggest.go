@@ -0,0 +1,37 @@
+package movie
+
+import (
+	"context"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+)
+
+func mapperSuggestResponse(s *entity.MovieSuggestion) *contract.SuggestResponse {
+	return &contract.SuggestResponse{
+		ID:     int(s.Id),
+		Title:  s.Title,
+		Image:  s.Image,
+		Rating: s.Rating,
+		Score:  s.Score,
+	}
+}
+
+// Suggest return at most params.Limit title for typeahead, matched by prefix or similar word
+func (ms *MovieService) Suggest(ctx context.Context, params contract.SuggestParam) (res []*contract.SuggestResponse, err error) {
+
+	suggestions, err := ms.MovieRepo.Suggest(ctx, params)
+	if err != nil {
+		log.Println("suggest movie err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(suggestions), mapperSuggestResponse).ToSlice()
+	if res == nil {
+		res = []*contract.SuggestResponse{}
+	}
+
+	return
+}
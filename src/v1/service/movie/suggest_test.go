@@ -0,0 +1,72 @@
+package movie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_movie "github.com/Risuii/movie/src/v1/service/mock/movie"
+)
+
+func TestSuggestMovieService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMovieRepo := mock_movie.NewMockMovieRepository(ctrl)
+
+	params := contract.SuggestParam{Query: "aven", Limit: 10}
+
+	tests := []struct {
+		name     string
+		want     []*contract.SuggestResponse
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error suggest",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Suggest(gomock.Any(), params).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success without match",
+			want: []*contract.SuggestResponse{},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Suggest(gomock.Any(), params).Return(nil, nil).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: []*contract.SuggestResponse{
+				{ID: 1, Title: "avengers", Rating: 9, Score: 0.92},
+				{ID: 4, Title: "the avengers", Image: "avengers.jpg", Rating: 7, Score: 0.41},
+			},
+			mockFunc: func() {
+				mockMovieRepo.EXPECT().Suggest(gomock.Any(), params).Return([]*entity.MovieSuggestion{
+					{ModelID: entity.ModelID{Id: 1}, Title: "avengers", Rating: 9, Score: 0.92},
+					{ModelID: entity.ModelID{Id: 4}, Title: "the avengers", Image: "avengers.jpg", Rating: 7, Score: 0.41},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitMovieService(mockMovieRepo, nil, nil, false).Suggest(context.Background(), params)
+			if err != tt.wantErr {
+				t.Errorf("Movie.Suggest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package person
+
+import (
+	"context"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+type PersonRepository interface {
+	Create(ctx context.Context, data *entity.Person) (entity.Person, error)
+	GetList(ctx context.Context, params contract.GetListParam) ([]*entity.Person, error)
+	GetPeopleCount(ctx context.Context, param contract.GetListParam) (int64, error)
+	Get(ctx context.Context, id int) (entity.Person, error)
+	GetCredits(ctx context.Context, id int64) ([]*entity.MovieCredit, error)
+	Update(ctx context.Context, data *entity.Person) error
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,172 @@
+package person
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+type PersonService struct {
+	PersonRepo PersonRepository
+}
+
+func InitPersonService(pRepo PersonRepository) *PersonService {
+	return &PersonService{
+		PersonRepo: pRepo,
+	}
+}
+
+func mapperPersonResponse(person *entity.Person) *contract.PersonResponse {
+	return &contract.PersonResponse{
+		ID:        int(person.Id),
+		Name:      person.Name,
+		Biography: person.Biography,
+		Image:     person.Image,
+		CreatedAt: person.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: person.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (ps *PersonService) getPerson(ctx context.Context, id int) (person entity.Person, err error) {
+	person, err = ps.PersonRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrPersonIdNotFound
+		}
+		log.Println("get person err: ", err)
+	}
+	return
+}
+
+func (ps *PersonService) Get(ctx context.Context, id int) (res contract.PersonResponse, err error) {
+
+	person, err := ps.getPerson(ctx, id)
+	if err != nil {
+		return
+	}
+
+	res = *mapperPersonResponse(&person)
+
+	return
+}
+
+func (ps *PersonService) GetList(ctx context.Context, params contract.GetListParam) (res contract.GetListPersonResponse, err error) {
+
+	people, err := ps.PersonRepo.GetList(ctx, params)
+	if err != nil {
+		log.Println("get list person err: ", err)
+		return
+	}
+
+	count, err := ps.PersonRepo.GetPeopleCount(ctx, params)
+	if err != nil {
+		log.Println("get count person err: ", err)
+		return
+	}
+
+	res = contract.GetListPersonResponse{
+		Data:       stream.Map(stream.OfSlice(people), mapperPersonResponse).ToSlice(),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	return
+}
+
+// GetMovies return every movie the person is credited on
+func (ps *PersonService) GetMovies(ctx context.Context, id int) (res []*contract.PersonMovieResponse, err error) {
+
+	person, err := ps.getPerson(ctx, id)
+	if err != nil {
+		return
+	}
+
+	credits, err := ps.PersonRepo.GetCredits(ctx, person.Id)
+	if err != nil {
+		log.Println("get person credits err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(credits), func(c *entity.MovieCredit) *contract.PersonMovieResponse {
+		return &contract.PersonMovieResponse{
+			MovieID:       c.MovieID,
+			MovieTitle:    c.MovieTitle,
+			Role:          c.Role,
+			CharacterName: c.CharacterName,
+			BillingOrder:  c.BillingOrder,
+		}
+	}).ToSlice()
+
+	return
+}
+
+func (ps *PersonService) Create(ctx context.Context, request contract.PersonRequest) (res contract.PersonResponse, err error) {
+
+	req := &entity.Person{
+		PersonData: entity.PersonData{
+			Name:      request.Name,
+			Biography: request.Biography,
+			Image:     request.Image,
+		},
+	}
+
+	person, err := ps.PersonRepo.Create(ctx, req)
+	if err != nil {
+		log.Println("create person err: ", err)
+		return
+	}
+
+	res = *mapperPersonResponse(&person)
+
+	return
+}
+
+func (ps *PersonService) Update(ctx context.Context, request contract.PersonRequest, id int) (res contract.PersonResponse, err error) {
+
+	person, err := ps.getPerson(ctx, id)
+	if err != nil {
+		return
+	}
+
+	person.PersonData = entity.PersonData{
+		Name:      request.Name,
+		Biography: request.Biography,
+		Image:     request.Image,
+	}
+
+	err = ps.PersonRepo.Update(ctx, &person)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrPersonIdNotFound
+		}
+		log.Println("update person err: ", err)
+		return
+	}
+
+	res = *mapperPersonResponse(&person)
+
+	return
+}
+
+func (ps *PersonService) Delete(ctx context.Context, id int) (err error) {
+
+	person, err := ps.getPerson(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = ps.PersonRepo.Delete(ctx, person.Id)
+	if err != nil {
+		log.Println("delete person err: ", err)
+		return
+	}
+
+	return
+}
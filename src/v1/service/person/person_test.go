@@ -0,0 +1,311 @@
+package person
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_person "github.com/Risuii/movie/src/v1/service/mock/person"
+)
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+
+}
+
+func TestGetPersonService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonRepo := mock_person.NewMockPersonRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		id       int
+		want     contract.PersonResponse
+		wantErr  error
+		mockFunc func(id int)
+	}{
+		{
+			name:    "error person id not found",
+			id:      1,
+			want:    contract.PersonResponse{},
+			wantErr: appErr.ErrPersonIdNotFound,
+			mockFunc: func(id int) {
+				mockPersonRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Person{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name: "success",
+			id:   1,
+			want: contract.PersonResponse{
+				ID:        1,
+				Name:      "Joko Anwar",
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+			},
+			mockFunc: func(id int) {
+				mockPersonRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Person{
+					ModelID:    entity.ModelID{Id: 1},
+					PersonData: entity.PersonData{Name: "Joko Anwar"},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.id)
+
+			p := InitPersonService(mockPersonRepo)
+			got, err := p.Get(context.Background(), tt.id)
+			if err != tt.wantErr {
+				t.Errorf("Person.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetListPersonService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonRepo := mock_person.NewMockPersonRepository(ctrl)
+
+	params := contract.GetListParam{
+		Page:    1,
+		Limit:   10,
+		Keyword: "joko",
+	}
+
+	tests := []struct {
+		name     string
+		want     contract.GetListPersonResponse
+		wantErr  bool
+		mockFunc func()
+	}{
+		{
+			name:    "error get list",
+			want:    contract.GetListPersonResponse{},
+			wantErr: true,
+			mockFunc: func() {
+				mockPersonRepo.EXPECT().GetList(gomock.Any(), params).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "error get count",
+			want:    contract.GetListPersonResponse{},
+			wantErr: true,
+			mockFunc: func() {
+				mockPersonRepo.EXPECT().GetList(gomock.Any(), params).Return([]*entity.Person{}, nil).Times(1)
+				mockPersonRepo.EXPECT().GetPeopleCount(gomock.Any(), params).Return(int64(0), assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.GetListPersonResponse{
+				Data: []*contract.PersonResponse{
+					{ID: 1, Name: "Joko Anwar", CreatedAt: "0001-01-01 00:00:00", UpdatedAt: "0001-01-01 00:00:00"},
+				},
+				Pagination: &frsUtils.Pagination{
+					Page:      1,
+					TotalPage: 1,
+					TotalData: 1,
+				},
+			},
+			wantErr: false,
+			mockFunc: func() {
+				mockPersonRepo.EXPECT().GetList(gomock.Any(), params).Return([]*entity.Person{
+					{ModelID: entity.ModelID{Id: 1}, PersonData: entity.PersonData{Name: "Joko Anwar"}},
+				}, nil).Times(1)
+				mockPersonRepo.EXPECT().GetPeopleCount(gomock.Any(), params).Return(int64(1), nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			p := InitPersonService(mockPersonRepo)
+			got, err := p.GetList(context.Background(), params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Person.GetList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetMoviesPersonService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonRepo := mock_person.NewMockPersonRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		id       int
+		want     []*contract.PersonMovieResponse
+		wantErr  error
+		mockFunc func(id int)
+	}{
+		{
+			name:    "error person id not found",
+			id:      1,
+			want:    nil,
+			wantErr: appErr.ErrPersonIdNotFound,
+			mockFunc: func(id int) {
+				mockPersonRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Person{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error get credits",
+			id:      1,
+			want:    nil,
+			wantErr: assert.AnError,
+			mockFunc: func(id int) {
+				mockPersonRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Person{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mockPersonRepo.EXPECT().GetCredits(gomock.Any(), int64(1)).Return(nil, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			id:   1,
+			want: []*contract.PersonMovieResponse{
+				{MovieID: 1, MovieTitle: "pengabdi setan", Role: entity.CreditRoleDirector},
+				{MovieID: 1, MovieTitle: "pengabdi setan", Role: entity.CreditRoleWriter},
+			},
+			mockFunc: func(id int) {
+				mockPersonRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Person{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mockPersonRepo.EXPECT().GetCredits(gomock.Any(), int64(1)).Return([]*entity.MovieCredit{
+					{MovieID: 1, PersonID: 1, MovieTitle: "pengabdi setan", Role: entity.CreditRoleDirector},
+					{MovieID: 1, PersonID: 1, MovieTitle: "pengabdi setan", Role: entity.CreditRoleWriter},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.id)
+
+			p := InitPersonService(mockPersonRepo)
+			got, err := p.GetMovies(context.Background(), tt.id)
+			if err != tt.wantErr {
+				t.Errorf("Person.GetMovies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreatePersonService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonRepo := mock_person.NewMockPersonRepository(ctrl)
+
+	request := contract.PersonRequest{Name: "Joko Anwar"}
+	params := &entity.Person{PersonData: entity.PersonData{Name: "Joko Anwar"}}
+
+	tests := []struct {
+		name     string
+		wantErr  bool
+		mockFunc func()
+	}{
+		{
+			name:    "error",
+			wantErr: true,
+			mockFunc: func() {
+				mockPersonRepo.EXPECT().Create(gomock.Any(), params).Return(entity.Person{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			wantErr: false,
+			mockFunc: func() {
+				mockPersonRepo.EXPECT().Create(gomock.Any(), params).Return(entity.Person{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			p := InitPersonService(mockPersonRepo)
+			_, err := p.Create(context.Background(), request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Person.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeletePersonService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPersonRepo := mock_person.NewMockPersonRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		id       int
+		wantErr  error
+		mockFunc func(id int)
+	}{
+		{
+			name:    "error id not found",
+			id:      1,
+			wantErr: appErr.ErrPersonIdNotFound,
+			mockFunc: func(id int) {
+				mockPersonRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Person{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			id:      1,
+			wantErr: nil,
+			mockFunc: func(id int) {
+				mockPersonRepo.EXPECT().Get(gomock.Any(), id).Return(entity.Person{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mockPersonRepo.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(tt.id)
+
+			p := InitPersonService(mockPersonRepo)
+			err := p.Delete(context.Background(), tt.id)
+			if err != tt.wantErr {
+				t.Errorf("Person.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package review
+
+import (
+	"context"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, data *entity.Review) (entity.Review, error)
+	GetList(ctx context.Context, movieID int64, params contract.GetListParam) ([]*entity.Review, error)
+	GetReviewCount(ctx context.Context, movieID int64) (int64, error)
+	Get(ctx context.Context, movieID, id int64) (entity.Review, error)
+	Update(ctx context.Context, data *entity.Review) error
+	Delete(ctx context.Context, id int64) error
+	LockMovie(ctx context.Context, movieID int64) error
+	RefreshMovieRating(ctx context.Context, movieID int64) (entity.MovieRating, error)
+	DeferCacheInvalidation(ctx context.Context) (context.Context, func())
+}
+
+type MovieRepository interface {
+	Get(ctx context.Context, id int) (entity.Movie, error)
+}
@@ -0,0 +1,225 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+type ReviewService struct {
+	ReviewRepo ReviewRepository
+	MovieRepo  MovieRepository
+	Atomic     frsAtomic.AtomicSessionProvider
+}
+
+func InitReviewService(rRepo ReviewRepository, mRepo MovieRepository, atomic frsAtomic.AtomicSessionProvider) *ReviewService {
+	return &ReviewService{
+		ReviewRepo: rRepo,
+		MovieRepo:  mRepo,
+		Atomic:     atomic,
+	}
+}
+
+func mapperReviewResponse(review *entity.Review) *contract.ReviewResponse {
+	return &contract.ReviewResponse{
+		ID:        int(review.Id),
+		MovieID:   review.MovieID,
+		UserID:    review.UserID,
+		Score:     review.Score,
+		Content:   review.Content,
+		CreatedAt: review.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: review.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (rs *ReviewService) getMovie(ctx context.Context, id int) (movie entity.Movie, err error) {
+	movie, err = rs.MovieRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("get movie err: ", err)
+	}
+	return
+}
+
+// getOwnReview return the review of the movie written by userID,
+// review of another user is reported as not found
+func (rs *ReviewService) getOwnReview(ctx context.Context, movieID int64, id int, userID string) (review entity.Review, err error) {
+	review, err = rs.ReviewRepo.Get(ctx, movieID, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrReviewIdNotFound
+		}
+		log.Println("get review err: ", err)
+		return
+	}
+
+	if review.UserID != userID {
+		err = appErr.ErrReviewIdNotFound
+		log.Println("review owner mismatch err: ", err)
+	}
+
+	return
+}
+
+// mutate run fn and recompute movie audience rating in one transaction,
+// movie row is locked first so concurrent review of the same movie is serialized.
+// Movie cache is invalidated after the transaction end so a read before the commit can not cache the old rating
+func (rs *ReviewService) mutate(ctx context.Context, movieID int64, fn func(ctx context.Context) error) error {
+	ctx, invalidateCache := rs.ReviewRepo.DeferCacheInvalidation(ctx)
+	defer invalidateCache()
+
+	return frsAtomic.Atomic(ctx, rs.Atomic, func(ctx context.Context) error {
+		err := rs.ReviewRepo.LockMovie(ctx, movieID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = appErr.ErrMovieIdNotFound
+			}
+			log.Println("lock movie err: ", err)
+			return err
+		}
+
+		if err = fn(ctx); err != nil {
+			return err
+		}
+
+		_, err = rs.ReviewRepo.RefreshMovieRating(ctx, movieID)
+		if err != nil {
+			log.Println("refresh movie rating err: ", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (rs *ReviewService) GetList(ctx context.Context, movieID int, params contract.GetListParam) (res contract.GetListReviewResponse, err error) {
+
+	movie, err := rs.getMovie(ctx, movieID)
+	if err != nil {
+		return
+	}
+
+	reviews, err := rs.ReviewRepo.GetList(ctx, movie.Id, params)
+	if err != nil {
+		log.Println("get list review err: ", err)
+		return
+	}
+
+	count, err := rs.ReviewRepo.GetReviewCount(ctx, movie.Id)
+	if err != nil {
+		log.Println("get count review err: ", err)
+		return
+	}
+
+	res = contract.GetListReviewResponse{
+		Data:       stream.Map(stream.OfSlice(reviews), mapperReviewResponse).ToSlice(),
+		Pagination: frsUtils.GetPaginationData(params.Page, params.Limit, int(count)),
+	}
+
+	return
+}
+
+func (rs *ReviewService) Create(ctx context.Context, request contract.ReviewRequest, movieID int) (res contract.ReviewResponse, err error) {
+
+	movie, err := rs.getMovie(ctx, movieID)
+	if err != nil {
+		return
+	}
+
+	req := &entity.Review{
+		ReviewData: entity.ReviewData{
+			MovieID: movie.Id,
+			UserID:  request.UserID,
+			Score:   request.Score,
+			Content: request.Content,
+		},
+	}
+
+	var review entity.Review
+
+	err = rs.mutate(ctx, movie.Id, func(ctx context.Context) error {
+		var err error
+		review, err = rs.ReviewRepo.Create(ctx, req)
+		if err != nil {
+			log.Println("create review err: ", err)
+		}
+		return err
+	})
+	if err != nil {
+		return
+	}
+
+	res = *mapperReviewResponse(&review)
+
+	return
+}
+
+func (rs *ReviewService) Update(ctx context.Context, request contract.ReviewRequest, movieID, id int) (res contract.ReviewResponse, err error) {
+
+	movie, err := rs.getMovie(ctx, movieID)
+	if err != nil {
+		return
+	}
+
+	review, err := rs.getOwnReview(ctx, movie.Id, id, request.UserID)
+	if err != nil {
+		return
+	}
+
+	review.Score = request.Score
+	review.Content = request.Content
+
+	err = rs.mutate(ctx, movie.Id, func(ctx context.Context) error {
+		err := rs.ReviewRepo.Update(ctx, &review)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = appErr.ErrReviewIdNotFound
+			}
+			log.Println("update review err: ", err)
+		}
+		return err
+	})
+	if err != nil {
+		return
+	}
+
+	review.UpdatedAt = time.Now()
+	res = *mapperReviewResponse(&review)
+
+	return
+}
+
+func (rs *ReviewService) Delete(ctx context.Context, movieID, id int, userID string) (err error) {
+
+	movie, err := rs.getMovie(ctx, movieID)
+	if err != nil {
+		return
+	}
+
+	review, err := rs.getOwnReview(ctx, movie.Id, id, userID)
+	if err != nil {
+		return
+	}
+
+	err = rs.mutate(ctx, movie.Id, func(ctx context.Context) error {
+		err := rs.ReviewRepo.Delete(ctx, review.Id)
+		if err != nil {
+			log.Println("delete review err: ", err)
+		}
+		return err
+	})
+
+	return
+}
@@ -0,0 +1,405 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	frsUtils "github.com/Risuii/frs-lib/utils"
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_review "github.com/Risuii/movie/src/v1/service/mock/review"
+)
+
+// expectAtomic expect one transaction that is committed or rolled back
+func expectAtomic(mock *mock_atomic.MockAtomicSessionProvider, session *mock_atomic.MockAtomicSession, commit bool) {
+	mock.EXPECT().BeginSession(gomock.Any()).DoAndReturn(func(ctx context.Context) (*frsAtomic.AtomicSessionContext, error) {
+		return frsAtomic.NewAtomicSessionContext(ctx, session), nil
+	}).Times(1)
+
+	if commit {
+		session.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+	} else {
+		session.EXPECT().Rollback(gomock.Any()).Return(nil).Times(1)
+	}
+}
+
+// expectDeferCache pass the context through, the repository mark the cache stale and the
+// returned func is the one that delete it
+func expectDeferCache(mock *mock_review.MockReviewRepository) {
+	mock.EXPECT().DeferCacheInvalidation(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, func()) {
+		return ctx, func() {}
+	}).AnyTimes()
+}
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+
+}
+
+type mockFields struct {
+	reviewRepo *mock_review.MockReviewRepository
+	movieRepo  *mock_review.MockMovieRepository
+	atomic     *mock_atomic.MockAtomicSessionProvider
+	session    *mock_atomic.MockAtomicSession
+}
+
+func newMockFields(ctrl *gomock.Controller) mockFields {
+	return mockFields{
+		reviewRepo: mock_review.NewMockReviewRepository(ctrl),
+		movieRepo:  mock_review.NewMockMovieRepository(ctrl),
+		atomic:     mock_atomic.NewMockAtomicSessionProvider(ctrl),
+		session:    mock_atomic.NewMockAtomicSession(ctrl),
+	}
+}
+
+var mockMovie = entity.Movie{ModelID: entity.ModelID{Id: 1}}
+
+func TestGetListReviewService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	params := contract.GetListParam{Page: 1, Limit: 10}
+
+	tests := []struct {
+		name     string
+		want     contract.GetListReviewResponse
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error movie id not found",
+			want:    contract.GetListReviewResponse{},
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error get count",
+			want:    contract.GetListReviewResponse{},
+			wantErr: assert.AnError,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				mock.reviewRepo.EXPECT().GetList(gomock.Any(), int64(1), params).Return([]*entity.Review{}, nil).Times(1)
+				mock.reviewRepo.EXPECT().GetReviewCount(gomock.Any(), int64(1)).Return(int64(0), assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.GetListReviewResponse{
+				Data: []*contract.ReviewResponse{
+					{ID: 1, MovieID: 1, UserID: "user-1", Score: 8, CreatedAt: "0001-01-01 00:00:00", UpdatedAt: "0001-01-01 00:00:00"},
+				},
+				Pagination: &frsUtils.Pagination{
+					Page:      1,
+					TotalPage: 1,
+					TotalData: 1,
+				},
+			},
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				mock.reviewRepo.EXPECT().GetList(gomock.Any(), int64(1), params).Return([]*entity.Review{
+					{ModelID: entity.ModelID{Id: 1}, ReviewData: entity.ReviewData{MovieID: 1, UserID: "user-1", Score: 8}},
+				}, nil).Times(1)
+				mock.reviewRepo.EXPECT().GetReviewCount(gomock.Any(), int64(1)).Return(int64(1), nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			r := InitReviewService(mocks.reviewRepo, mocks.movieRepo, mocks.atomic)
+			got, err := r.GetList(context.Background(), 1, params)
+			if err != tt.wantErr {
+				t.Errorf("Review.GetList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateReviewService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	request := contract.ReviewRequest{UserID: "user-1", Score: 8, Content: "scary"}
+	params := &entity.Review{ReviewData: entity.ReviewData{MovieID: 1, UserID: "user-1", Score: 8, Content: "scary"}}
+
+	tests := []struct {
+		name     string
+		want     contract.ReviewResponse
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error movie id not found",
+			want:    contract.ReviewResponse{},
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error movie deleted before lock",
+			want:    contract.ReviewResponse{},
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				expectDeferCache(mock.reviewRepo)
+				expectAtomic(mock.atomic, mock.session, false)
+				mock.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error duplicate review",
+			want:    contract.ReviewResponse{},
+			wantErr: appErr.ErrDuplicateReview,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				expectDeferCache(mock.reviewRepo)
+				expectAtomic(mock.atomic, mock.session, false)
+				mock.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().Create(gomock.Any(), params).Return(entity.Review{}, appErr.ErrDuplicateReview).Times(1)
+			},
+		},
+		{
+			name:    "error refresh rating roll back review",
+			want:    contract.ReviewResponse{},
+			wantErr: assert.AnError,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				expectDeferCache(mock.reviewRepo)
+				expectAtomic(mock.atomic, mock.session, false)
+				mock.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().Create(gomock.Any(), params).Return(entity.Review{}, nil).Times(1)
+				mock.reviewRepo.EXPECT().RefreshMovieRating(gomock.Any(), int64(1)).Return(entity.MovieRating{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.ReviewResponse{
+				ID:        1,
+				MovieID:   1,
+				UserID:    "user-1",
+				Score:     8,
+				Content:   "scary",
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+			},
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				expectDeferCache(mock.reviewRepo)
+				expectAtomic(mock.atomic, mock.session, true)
+				mock.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().Create(gomock.Any(), params).Return(entity.Review{
+					ModelID:    entity.ModelID{Id: 1},
+					ReviewData: params.ReviewData,
+				}, nil).Times(1)
+				mock.reviewRepo.EXPECT().RefreshMovieRating(gomock.Any(), int64(1)).Return(entity.MovieRating{AverageRating: 8, RatingCount: 1}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			r := InitReviewService(mocks.reviewRepo, mocks.movieRepo, mocks.atomic)
+			got, err := r.Create(context.Background(), request, 1)
+			if err != tt.wantErr {
+				t.Errorf("Review.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateReviewService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	request := contract.ReviewRequest{UserID: "user-1", Score: 4, Content: "not that scary"}
+	review := entity.Review{ModelID: entity.ModelID{Id: 2}, ReviewData: entity.ReviewData{MovieID: 1, UserID: "user-1", Score: 8}}
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error review id not found",
+			wantErr: appErr.ErrReviewIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				mock.reviewRepo.EXPECT().Get(gomock.Any(), int64(1), int64(2)).Return(entity.Review{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error review of another user",
+			wantErr: appErr.ErrReviewIdNotFound,
+			mockFunc: func(mock mockFields) {
+				other := review
+				other.UserID = "user-2"
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				mock.reviewRepo.EXPECT().Get(gomock.Any(), int64(1), int64(2)).Return(other, nil).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			wantErr: nil,
+			mockFunc: func(mock mockFields) {
+				updated := review
+				updated.Score = 4
+				updated.Content = "not that scary"
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				mock.reviewRepo.EXPECT().Get(gomock.Any(), int64(1), int64(2)).Return(review, nil).Times(1)
+				expectDeferCache(mock.reviewRepo)
+				expectAtomic(mock.atomic, mock.session, true)
+				mock.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().Update(gomock.Any(), &updated).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().RefreshMovieRating(gomock.Any(), int64(1)).Return(entity.MovieRating{AverageRating: 4, RatingCount: 1}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			r := InitReviewService(mocks.reviewRepo, mocks.movieRepo, mocks.atomic)
+			got, err := r.Update(context.Background(), request, 1, 2)
+			if err != tt.wantErr {
+				t.Errorf("Review.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, 4, got.Score)
+				assert.Equal(t, "not that scary", got.Content)
+			}
+		})
+	}
+}
+
+func TestDeleteReviewService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	review := entity.Review{ModelID: entity.ModelID{Id: 2}, ReviewData: entity.ReviewData{MovieID: 1, UserID: "user-1", Score: 8}}
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error movie id not found",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error delete",
+			wantErr: assert.AnError,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				mock.reviewRepo.EXPECT().Get(gomock.Any(), int64(1), int64(2)).Return(review, nil).Times(1)
+				expectDeferCache(mock.reviewRepo)
+				expectAtomic(mock.atomic, mock.session, false)
+				mock.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().Delete(gomock.Any(), int64(2)).Return(assert.AnError).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			wantErr: nil,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+				mock.reviewRepo.EXPECT().Get(gomock.Any(), int64(1), int64(2)).Return(review, nil).Times(1)
+				expectDeferCache(mock.reviewRepo)
+				expectAtomic(mock.atomic, mock.session, true)
+				mock.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().Delete(gomock.Any(), int64(2)).Return(nil).Times(1)
+				mock.reviewRepo.EXPECT().RefreshMovieRating(gomock.Any(), int64(1)).Return(entity.MovieRating{}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			r := InitReviewService(mocks.reviewRepo, mocks.movieRepo, mocks.atomic)
+			err := r.Delete(context.Background(), 1, 2, "user-1")
+			if err != tt.wantErr {
+				t.Errorf("Review.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteReviewServiceInvalidateCacheAfterCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	var committed, invalidated bool
+	mocks.reviewRepo.EXPECT().DeferCacheInvalidation(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, func()) {
+		return ctx, func() {
+			assert.True(t, committed, "cache is invalidated before the commit")
+			invalidated = true
+		}
+	}).Times(1)
+	mocks.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(mockMovie, nil).Times(1)
+	mocks.reviewRepo.EXPECT().Get(gomock.Any(), int64(1), int64(2)).
+		Return(entity.Review{ModelID: entity.ModelID{Id: 2}, ReviewData: entity.ReviewData{MovieID: 1, UserID: "user-1"}}, nil).Times(1)
+	mocks.atomic.EXPECT().BeginSession(gomock.Any()).DoAndReturn(func(ctx context.Context) (*frsAtomic.AtomicSessionContext, error) {
+		return frsAtomic.NewAtomicSessionContext(ctx, mocks.session), nil
+	}).Times(1)
+	mocks.session.EXPECT().Commit(gomock.Any()).DoAndReturn(func(context.Context) error {
+		assert.False(t, invalidated)
+		committed = true
+		return nil
+	}).Times(1)
+	mocks.reviewRepo.EXPECT().LockMovie(gomock.Any(), int64(1)).Return(nil).Times(1)
+	mocks.reviewRepo.EXPECT().Delete(gomock.Any(), int64(2)).Return(nil).Times(1)
+	mocks.reviewRepo.EXPECT().RefreshMovieRating(gomock.Any(), int64(1)).Return(entity.MovieRating{}, nil).Times(1)
+
+	r := InitReviewService(mocks.reviewRepo, mocks.movieRepo, mocks.atomic)
+	err := r.Delete(context.Background(), 1, 2, "user-1")
+
+	assert.NoError(t, err)
+	assert.True(t, invalidated)
+}
@@ -0,0 +1,17 @@
+package role
+
+import (
+	"context"
+
+	"github.com/Risuii/movie/src/entity"
+)
+
+type RoleRepository interface {
+	Create(ctx context.Context, data *entity.Role) (entity.Role, error)
+	GetList(ctx context.Context) ([]*entity.Role, error)
+	Get(ctx context.Context, name string) (entity.Role, error)
+	GetPermissions(ctx context.Context, roles []string) ([]string, error)
+	Update(ctx context.Context, data *entity.Role) error
+	ReplacePermissions(ctx context.Context, name string, permissions []string) error
+	Delete(ctx context.Context, name string) error
+}
@@ -0,0 +1,193 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+type RoleService struct {
+	RoleRepo RoleRepository
+	Atomic   frsAtomic.AtomicSessionProvider
+}
+
+func InitRoleService(rRepo RoleRepository, atomic frsAtomic.AtomicSessionProvider) *RoleService {
+	return &RoleService{
+		RoleRepo: rRepo,
+		Atomic:   atomic,
+	}
+}
+
+func mapperRoleResponse(role *entity.Role) contract.RoleResponse {
+	permissions := []string(role.Permissions)
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return contract.RoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		BuiltIn:     role.BuiltIn,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   role.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (rs *RoleService) Get(ctx context.Context, name string) (res contract.RoleResponse, err error) {
+
+	role, err := rs.RoleRepo.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrRoleNotFound
+		}
+		log.Println("get role err: ", err)
+		return
+	}
+
+	res = mapperRoleResponse(&role)
+
+	return
+}
+
+func (rs *RoleService) GetList(ctx context.Context) (res []contract.RoleResponse, err error) {
+
+	roles, err := rs.RoleRepo.GetList(ctx)
+	if err != nil {
+		log.Println("get list role err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(roles), func(r *entity.Role) contract.RoleResponse {
+		return mapperRoleResponse(r)
+	}).ToSlice()
+
+	return
+}
+
+// GetPermissions return permission granted to any of the roles, it is used by the Authorize middleware
+func (rs *RoleService) GetPermissions(ctx context.Context, roles []string) (permissions []string, err error) {
+
+	permissions, err = rs.RoleRepo.GetPermissions(ctx, roles)
+	if err != nil {
+		log.Println("get role permissions err: ", err)
+		return
+	}
+
+	return
+}
+
+func (rs *RoleService) Create(ctx context.Context, request contract.RoleRequest) (res contract.RoleResponse, err error) {
+
+	var role entity.Role
+	err = frsAtomic.Atomic(ctx, rs.Atomic, func(ctx context.Context) error {
+		created, err := rs.RoleRepo.Create(ctx, &entity.Role{
+			Name:        request.Name,
+			Description: request.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		role = created
+		return rs.RoleRepo.ReplacePermissions(ctx, role.Name, request.Permissions)
+	})
+	if err != nil {
+		log.Println("create role err: ", err)
+		return
+	}
+
+	role.Permissions = request.Permissions
+	res = mapperRoleResponse(&role)
+
+	return
+}
+
+// Update replace description and permissions of the role, admin must keep
+// role:manage so the role endpoints can not lock every admin out
+func (rs *RoleService) Update(ctx context.Context, request contract.RoleRequest) (res contract.RoleResponse, err error) {
+
+	if request.Name == entity.RoleAdmin && !hasPermission(request.Permissions, entity.PermissionRoleManage) {
+		err = appErr.ErrRoleAdminLockout
+		log.Println("update role err: ", err)
+		return
+	}
+
+	role, err := rs.RoleRepo.Get(ctx, request.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrRoleNotFound
+		}
+		log.Println("find role err: ", err)
+		return
+	}
+
+	role.Description = request.Description
+
+	err = frsAtomic.Atomic(ctx, rs.Atomic, func(ctx context.Context) error {
+		if err := rs.RoleRepo.Update(ctx, &role); err != nil {
+			return err
+		}
+
+		return rs.RoleRepo.ReplacePermissions(ctx, role.Name, request.Permissions)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrRoleNotFound
+		}
+		log.Println("update role err: ", err)
+		return
+	}
+
+	role.Permissions = request.Permissions
+	res = mapperRoleResponse(&role)
+
+	return
+}
+
+// Delete remove a custom role, built in role is kept because token use its name
+func (rs *RoleService) Delete(ctx context.Context, name string) (err error) {
+
+	role, err := rs.RoleRepo.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrRoleNotFound
+		}
+		log.Println("get role err: ", err)
+		return
+	}
+
+	if role.BuiltIn {
+		err = appErr.ErrRoleBuiltIn
+		log.Println("delete role err: ", err)
+		return
+	}
+
+	err = rs.RoleRepo.Delete(ctx, role.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrRoleNotFound
+		}
+		log.Println("delete role err: ", err)
+		return
+	}
+
+	return
+}
+
+func hasPermission(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
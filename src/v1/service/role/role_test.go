@@ -0,0 +1,260 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	frsAtomic "github.com/Risuii/frs-lib/atomic"
+	mock_atomic "github.com/Risuii/frs-lib/atomic/mock"
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_role "github.com/Risuii/movie/src/v1/service/mock/role"
+)
+
+func expectAtomic(mock *mock_atomic.MockAtomicSessionProvider, session *mock_atomic.MockAtomicSession, commit bool) {
+	mock.EXPECT().BeginSession(gomock.Any()).DoAndReturn(func(ctx context.Context) (*frsAtomic.AtomicSessionContext, error) {
+		return frsAtomic.NewAtomicSessionContext(ctx, session), nil
+	}).Times(1)
+
+	if commit {
+		session.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+	} else {
+		session.EXPECT().Rollback(gomock.Any()).Return(nil).Times(1)
+	}
+}
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+}
+
+func TestGetRoleService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mock_role.NewMockRoleRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		want     contract.RoleResponse
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error role not found",
+			wantErr: appErr.ErrRoleNotFound,
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), "editor").Return(entity.Role{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error get role",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), "editor").Return(entity.Role{}, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.RoleResponse{
+				Name:        "editor",
+				BuiltIn:     true,
+				Permissions: []string{entity.PermissionMovieCreate, entity.PermissionMovieUpdate},
+				CreatedAt:   "0001-01-01 00:00:00",
+				UpdatedAt:   "0001-01-01 00:00:00",
+			},
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), "editor").Return(entity.Role{
+					Name:        "editor",
+					BuiltIn:     true,
+					Permissions: []string{entity.PermissionMovieCreate, entity.PermissionMovieUpdate},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitRoleService(mockRoleRepo, nil).Get(context.Background(), "editor")
+			if err != tt.wantErr {
+				t.Errorf("Role.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateRoleService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mock_role.NewMockRoleRepository(ctrl)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	request := contract.RoleRequest{Name: "curator", Permissions: []string{entity.PermissionMovieUpdate}}
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error duplicate role",
+			wantErr: appErr.ErrDuplicateRole,
+			mockFunc: func() {
+				expectAtomic(mockAtomic, mockSession, false)
+				mockRoleRepo.EXPECT().Create(gomock.Any(), &entity.Role{Name: "curator"}).Return(entity.Role{}, appErr.ErrDuplicateRole).Times(1)
+			},
+		},
+		{
+			name:    "error replace permissions",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				expectAtomic(mockAtomic, mockSession, false)
+				mockRoleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.Role{Name: "curator"}, nil).Times(1)
+				mockRoleRepo.EXPECT().ReplacePermissions(gomock.Any(), "curator", request.Permissions).Return(assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				expectAtomic(mockAtomic, mockSession, true)
+				mockRoleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.Role{Name: "curator"}, nil).Times(1)
+				mockRoleRepo.EXPECT().ReplacePermissions(gomock.Any(), "curator", request.Permissions).Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := InitRoleService(mockRoleRepo, mockAtomic).Create(context.Background(), request)
+			if err != tt.wantErr {
+				t.Errorf("Role.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, request.Permissions, got.Permissions)
+			}
+		})
+	}
+}
+
+func TestUpdateRoleService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mock_role.NewMockRoleRepository(ctrl)
+	mockAtomic := mock_atomic.NewMockAtomicSessionProvider(ctrl)
+	mockSession := mock_atomic.NewMockAtomicSession(ctrl)
+
+	tests := []struct {
+		name     string
+		request  contract.RoleRequest
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:     "error admin without role manage",
+			request:  contract.RoleRequest{Name: entity.RoleAdmin, Permissions: []string{entity.PermissionMovieCreate}},
+			wantErr:  appErr.ErrRoleAdminLockout,
+			mockFunc: func() {},
+		},
+		{
+			name:    "error role not found",
+			request: contract.RoleRequest{Name: "curator"},
+			wantErr: appErr.ErrRoleNotFound,
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), "curator").Return(entity.Role{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			request: contract.RoleRequest{Name: entity.RoleEditor, Description: "edit", Permissions: []string{entity.PermissionMovieUpdate}},
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), entity.RoleEditor).Return(entity.Role{Name: entity.RoleEditor, BuiltIn: true}, nil).Times(1)
+				expectAtomic(mockAtomic, mockSession, true)
+				mockRoleRepo.EXPECT().Update(gomock.Any(), &entity.Role{Name: entity.RoleEditor, Description: "edit", BuiltIn: true}).Return(nil).Times(1)
+				mockRoleRepo.EXPECT().ReplacePermissions(gomock.Any(), entity.RoleEditor, []string{entity.PermissionMovieUpdate}).Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			_, err := InitRoleService(mockRoleRepo, mockAtomic).Update(context.Background(), tt.request)
+			if err != tt.wantErr {
+				t.Errorf("Role.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteRoleService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mock_role.NewMockRoleRepository(ctrl)
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func()
+	}{
+		{
+			name:    "error built in role",
+			wantErr: appErr.ErrRoleBuiltIn,
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), "curator").Return(entity.Role{Name: "curator", BuiltIn: true}, nil).Times(1)
+			},
+		},
+		{
+			name:    "error delete role",
+			wantErr: assert.AnError,
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), "curator").Return(entity.Role{Name: "curator"}, nil).Times(1)
+				mockRoleRepo.EXPECT().Delete(gomock.Any(), "curator").Return(assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				mockRoleRepo.EXPECT().Get(gomock.Any(), "curator").Return(entity.Role{Name: "curator"}, nil).Times(1)
+				mockRoleRepo.EXPECT().Delete(gomock.Any(), "curator").Return(nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			err := InitRoleService(mockRoleRepo, nil).Delete(context.Background(), "curator")
+			if err != tt.wantErr {
+				t.Errorf("Role.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
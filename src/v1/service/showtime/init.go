@@ -0,0 +1,26 @@
+package showtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+)
+
+type ShowtimeRepository interface {
+	Create(ctx context.Context, data *entity.Showtime) (int64, error)
+	Get(ctx context.Context, id int64) (entity.Showtime, error)
+	GetByMovie(ctx context.Context, movieID int64, from, to time.Time, city string) ([]*entity.Showtime, error)
+	GetByCinema(ctx context.Context, cinemaID int64, from, to time.Time) ([]*entity.Showtime, error)
+	Update(ctx context.Context, data *entity.Showtime) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type MovieRepository interface {
+	Get(ctx context.Context, id int) (entity.Movie, error)
+}
+
+type CinemaRepository interface {
+	Get(ctx context.Context, id int) (entity.Cinema, error)
+	GetScreen(ctx context.Context, id int) (entity.Screen, error)
+}
@@ -0,0 +1,220 @@
+package showtime
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+	"github.com/mariomac/gostream/stream"
+
+	appErr "github.com/Risuii/movie/src/errors"
+)
+
+type ShowtimeService struct {
+	ShowtimeRepo ShowtimeRepository
+	MovieRepo    MovieRepository
+	CinemaRepo   CinemaRepository
+}
+
+func InitShowtimeService(sRepo ShowtimeRepository, mRepo MovieRepository, cRepo CinemaRepository) *ShowtimeService {
+	return &ShowtimeService{
+		ShowtimeRepo: sRepo,
+		MovieRepo:    mRepo,
+		CinemaRepo:   cRepo,
+	}
+}
+
+// mapperShowtimeResponse format time with offset because showtime
+// is read by client in other time zone
+func mapperShowtimeResponse(showtime *entity.Showtime) *contract.ShowtimeResponse {
+	return &contract.ShowtimeResponse{
+		ID:         int(showtime.Id),
+		MovieID:    showtime.MovieID,
+		MovieTitle: showtime.MovieTitle,
+		ScreenID:   showtime.ScreenID,
+		ScreenName: showtime.ScreenName,
+		CinemaID:   showtime.CinemaID,
+		CinemaName: showtime.CinemaName,
+		City:       showtime.City,
+		StartTime:  showtime.StartTime.Local().Format(time.RFC3339),
+		EndTime:    showtime.EndTime.Local().Format(time.RFC3339),
+		Price:      showtime.Price,
+	}
+}
+
+func (ss *ShowtimeService) getShowtime(ctx context.Context, id int) (showtime entity.Showtime, err error) {
+	showtime, err = ss.ShowtimeRepo.Get(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrShowtimeIdNotFound
+		}
+		log.Println("get showtime err: ", err)
+	}
+	return
+}
+
+func (ss *ShowtimeService) getMovie(ctx context.Context, id int) (movie entity.Movie, err error) {
+	movie, err = ss.MovieRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrMovieIdNotFound
+		}
+		log.Println("get movie err: ", err)
+	}
+	return
+}
+
+func (ss *ShowtimeService) getCinema(ctx context.Context, id int) (cinema entity.Cinema, err error) {
+	cinema, err = ss.CinemaRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrCinemaIdNotFound
+		}
+		log.Println("get cinema err: ", err)
+	}
+	return
+}
+
+// buildShowtime validate movie and screen of the request,
+// end time is start time plus movie runtime
+func (ss *ShowtimeService) buildShowtime(ctx context.Context, request contract.ShowtimeRequest) (data entity.ShowtimeData, err error) {
+	movie, err := ss.getMovie(ctx, int(request.MovieID))
+	if err != nil {
+		return
+	}
+
+	if movie.Runtime <= 0 {
+		err = appErr.ErrMovieRuntimeNotSet
+		log.Println("build showtime err: ", err)
+		return
+	}
+
+	screen, err := ss.CinemaRepo.GetScreen(ctx, int(request.ScreenID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrScreenIdNotFound
+		}
+		log.Println("get screen err: ", err)
+		return
+	}
+
+	data = entity.ShowtimeData{
+		MovieID:   movie.Id,
+		ScreenID:  screen.Id,
+		StartTime: request.StartTime,
+		EndTime:   request.StartTime.Add(time.Duration(movie.Runtime) * time.Minute),
+		Price:     request.Price,
+	}
+
+	return
+}
+
+func (ss *ShowtimeService) Get(ctx context.Context, id int) (res contract.ShowtimeResponse, err error) {
+
+	showtime, err := ss.getShowtime(ctx, id)
+	if err != nil {
+		return
+	}
+
+	res = *mapperShowtimeResponse(&showtime)
+
+	return
+}
+
+// GetByMovie return showtime of the movie on filter date, optionally in one city
+func (ss *ShowtimeService) GetByMovie(ctx context.Context, movieID int, filter contract.ShowtimeFilter) (res []*contract.ShowtimeResponse, err error) {
+
+	movie, err := ss.getMovie(ctx, movieID)
+	if err != nil {
+		return
+	}
+
+	showtimes, err := ss.ShowtimeRepo.GetByMovie(ctx, movie.Id, filter.From, filter.To, filter.City)
+	if err != nil {
+		log.Println("get showtime by movie err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(showtimes), mapperShowtimeResponse).ToSlice()
+
+	return
+}
+
+// GetSchedule return showtime on every screen of the cinema on filter date
+func (ss *ShowtimeService) GetSchedule(ctx context.Context, cinemaID int, filter contract.ShowtimeFilter) (res []*contract.ShowtimeResponse, err error) {
+
+	cinema, err := ss.getCinema(ctx, cinemaID)
+	if err != nil {
+		return
+	}
+
+	showtimes, err := ss.ShowtimeRepo.GetByCinema(ctx, cinema.Id, filter.From, filter.To)
+	if err != nil {
+		log.Println("get showtime by cinema err: ", err)
+		return
+	}
+
+	res = stream.Map(stream.OfSlice(showtimes), mapperShowtimeResponse).ToSlice()
+
+	return
+}
+
+func (ss *ShowtimeService) Create(ctx context.Context, request contract.ShowtimeRequest) (res contract.ShowtimeResponse, err error) {
+
+	data, err := ss.buildShowtime(ctx, request)
+	if err != nil {
+		return
+	}
+
+	id, err := ss.ShowtimeRepo.Create(ctx, &entity.Showtime{ShowtimeData: data})
+	if err != nil {
+		log.Println("create showtime err: ", err)
+		return
+	}
+
+	return ss.Get(ctx, int(id))
+}
+
+func (ss *ShowtimeService) Update(ctx context.Context, request contract.ShowtimeRequest, id int) (res contract.ShowtimeResponse, err error) {
+
+	showtime, err := ss.getShowtime(ctx, id)
+	if err != nil {
+		return
+	}
+
+	showtime.ShowtimeData, err = ss.buildShowtime(ctx, request)
+	if err != nil {
+		return
+	}
+
+	err = ss.ShowtimeRepo.Update(ctx, &showtime)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = appErr.ErrShowtimeIdNotFound
+		}
+		log.Println("update showtime err: ", err)
+		return
+	}
+
+	return ss.Get(ctx, id)
+}
+
+func (ss *ShowtimeService) Delete(ctx context.Context, id int) (err error) {
+
+	showtime, err := ss.getShowtime(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = ss.ShowtimeRepo.Delete(ctx, showtime.Id)
+	if err != nil {
+		log.Println("delete showtime err: ", err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,255 @@
+package showtime
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Risuii/movie/src/app"
+	"github.com/Risuii/movie/src/entity"
+	"github.com/Risuii/movie/src/v1/contract"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	appErr "github.com/Risuii/movie/src/errors"
+	mock_showtime "github.com/Risuii/movie/src/v1/service/mock/showtime"
+)
+
+func TestMain(m *testing.M) {
+	os.Chdir("../../../../")
+
+	app.Init(context.Background())
+
+	exitVal := m.Run()
+
+	os.Exit(exitVal)
+
+}
+
+type mockFields struct {
+	showtimeRepo *mock_showtime.MockShowtimeRepository
+	movieRepo    *mock_showtime.MockMovieRepository
+	cinemaRepo   *mock_showtime.MockCinemaRepository
+}
+
+func newMockFields(ctrl *gomock.Controller) mockFields {
+	return mockFields{
+		showtimeRepo: mock_showtime.NewMockShowtimeRepository(ctrl),
+		movieRepo:    mock_showtime.NewMockMovieRepository(ctrl),
+		cinemaRepo:   mock_showtime.NewMockCinemaRepository(ctrl),
+	}
+}
+
+func TestCreateShowtimeService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	start := time.Date(2024, 1, 1, 19, 0, 0, 0, time.Local)
+	request := contract.ShowtimeRequest{MovieID: 1, ScreenID: 2, StartTime: start, Price: 50000}
+	movie := entity.Movie{ModelID: entity.ModelID{Id: 1}, MovieData: entity.MovieData{Runtime: 117}}
+	screen := entity.Screen{ModelID: entity.ModelID{Id: 2}}
+	params := &entity.Showtime{ShowtimeData: entity.ShowtimeData{
+		MovieID:   1,
+		ScreenID:  2,
+		StartTime: start,
+		EndTime:   start.Add(117 * time.Minute),
+		Price:     50000,
+	}}
+
+	tests := []struct {
+		name     string
+		want     contract.ShowtimeResponse
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error movie id not found",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error movie runtime not set",
+			wantErr: appErr.ErrMovieRuntimeNotSet,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+			},
+		},
+		{
+			name:    "error screen id not found",
+			wantErr: appErr.ErrScreenIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(entity.Screen{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error overlap",
+			wantErr: appErr.ErrShowtimeOverlap,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(screen, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Create(gomock.Any(), params).Return(int64(0), appErr.ErrShowtimeOverlap).Times(1)
+			},
+		},
+		{
+			name: "success",
+			want: contract.ShowtimeResponse{
+				ID:        3,
+				MovieID:   1,
+				ScreenID:  2,
+				StartTime: start.Format(time.RFC3339),
+				EndTime:   start.Add(117 * time.Minute).Format(time.RFC3339),
+				Price:     50000,
+			},
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(screen, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Create(gomock.Any(), params).Return(int64(3), nil).Times(1)
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(entity.Showtime{
+					ModelID:      entity.ModelID{Id: 3},
+					ShowtimeData: params.ShowtimeData,
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			s := InitShowtimeService(mocks.showtimeRepo, mocks.movieRepo, mocks.cinemaRepo)
+			got, err := s.Create(context.Background(), request)
+			if err != tt.wantErr {
+				t.Errorf("Showtime.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateShowtimeService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	start := time.Date(2024, 1, 1, 21, 0, 0, 0, time.Local)
+	request := contract.ShowtimeRequest{MovieID: 1, ScreenID: 2, StartTime: start, Price: 60000}
+	movie := entity.Movie{ModelID: entity.ModelID{Id: 1}, MovieData: entity.MovieData{Runtime: 90}}
+	screen := entity.Screen{ModelID: entity.ModelID{Id: 2}}
+	existing := entity.Showtime{ModelID: entity.ModelID{Id: 3}}
+	updated := entity.Showtime{ModelID: entity.ModelID{Id: 3}, ShowtimeData: entity.ShowtimeData{
+		MovieID:   1,
+		ScreenID:  2,
+		StartTime: start,
+		EndTime:   start.Add(90 * time.Minute),
+		Price:     60000,
+	}}
+
+	tests := []struct {
+		name     string
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error showtime id not found",
+			wantErr: appErr.ErrShowtimeIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(entity.Showtime{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "error overlap",
+			wantErr: appErr.ErrShowtimeOverlap,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(existing, nil).Times(1)
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(screen, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Update(gomock.Any(), &updated).Return(appErr.ErrShowtimeOverlap).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			wantErr: nil,
+			mockFunc: func(mock mockFields) {
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(existing, nil).Times(1)
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(movie, nil).Times(1)
+				mock.cinemaRepo.EXPECT().GetScreen(gomock.Any(), 2).Return(screen, nil).Times(1)
+				mock.showtimeRepo.EXPECT().Update(gomock.Any(), &updated).Return(nil).Times(1)
+				mock.showtimeRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(updated, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			s := InitShowtimeService(mocks.showtimeRepo, mocks.movieRepo, mocks.cinemaRepo)
+			_, err := s.Update(context.Background(), request, 3)
+			if err != tt.wantErr {
+				t.Errorf("Showtime.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetByMovieShowtimeService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMockFields(ctrl)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	filter := contract.ShowtimeFilter{From: from, To: from.AddDate(0, 0, 1), City: "jakarta"}
+
+	tests := []struct {
+		name     string
+		wantLen  int
+		wantErr  error
+		mockFunc func(mock mockFields)
+	}{
+		{
+			name:    "error movie id not found",
+			wantErr: appErr.ErrMovieIdNotFound,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{}, sql.ErrNoRows).Times(1)
+			},
+		},
+		{
+			name:    "success",
+			wantLen: 2,
+			mockFunc: func(mock mockFields) {
+				mock.movieRepo.EXPECT().Get(gomock.Any(), 1).Return(entity.Movie{ModelID: entity.ModelID{Id: 1}}, nil).Times(1)
+				mock.showtimeRepo.EXPECT().GetByMovie(gomock.Any(), int64(1), filter.From, filter.To, "jakarta").Return([]*entity.Showtime{
+					{ModelID: entity.ModelID{Id: 1}},
+					{ModelID: entity.ModelID{Id: 2}},
+				}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc(mocks)
+
+			s := InitShowtimeService(mocks.showtimeRepo, mocks.movieRepo, mocks.cinemaRepo)
+			got, err := s.GetByMovie(context.Background(), 1, filter)
+			if err != tt.wantErr {
+				t.Errorf("Showtime.GetByMovie() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}